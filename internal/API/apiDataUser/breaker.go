@@ -0,0 +1,146 @@
+package apiDataUser
+
+import (
+	"fmt"
+	"sync"
+	"time"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/logger"
+	"time-tracker/internal/metrics"
+)
+
+// ErrCircuitOpen - запрос не отправлялся, так как сторонний API недавно был недоступен
+var ErrCircuitOpen = fmt.Errorf("%w: API временно недоступен (circuit breaker открыт)", domain.ErrEnrichmentFailed)
+
+// состояние circuit breaker; значения публикуются в метрике enrichment_api_circuit_state
+type breakerState int
+
+const (
+	stateClosed breakerState = iota
+	stateHalfOpen
+	stateOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case stateHalfOpen:
+		return "half-open"
+	case stateOpen:
+		return "open"
+	default:
+		return "closed"
+	}
+}
+
+// результат попытки запроса для circuit breaker
+type result int
+
+const (
+	// API ответил (в том числе 4xx)
+	resultSuccess result = iota
+	// сетевая ошибка, таймаут, 429, 5xx или некорректный ответ
+	resultFailure
+	// запрос не дошел до API по причинам на нашей стороне (например, отменен контекст вызывающего)
+	resultIgnored
+)
+
+// breaker - circuit breaker: после threshold неудач подряд открывается и отклоняет запросы
+// в течение cooldown, затем пропускает один пробный запрос (half-open). Успешная проба
+// закрывает breaker, неудачная снова открывает его на cooldown.
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+	// в состоянии half-open пробный запрос уже отправлен
+	probing bool
+}
+
+// newBreaker создает закрытый circuit breaker; threshold <= 0 выключает его
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	metrics.SetEnrichmentCircuitState(int(stateClosed))
+	return &breaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// allow разрешает попытку запроса или возвращает ErrCircuitOpen.
+// Каждая разрешенная попытка должна завершиться вызовом done.
+func (b *breaker) allow() error {
+	if b.threshold <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case stateOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		b.setState(stateHalfOpen)
+		fallthrough
+	case stateHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// done учитывает результат попытки, разрешенной allow
+func (b *breaker) done(r result) {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == stateHalfOpen {
+		b.probing = false
+	}
+
+	switch r {
+	case resultSuccess:
+		b.failures = 0
+		if b.state != stateClosed {
+			b.setState(stateClosed)
+		}
+	case resultFailure:
+		b.failures++
+		if b.state == stateHalfOpen || (b.state == stateClosed && b.failures >= b.threshold) {
+			b.openedAt = b.now()
+			b.setState(stateOpen)
+		}
+	}
+}
+
+// isOpen сообщает, что breaker открыт и запросы сейчас отклоняются
+func (b *breaker) isOpen() bool {
+	if b.threshold <= 0 {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state == stateOpen && b.now().Sub(b.openedAt) < b.cooldown
+}
+
+func (b *breaker) setState(state breakerState) {
+	switch state {
+	case stateOpen:
+		logger.SugaredLogger().Warnw("Сторонний API недоступен, запросы приостановлены",
+			"from", b.state.String(), "failures", b.failures, "cooldown", b.cooldown)
+	case stateHalfOpen:
+		logger.SugaredLogger().Infow("Пробный запрос к стороннему API", "from", b.state.String())
+	case stateClosed:
+		logger.SugaredLogger().Infow("Сторонний API снова доступен", "from", b.state.String())
+	}
+	b.state = state
+	metrics.SetEnrichmentCircuitState(int(state))
+}
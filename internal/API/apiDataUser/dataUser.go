@@ -1,32 +1,211 @@
 package apiDataUser
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"math/rand/v2"
 	"net/http"
+	"time"
+	"time-tracker/internal/config"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/logger"
+	"time-tracker/internal/metrics"
 	"time-tracker/internal/models"
 )
 
-func GetPeopleInfoFromAPI(series, number, urlAPI string) (*models.UserData, error) {
+// Enricher получает данные пользователя из стороннего API по серии и номеру паспорта
+type Enricher interface {
+	GetPeopleInfo(ctx context.Context, series, number string) (*models.UserData, error)
+	Ping(ctx context.Context) error
+}
+
+// Client - клиент стороннего API (API_URL): каждая попытка ограничена таймаутом, после сетевых
+// ошибок, таймаутов, 429 и 5xx запрос повторяется с растущей паузой, а пока API недоступен,
+// circuit breaker сразу возвращает ErrCircuitOpen, не дожидаясь таймаутов
+type Client struct {
+	url        string
+	http       *http.Client
+	timeout    time.Duration
+	retries    int
+	backoff    time.Duration
+	maxBackoff time.Duration
+	breaker    *breaker
+}
+
+// NewClient создает клиент стороннего API с настройками API_* из conf
+func NewClient(conf *config.Config) *Client {
+	return &Client{
+		url:        conf.API_URL,
+		http:       &http.Client{},
+		timeout:    conf.API_TIMEOUT,
+		retries:    conf.API_RETRIES,
+		backoff:    conf.API_RETRY_BACKOFF,
+		maxBackoff: conf.API_RETRY_MAX_BACKOFF,
+		breaker:    newBreaker(conf.API_BREAKER_THRESHOLD, conf.API_BREAKER_COOLDOWN),
+	}
+}
+
+// GetPeopleInfo запрашивает ФИО и адрес по паспорту. Если API не знает паспорт (404)
+// или отклоняет его (400, 422), возвращается ошибка валидации passportNumber,
+// остальные ошибки оборачивают domain.ErrEnrichmentFailed.
+func (c *Client) GetPeopleInfo(ctx context.Context, series, number string) (*models.UserData, error) {
 	url := fmt.Sprintf("%s/info?passportSerie=%s&passportNumber=%s",
-		urlAPI, series, number)
+		c.url, series, number)
+
+	var err error
+	for attempt := 0; attempt <= c.retries; attempt++ {
+		if attempt > 0 {
+			metrics.EnrichmentRetry()
+			delay := c.backoffDelay(attempt)
+			logger.SugaredLogger().Warnw("Повтор запроса к стороннему API", "attempt", attempt, "delay", delay, "error", err)
+			if err := sleep(ctx, delay); err != nil {
+				return nil, fmt.Errorf("%w: %v", domain.ErrEnrichmentFailed, err)
+			}
+		}
+
+		if err = c.breaker.allow(); err != nil {
+			metrics.EnrichmentRejected()
+			return nil, err
+		}
 
-	resp, err := http.Get(url)
+		var userInfo *models.UserData
+		var retry bool
+		userInfo, retry, err = c.fetch(ctx, url)
+		if err == nil {
+			userInfo.PassportNumber = fmt.Sprintf("%s %s", series, number)
+			return userInfo, nil
+		}
+		if !retry {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+// fetch выполняет одну попытку запроса и сообщает ее результат circuit breaker.
+// retry - стоит ли повторить запрос.
+func (c *Client) fetch(ctx context.Context, url string) (*models.UserData, bool, error) {
+	attemptCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(attemptCtx, http.MethodGet, url, nil)
+	if err != nil {
+		c.breaker.done(resultIgnored)
+		return nil, false, fmt.Errorf("%w: ошибка создания запроса к API: %v", domain.ErrEnrichmentFailed, err)
+	}
+
+	start := time.Now()
+	resp, err := c.http.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка HTTP запроса к API: %v", err)
+		metrics.ObserveEnrichment(start, metrics.EnrichmentErrorRequest)
+		// вызывающий отменил запрос или исчерпал свое время - API тут ни при чем
+		if ctx.Err() != nil {
+			c.breaker.done(resultIgnored)
+			return nil, false, fmt.Errorf("%w: ошибка HTTP запроса к API: %v", domain.ErrEnrichmentFailed, err)
+		}
+		c.breaker.done(resultFailure)
+		return nil, true, fmt.Errorf("%w: ошибка HTTP запроса к API: %v", domain.ErrEnrichmentFailed, err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("неправильный статус код API: %d", resp.StatusCode)
+	switch {
+	case resp.StatusCode == http.StatusOK:
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError:
+		metrics.ObserveEnrichment(start, metrics.EnrichmentErrorStatus)
+		c.breaker.done(resultFailure)
+		return nil, true, fmt.Errorf("%w: неправильный статус код API: %d", domain.ErrEnrichmentFailed, resp.StatusCode)
+	case resp.StatusCode == http.StatusNotFound:
+		metrics.ObserveEnrichment(start, metrics.EnrichmentErrorStatus)
+		c.breaker.done(resultSuccess)
+		return nil, false, domain.NewValidationError("passportNumber", "not found by enrichment API")
+	case resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnprocessableEntity:
+		metrics.ObserveEnrichment(start, metrics.EnrichmentErrorStatus)
+		c.breaker.done(resultSuccess)
+		return nil, false, domain.NewValidationError("passportNumber", "rejected by enrichment API")
+	default:
+		metrics.ObserveEnrichment(start, metrics.EnrichmentErrorStatus)
+		c.breaker.done(resultSuccess)
+		return nil, false, fmt.Errorf("%w: неправильный статус код API: %d", domain.ErrEnrichmentFailed, resp.StatusCode)
 	}
 
 	var userInfo models.UserData
 	err = json.NewDecoder(resp.Body).Decode(&userInfo)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка декодирования JSON: %v", err)
+		metrics.ObserveEnrichment(start, metrics.EnrichmentErrorDecode)
+		c.breaker.done(resultFailure)
+		return nil, false, fmt.Errorf("%w: ошибка декодирования JSON: %v", domain.ErrEnrichmentFailed, err)
+	}
+	metrics.ObserveEnrichment(start, "")
+	c.breaker.done(resultSuccess)
+
+	return &userInfo, false, nil
+}
+
+// Ping проверяет, что сторонний API отвечает. Ответ 4xx считается нормальным,
+// так как запрос отправляется без параметров паспорта. Пока circuit breaker открыт,
+// возвращается ErrCircuitOpen без запроса к API; результат проверки на breaker не влияет.
+func (c *Client) Ping(ctx context.Context) error {
+	if c.breaker.isOpen() {
+		return ErrCircuitOpen
+	}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+"/info", nil)
+	if err != nil {
+		return fmt.Errorf("%w: ошибка создания запроса к API: %v", domain.ErrEnrichmentFailed, err)
 	}
-	userInfo.PassportNumber = fmt.Sprintf("%s %s", series, number)
 
-	return &userInfo, nil
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: ошибка HTTP запроса к API: %v", domain.ErrEnrichmentFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%w: неправильный статус код API: %d", domain.ErrEnrichmentFailed, resp.StatusCode)
+	}
+	return nil
+}
+
+func (c *Client) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+// backoffDelay - пауза перед повтором attempt: backoff, удвоенный с каждой попыткой,
+// не больше maxBackoff, из которой случайно выбирается значение от половины до целой,
+// чтобы повторы разных запросов не приходили в API одновременно
+func (c *Client) backoffDelay(attempt int) time.Duration {
+	delay := c.backoff
+	for i := 1; i < attempt && (c.maxBackoff <= 0 || delay < c.maxBackoff); i++ {
+		delay *= 2
+	}
+	if c.maxBackoff > 0 && delay > c.maxBackoff {
+		delay = c.maxBackoff
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// sleep ждет d или отмены ctx
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
@@ -0,0 +1,176 @@
+package apiDataUser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+	"time-tracker/internal/config"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/logger"
+)
+
+// newTestAPI имитирует сторонний API: statuses - коды ответов на очередные запросы,
+// после их исчерпания API отвечает 200 с данными пользователя
+func newTestAPI(t *testing.T, delay time.Duration, statuses ...int) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1))
+		if delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if n <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"surname": "Иванов", "name": "Иван", "patronymic": "Иванович", "address": "г. Москва"}`)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func newTestClient(url string, retries, threshold int) *Client {
+	return NewClient(&config.Config{
+		API_URL:               url,
+		API_TIMEOUT:           time.Second,
+		API_RETRIES:           retries,
+		API_RETRY_BACKOFF:     time.Millisecond,
+		API_RETRY_MAX_BACKOFF: 5 * time.Millisecond,
+		API_BREAKER_THRESHOLD: threshold,
+		API_BREAKER_COOLDOWN:  time.Minute,
+	})
+}
+
+func TestGetPeopleInfo(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	tests := []struct {
+		name       string
+		statuses   []int
+		retries    int
+		wantCalls  int32
+		wantErr    error
+		validation bool
+	}{
+		{name: "#1 Успешный запрос", wantCalls: 1},
+		{name: "#2 Успех после повторов 5xx", statuses: []int{503, 500}, retries: 2, wantCalls: 3},
+		{name: "#3 Повтор после 429", statuses: []int{429}, retries: 1, wantCalls: 2},
+		{name: "#4 Повторы исчерпаны", statuses: []int{502, 502, 502}, retries: 2, wantCalls: 3, wantErr: domain.ErrEnrichmentFailed},
+		{name: "#5 Паспорт не найден - без повторов", statuses: []int{404}, retries: 2, wantCalls: 1, validation: true},
+		{name: "#6 Паспорт отклонен - без повторов", statuses: []int{400}, retries: 2, wantCalls: 1, validation: true},
+		{name: "#7 Прочие 4xx - без повторов", statuses: []int{403}, retries: 2, wantCalls: 1, wantErr: domain.ErrEnrichmentFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := newTestAPI(t, 0, tt.statuses...)
+			client := newTestClient(server.URL, tt.retries, 10)
+
+			user, err := client.GetPeopleInfo(context.Background(), "1234", "567890")
+			assert.Equal(t, tt.wantCalls, calls.Load())
+
+			switch {
+			case tt.validation:
+				var validationErr *domain.ValidationError
+				assert.True(t, errors.As(err, &validationErr), err)
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, "1234 567890", user.PassportNumber)
+				assert.Equal(t, "Иванов", user.Surname)
+			}
+		})
+	}
+}
+
+func TestGetPeopleInfoTimeout(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	server, calls := newTestAPI(t, time.Second)
+	client := newTestClient(server.URL, 1, 10)
+	client.timeout = 20 * time.Millisecond
+
+	start := time.Now()
+	_, err := client.GetPeopleInfo(context.Background(), "1234", "567890")
+	assert.ErrorIs(t, err, domain.ErrEnrichmentFailed)
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	server, calls := newTestAPI(t, 0, 500, 500, 500)
+	client := newTestClient(server.URL, 0, 2)
+	now := time.Now()
+	client.breaker.now = func() time.Time { return now }
+
+	// две неудачи подряд открывают breaker
+	for i := 0; i < 2; i++ {
+		_, err := client.GetPeopleInfo(context.Background(), "1234", "567890")
+		assert.ErrorIs(t, err, domain.ErrEnrichmentFailed)
+	}
+	assert.Equal(t, int32(2), calls.Load())
+
+	// пока breaker открыт, запросы к API не отправляются
+	_, err := client.GetPeopleInfo(context.Background(), "1234", "567890")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.ErrorIs(t, err, domain.ErrEnrichmentFailed)
+	assert.ErrorIs(t, client.Ping(context.Background()), ErrCircuitOpen)
+	assert.Equal(t, int32(2), calls.Load())
+
+	// неудачная проба после cooldown снова открывает breaker
+	now = now.Add(time.Minute)
+	_, err = client.GetPeopleInfo(context.Background(), "1234", "567890")
+	assert.NotErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(3), calls.Load())
+	_, err = client.GetPeopleInfo(context.Background(), "1234", "567890")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+
+	// успешная проба закрывает breaker
+	now = now.Add(time.Minute)
+	_, err = client.GetPeopleInfo(context.Background(), "1234", "567890")
+	assert.NoError(t, err)
+	_, err = client.GetPeopleInfo(context.Background(), "1234", "567890")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(5), calls.Load())
+	assert.Equal(t, stateClosed, client.breaker.state)
+}
+
+func TestCircuitBreakerIgnoresCanceledCalls(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	server, _ := newTestAPI(t, time.Second)
+	client := newTestClient(server.URL, 2, 1)
+
+	// вызывающий исчерпал свое время - это не отказ API, повторов и открытия breaker нет
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.GetPeopleInfo(ctx, "1234", "567890")
+	assert.ErrorIs(t, err, domain.ErrEnrichmentFailed)
+	assert.Equal(t, stateClosed, client.breaker.state)
+	assert.False(t, client.breaker.isOpen())
+}
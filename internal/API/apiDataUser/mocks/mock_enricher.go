@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/API/apiDataUser/dataUser.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "time-tracker/internal/models"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEnricher is a mock of Enricher interface.
+type MockEnricher struct {
+	ctrl     *gomock.Controller
+	recorder *MockEnricherMockRecorder
+}
+
+// MockEnricherMockRecorder is the mock recorder for MockEnricher.
+type MockEnricherMockRecorder struct {
+	mock *MockEnricher
+}
+
+// NewMockEnricher creates a new mock instance.
+func NewMockEnricher(ctrl *gomock.Controller) *MockEnricher {
+	mock := &MockEnricher{ctrl: ctrl}
+	mock.recorder = &MockEnricherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEnricher) EXPECT() *MockEnricherMockRecorder {
+	return m.recorder
+}
+
+// GetPeopleInfo mocks base method.
+func (m *MockEnricher) GetPeopleInfo(ctx context.Context, series, number string) (*models.UserData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPeopleInfo", ctx, series, number)
+	ret0, _ := ret[0].(*models.UserData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPeopleInfo indicates an expected call of GetPeopleInfo.
+func (mr *MockEnricherMockRecorder) GetPeopleInfo(ctx, series, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPeopleInfo", reflect.TypeOf((*MockEnricher)(nil).GetPeopleInfo), ctx, series, number)
+}
+
+// Ping mocks base method.
+func (m *MockEnricher) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockEnricherMockRecorder) Ping(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockEnricher)(nil).Ping), ctx)
+}
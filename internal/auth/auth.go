@@ -0,0 +1,88 @@
+// Package auth выпускает и проверяет токены доступа и хранит аутентифицированного пользователя в контексте запроса
+package auth
+
+import (
+	"context"
+	"time-tracker/internal/domain"
+)
+
+// роли пользователей (таблица roles); что разрешено каждой роли, решает политика доступа в usecase
+const (
+	RoleMember  = "member"
+	RoleManager = "manager"
+	RoleAdmin   = "admin"
+)
+
+// Roles - все роли в порядке расширения прав
+var Roles = []string{RoleMember, RoleManager, RoleAdmin}
+
+// ValidRole сообщает, известна ли роль
+func ValidRole(role string) bool {
+	for _, r := range Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// права ключей API; совпадают с действиями политики доступа, которые можно доверить ключу
+const (
+	ScopeTasksRead   = "tasks:read"
+	ScopeTasksWrite  = "tasks:write"
+	ScopeReportsRead = "reports:read"
+	ScopeCatalogRead = "catalog:read"
+)
+
+// Scopes - все права, которые можно выдать ключу API
+var Scopes = []string{ScopeTasksRead, ScopeTasksWrite, ScopeReportsRead, ScopeCatalogRead}
+
+// ValidScope сообщает, можно ли выдать право ключу API
+func ValidScope(scope string) bool {
+	for _, s := range Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Principal - аутентифицированный пользователь, от имени которого выполняется запрос
+type Principal struct {
+	UserID int
+	Role   string
+	// Scopes ограничивает запрос по ключу API его правами; nil - вход по логину, права определяет только роль
+	Scopes []string
+}
+
+// Allows сообщает, разрешено ли действие scope правами ключа API
+func (p Principal) Allows(scope string) bool {
+	if p.Scopes == nil {
+		return true
+	}
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// System - пользователь для подкоманд бинарника (import и т.п.), которые запускает администратор сервера
+var System = Principal{Role: RoleAdmin}
+
+type principalKey struct{}
+
+// WithPrincipal возвращает контекст с аутентифицированным пользователем
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext возвращает пользователя из контекста или ErrUnauthorized, если запрос не аутентифицирован
+func FromContext(ctx context.Context) (Principal, error) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	if !ok {
+		return Principal{}, domain.ErrUnauthorized
+	}
+	return p, nil
+}
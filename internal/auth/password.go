@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// dummyHash сравнивается с паролем, когда логин не найден, чтобы время ответа
+// не выдавало, существует ли пользователь
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("time-tracker"), bcrypt.DefaultCost)
+
+// HashPassword возвращает bcrypt-хеш пароля для хранения в БД
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// CheckPassword сравнивает пароль с bcrypt-хешем; пустой hash означает, что пароль не задан
+func CheckPassword(hash, password string) (bool, error) {
+	if hash == "" {
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return false, nil
+	}
+
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	return err == nil, err
+}
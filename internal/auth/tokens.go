@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"strconv"
+	"time"
+	"time-tracker/internal/domain"
+)
+
+const (
+	// MinSecretLength - минимальная длина ключа подписи HS256 в байтах
+	MinSecretLength = 32
+	// issuer - издатель в токенах доступа
+	issuer = "time-tracker"
+	// refreshTokenSize - длина refresh-токена в байтах (до кодирования в base64)
+	refreshTokenSize = 32
+)
+
+// Tokens подписывает и проверяет токены доступа (JWT, HS256) и выпускает refresh-токены
+type Tokens struct {
+	secret     []byte
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+// claims - содержимое токена доступа: sub - ID пользователя, role - роль
+type claims struct {
+	Role string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// NewTokens проверяет ключ подписи (не короче MinSecretLength) и время жизни токенов
+func NewTokens(secret string, accessTTL, refreshTTL time.Duration) (*Tokens, error) {
+	if len(secret) < MinSecretLength {
+		return nil, fmt.Errorf("ключ подписи токенов должен быть не короче %d байт", MinSecretLength)
+	}
+	if accessTTL <= 0 || refreshTTL <= 0 {
+		return nil, errors.New("время жизни токенов должно быть положительным")
+	}
+	return &Tokens{secret: []byte(secret), accessTTL: accessTTL, refreshTTL: refreshTTL}, nil
+}
+
+// AccessTTL - время жизни токена доступа
+func (t *Tokens) AccessTTL() time.Duration {
+	return t.accessTTL
+}
+
+// RefreshTTL - время жизни refresh-токена
+func (t *Tokens) RefreshTTL() time.Duration {
+	return t.refreshTTL
+}
+
+// IssueAccessToken подписывает токен доступа пользователя p
+func (t *Tokens) IssueAccessToken(p Principal, now time.Time) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		Role: p.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Subject:   strconv.Itoa(p.UserID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(t.accessTTL)),
+		},
+	})
+	return token.SignedString(t.secret)
+}
+
+// ParseAccessToken проверяет подпись и срок действия токена доступа.
+// Любая ошибка проверки возвращается как ErrUnauthorized.
+func (t *Tokens) ParseAccessToken(token string) (Principal, error) {
+	var c claims
+	_, err := jwt.ParseWithClaims(token, &c, func(*jwt.Token) (interface{}, error) {
+		return t.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: %v", domain.ErrUnauthorized, err)
+	}
+
+	userID, err := strconv.Atoi(c.Subject)
+	if err != nil || userID <= 0 {
+		return Principal{}, fmt.Errorf("%w: некорректный sub", domain.ErrUnauthorized)
+	}
+	return Principal{UserID: userID, Role: c.Role}, nil
+}
+
+// NewRefreshToken возвращает случайный refresh-токен и его хеш для хранения в БД
+func NewRefreshToken() (string, string, error) {
+	raw := make([]byte, refreshTokenSize)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	return token, HashToken(token), nil
+}
+
+// HashToken - хеш секретного токена (refresh-токена, ключа API, токена календаря).
+// В БД хранится только хеш, по нему же токен ищется.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"errors"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+	"time-tracker/internal/domain"
+)
+
+func newTestTokens(t *testing.T, secret string) *Tokens {
+	tokens, err := NewTokens(secret, 15*time.Minute, time.Hour)
+	require.NoError(t, err)
+	return tokens
+}
+
+func TestNewTokens(t *testing.T) {
+	_, err := NewTokens(strings.Repeat("s", MinSecretLength-1), time.Minute, time.Hour)
+	assert.Error(t, err)
+
+	_, err = NewTokens(strings.Repeat("s", MinSecretLength), 0, time.Hour)
+	assert.Error(t, err)
+
+	_, err = NewTokens(strings.Repeat("s", MinSecretLength), time.Minute, time.Hour)
+	assert.NoError(t, err)
+}
+
+func TestAccessToken(t *testing.T) {
+	tokens := newTestTokens(t, strings.Repeat("s", MinSecretLength))
+	user := Principal{UserID: 7, Role: RoleMember}
+
+	token, err := tokens.IssueAccessToken(user, time.Now())
+	require.NoError(t, err)
+
+	p, err := tokens.ParseAccessToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, user, p)
+
+	hs256 := func(c jwt.Claims, secret string) string {
+		signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString([]byte(secret))
+		require.NoError(t, err)
+		return signed
+	}
+	valid := func() jwt.RegisteredClaims {
+		return jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Subject:   "7",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		}
+	}
+
+	expired, err := tokens.IssueAccessToken(user, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+
+	noExp := valid()
+	noExp.ExpiresAt = nil
+	otherIssuer := valid()
+	otherIssuer.Issuer = "other"
+	badSubject := valid()
+	badSubject.Subject = "ivanov"
+
+	// alg none не должен приниматься, даже если подпись "совпадает"
+	none, err := jwt.NewWithClaims(jwt.SigningMethodNone, claims{Role: RoleAdmin, RegisteredClaims: valid()}).
+		SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "#1 Истекший токен", token: expired},
+		{name: "#2 Другой ключ", token: hs256(claims{Role: RoleAdmin, RegisteredClaims: valid()}, strings.Repeat("x", MinSecretLength))},
+		{name: "#3 Алгоритм none", token: none},
+		{name: "#4 Без срока действия", token: hs256(claims{Role: RoleAdmin, RegisteredClaims: noExp}, strings.Repeat("s", MinSecretLength))},
+		{name: "#5 Другой издатель", token: hs256(claims{Role: RoleAdmin, RegisteredClaims: otherIssuer}, strings.Repeat("s", MinSecretLength))},
+		{name: "#6 Некорректный sub", token: hs256(claims{Role: RoleAdmin, RegisteredClaims: badSubject}, strings.Repeat("s", MinSecretLength))},
+		{name: "#7 Мусор", token: "not.a.token"},
+		{name: "#8 Подделанная подпись", token: token[:strings.LastIndex(token, ".")] + ".AAAA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tokens.ParseAccessToken(tt.token)
+			assert.True(t, errors.Is(err, domain.ErrUnauthorized), err)
+		})
+	}
+}
+
+func TestRefreshToken(t *testing.T) {
+	token, hash, err := NewRefreshToken()
+	require.NoError(t, err)
+	assert.Equal(t, HashToken(token), hash)
+	assert.NotEqual(t, token, hash)
+
+	other, _, err := NewRefreshToken()
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
+
+func TestPassword(t *testing.T) {
+	hash, err := HashPassword("password1")
+	require.NoError(t, err)
+
+	ok, err := CheckPassword(hash, "password1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = CheckPassword(hash, "password2")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// пароль не задан
+	ok, err = CheckPassword("", "password1")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time-tracker/internal/auth"
+	"time-tracker/internal/config"
+	"time-tracker/internal/models"
+	"time-tracker/internal/storage/postgres"
+	"time-tracker/internal/usecase"
+	"time-tracker/internal/validator"
+)
+
+// Credentials задает пользователю логин, пароль и, если указана, роль. Так создается первый администратор,
+// пока войти в API еще некому: с -passport вместо -user пользователь сначала создается.
+// Пароль читается из первой строки stdin, чтобы не попадать в историю команд:
+//
+//	echo "$PASSWORD" | time-tracker credentials (-user 1 | -passport "1234 567890") -login admin [-role admin]
+//
+// Подключение к БД берется из той же конфигурации, что и у сервера (--config, CONFIG_FILE или .env).
+func Credentials(conf *config.Config, args []string, in io.Reader, out io.Writer) error {
+	flags := flag.NewFlagSet("credentials", flag.ContinueOnError)
+	userID := flags.Int("user", 0, "ID пользователя")
+	passport := flags.String("passport", "", "серия и номер паспорта нового пользователя (вместо -user)")
+	login := flags.String("login", "", "логин")
+	role := flags.String("role", "", "роль: member, manager или admin (по умолчанию не меняется)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if (*userID <= 0) == (*passport == "") || *login == "" {
+		flags.Usage()
+		return errors.New("нужно указать -login и либо -user, либо -passport")
+	}
+	if *passport != "" {
+		if _, _, err := validator.ValidatePassport("passport", *passport); err != nil {
+			return err
+		}
+	}
+
+	password, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	password = strings.TrimRight(password, "\r\n")
+	if err := validator.ValidateCredentials(*login, password); err != nil {
+		return err
+	}
+
+	db, err := postgres.NewPostgresStorage(conf)
+	if err != nil {
+		return fmt.Errorf("подключение к БД: %w", err)
+	}
+	defer db.Close()
+
+	ctx := auth.WithPrincipal(context.Background(), auth.System)
+	uc := usecase.NewUseCaseStorage(db, nil)
+	if *passport != "" {
+		if *userID, err = uc.UseCaseCreate(ctx, models.UserData{PassportNumber: *passport}); err != nil {
+			return err
+		}
+	}
+	if err := uc.UseCaseSetCredentials(ctx, *userID, models.Credentials{Login: *login, Password: password}); err != nil {
+		return err
+	}
+	if *role != "" {
+		if err := uc.UseCaseSetUserRole(ctx, *userID, *role); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(out, "учетные данные пользователя %d сохранены\n", *userID)
+	return nil
+}
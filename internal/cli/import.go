@@ -0,0 +1,67 @@
+// Package cli содержит подкоманды бинарника, которые выполняются вместо запуска сервера
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time-tracker/internal/auth"
+	"time-tracker/internal/config"
+	"time-tracker/internal/importer"
+	"time-tracker/internal/storage/postgres"
+	"time-tracker/internal/usecase"
+)
+
+// Import загружает задачи пользователя из файла iCalendar или CSV и печатает итог в out в формате JSON:
+//
+//	time-tracker import -user 1 -file export.ics [-format ics|csv] [-dry-run]
+//
+// Подключение к БД берется из той же конфигурации, что и у сервера (--config, CONFIG_FILE или .env).
+func Import(conf *config.Config, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	userID := flags.Int("user", 0, "ID пользователя")
+	path := flags.String("file", "", "путь к файлу .ics или .csv")
+	format := flags.String("format", "", "формат файла: ics или csv (по умолчанию - по расширению)")
+	dryRun := flags.Bool("dry-run", false, "только проверить файл, ничего не сохраняя")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *userID <= 0 || *path == "" {
+		flags.Usage()
+		return errors.New("нужно указать -user и -file")
+	}
+	if *format == "" {
+		*format = importer.FormatFromContentType("", filepath.Base(*path))
+		if *format == "" {
+			return fmt.Errorf("не удалось определить формат файла %s, укажите -format", *path)
+		}
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	db, err := postgres.NewPostgresStorage(conf)
+	if err != nil {
+		return fmt.Errorf("подключение к БД: %w", err)
+	}
+	defer db.Close()
+
+	ctx := auth.WithPrincipal(context.Background(), auth.System)
+	result, err := usecase.NewUseCaseStorage(db, nil).UseCaseImportTasks(ctx, *userID, *format, file, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(result)
+}
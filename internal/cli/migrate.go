@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"github.com/golang-migrate/migrate/v4"
+	"io"
+	"strconv"
+	"time-tracker/internal/config"
+	"time-tracker/internal/storage/postgres"
+)
+
+const migrateUsage = `использование: time-tracker migrate <команда>
+  up         применить все новые миграции
+  down N     откатить N последних миграций
+  goto V     перейти к версии V (вверх или вниз)
+  version    показать текущую версию
+  force V    записать версию V без выполнения миграций и снять признак dirty`
+
+// Migrate управляет схемой БД встроенными миграциями и печатает в out версию после выполнения команды:
+//
+//	time-tracker migrate up | down N | goto V | version | force V
+//
+// Подключение к БД берется из той же конфигурации, что и у сервера (--config, CONFIG_FILE или .env).
+func Migrate(conf *config.Config, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+	command, args := args[0], args[1:]
+
+	var run func(m *migrate.Migrate) error
+	switch command {
+	case "up":
+		if len(args) != 0 {
+			return errors.New(migrateUsage)
+		}
+		run = func(m *migrate.Migrate) error { return m.Up() }
+	case "down":
+		// откат без N удалил бы все данные, поэтому количество шагов обязательно
+		n, err := migrateArg(args, "N")
+		if err != nil {
+			return err
+		}
+		run = func(m *migrate.Migrate) error { return m.Steps(-int(n)) }
+	case "goto":
+		v, err := migrateArg(args, "V")
+		if err != nil {
+			return err
+		}
+		run = func(m *migrate.Migrate) error { return m.Migrate(v) }
+	case "force":
+		v, err := migrateArg(args, "V")
+		if err != nil {
+			return err
+		}
+		run = func(m *migrate.Migrate) error { return m.Force(int(v)) }
+	case "version":
+		if len(args) != 0 {
+			return errors.New(migrateUsage)
+		}
+		run = func(m *migrate.Migrate) error { return nil }
+	default:
+		return fmt.Errorf("неизвестная команда %q\n%s", command, migrateUsage)
+	}
+
+	m, err := postgres.OpenMigrate(conf)
+	if err != nil {
+		return fmt.Errorf("подключение к БД: %w", err)
+	}
+	defer m.Close()
+
+	if err := run(m); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+
+	version, dirty, err := m.Version()
+	switch {
+	case errors.Is(err, migrate.ErrNilVersion):
+		fmt.Fprintln(out, "миграции не применены")
+		return nil
+	case err != nil:
+		return err
+	}
+	fmt.Fprintf(out, "версия: %d, dirty: %t\n", version, dirty)
+	return nil
+}
+
+// migrateArg разбирает единственный аргумент команды - положительное число
+func migrateArg(args []string, name string) (uint, error) {
+	if len(args) != 1 {
+		return 0, errors.New(migrateUsage)
+	}
+	value, err := strconv.ParseUint(args[0], 10, 32)
+	if err != nil || value == 0 {
+		return 0, fmt.Errorf("%s должно быть положительным числом: %q", name, args[0])
+	}
+	return uint(value), nil
+}
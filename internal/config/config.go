@@ -1,9 +1,17 @@
 package config
 
 import (
+	"fmt"
 	"github.com/caarlos0/env/v6"
+	"github.com/joho/godotenv"
+	"os"
+	"time"
 )
 
+// DefaultConfigFile - файл переменных окружения, который читается из текущего каталога,
+// если файл не указан явно; его отсутствие не ошибка
+const DefaultConfigFile = ".env"
+
 type Config struct {
 	DB_HOST     string `env:"DB_HOST"`
 	DB_PORT     string `env:"DB_PORT"`
@@ -13,6 +21,75 @@ type Config struct {
 	SERVER_PORT string `env:"SERVER_PORT"`
 	SERVER_HOST string `env:"SERVER_HOST"`
 	API_URL     string `env:"API_URL"`
+	// API_TIMEOUT - предельное время одной попытки запроса к стороннему API; 0 - без отдельного таймаута
+	API_TIMEOUT time.Duration `env:"API_TIMEOUT" envDefault:"3s"`
+	// API_RETRIES - сколько раз повторить запрос к API после сетевой ошибки, таймаута, 429 или 5xx;
+	// пауза перед повтором растет от API_RETRY_BACKOFF вдвое с каждой попыткой (со случайным разбросом),
+	// но не больше API_RETRY_MAX_BACKOFF
+	API_RETRIES           int           `env:"API_RETRIES" envDefault:"2"`
+	API_RETRY_BACKOFF     time.Duration `env:"API_RETRY_BACKOFF" envDefault:"200ms"`
+	API_RETRY_MAX_BACKOFF time.Duration `env:"API_RETRY_MAX_BACKOFF" envDefault:"2s"`
+	// после API_BREAKER_THRESHOLD неудачных попыток подряд запросы к API не отправляются
+	// в течение API_BREAKER_COOLDOWN, затем пропускается один пробный запрос; 0 - circuit breaker выключен
+	API_BREAKER_THRESHOLD int           `env:"API_BREAKER_THRESHOLD" envDefault:"5"`
+	API_BREAKER_COOLDOWN  time.Duration `env:"API_BREAKER_COOLDOWN" envDefault:"30s"`
+	// ENRICHMENT_WORKERS - сколько обработчиков очереди обогащения запускает сервер; 0 - очередь
+	// обрабатывают другие экземпляры. Свободный обработчик проверяет очередь раз в ENRICHMENT_POLL_INTERVAL.
+	ENRICHMENT_WORKERS       int           `env:"ENRICHMENT_WORKERS" envDefault:"1"`
+	ENRICHMENT_POLL_INTERVAL time.Duration `env:"ENRICHMENT_POLL_INTERVAL" envDefault:"2s"`
+	// ENRICHMENT_JOB_TIMEOUT - предельное время обработки одной задачи, включая повторы запроса к API;
+	// задачу, не завершенную за это время и еще 30 секунд на запись результата (например, после падения
+	// сервера), возьмет другой обработчик
+	ENRICHMENT_JOB_TIMEOUT time.Duration `env:"ENRICHMENT_JOB_TIMEOUT" envDefault:"30s"`
+	// после ENRICHMENT_MAX_ATTEMPTS неудачных попыток обогащение отмечается failed; пауза между
+	// попытками растет от ENRICHMENT_RETRY_DELAY вдвое, но не больше ENRICHMENT_RETRY_MAX_DELAY
+	ENRICHMENT_MAX_ATTEMPTS    int           `env:"ENRICHMENT_MAX_ATTEMPTS" envDefault:"10"`
+	ENRICHMENT_RETRY_DELAY     time.Duration `env:"ENRICHMENT_RETRY_DELAY" envDefault:"30s"`
+	ENRICHMENT_RETRY_MAX_DELAY time.Duration `env:"ENRICHMENT_RETRY_MAX_DELAY" envDefault:"30m"`
+	// AUTO_MIGRATE - применять новые миграции при старте; если выключено, миграции
+	// применяются отдельным шагом: time-tracker migrate up
+	AUTO_MIGRATE bool `env:"AUTO_MIGRATE" envDefault:"true"`
+	// LOG_FILE - файл, в который дублируются логи; пусто - только stdout
+	LOG_FILE string `env:"LOG_FILE"`
+	// JWT_SECRET - ключ подписи токенов доступа (HS256), не короче 32 байт; без него сервер не стартует
+	JWT_SECRET string `env:"JWT_SECRET"`
+	// время жизни токена доступа и refresh-токена
+	ACCESS_TOKEN_TTL  time.Duration `env:"ACCESS_TOKEN_TTL" envDefault:"15m"`
+	REFRESH_TOKEN_TTL time.Duration `env:"REFRESH_TOKEN_TTL" envDefault:"720h"`
+	// READINESS_CHECK_API включает проверку стороннего API в /readyz
+	READINESS_CHECK_API bool `env:"READINESS_CHECK_API" envDefault:"false"`
+
+	// REQUEST_TIMEOUT - предельное время обработки одного запроса, включая запросы к БД и стороннему API
+	REQUEST_TIMEOUT time.Duration `env:"REQUEST_TIMEOUT" envDefault:"10s"`
+	// EXPORT_TIMEOUT - предельное время выгрузки списка задач или отчета в CSV и XLSX вместо REQUEST_TIMEOUT;
+	// на время выгрузки продлевается и SERVER_WRITE_TIMEOUT
+	EXPORT_TIMEOUT time.Duration `env:"EXPORT_TIMEOUT" envDefault:"10m"`
+
+	// таймауты http.Server; SERVER_WRITE_TIMEOUT должен быть больше REQUEST_TIMEOUT
+	SERVER_READ_TIMEOUT  time.Duration `env:"SERVER_READ_TIMEOUT" envDefault:"15s"`
+	SERVER_WRITE_TIMEOUT time.Duration `env:"SERVER_WRITE_TIMEOUT" envDefault:"30s"`
+	SERVER_IDLE_TIMEOUT  time.Duration `env:"SERVER_IDLE_TIMEOUT" envDefault:"60s"`
+	// SHUTDOWN_TIMEOUT - сколько ждать завершения активных запросов и обработчиков очереди обогащения после SIGINT/SIGTERM
+	SHUTDOWN_TIMEOUT time.Duration `env:"SHUTDOWN_TIMEOUT" envDefault:"15s"`
+}
+
+// Load читает конфигурацию из переменных окружения, предварительно загрузив их из файла:
+// configFile (флаг --config), иначе из CONFIG_FILE, иначе из .env текущего каталога, если он есть.
+// Переменные, уже заданные в окружении, важнее значений из файла.
+func Load(configFile string) (*Config, error) {
+	if configFile == "" {
+		configFile = os.Getenv("CONFIG_FILE")
+	}
+
+	if configFile != "" {
+		if err := godotenv.Load(configFile); err != nil {
+			return nil, fmt.Errorf("файл конфигурации %s: %w", configFile, err)
+		}
+	} else if err := godotenv.Load(DefaultConfigFile); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("файл конфигурации %s: %w", DefaultConfigFile, err)
+	}
+
+	return ParseConfigServer()
 }
 
 func ParseConfigServer() (*Config, error) {
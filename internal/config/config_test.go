@@ -0,0 +1,87 @@
+package config
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// unsetEnv убирает переменную на время теста; после теста t.Setenv вернет прежнее значение
+func unsetEnv(t *testing.T, key string) {
+	t.Helper()
+	t.Setenv(key, "")
+	os.Unsetenv(key)
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "time-tracker.env")
+	require.NoError(t, os.WriteFile(file, []byte("SERVER_PORT=9090\nLOG_FILE=/var/log/time-tracker.log\nAUTO_MIGRATE=false\n"), 0o600))
+
+	tests := []struct {
+		name       string
+		configFile string
+		env        map[string]string
+		wantPort   string
+		wantErr    bool
+	}{
+		{
+			name:       "#1 Файл из флага",
+			configFile: file,
+			wantPort:   "9090",
+		},
+		{
+			name:     "#2 Файл из CONFIG_FILE",
+			env:      map[string]string{"CONFIG_FILE": file},
+			wantPort: "9090",
+		},
+		{
+			name:       "#3 Окружение важнее файла",
+			configFile: file,
+			env:        map[string]string{"SERVER_PORT": "8081"},
+			wantPort:   "8081",
+		},
+		{
+			name:       "#4 Указанного файла нет",
+			configFile: filepath.Join(dir, "missing.env"),
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"CONFIG_FILE", "SERVER_PORT", "LOG_FILE", "AUTO_MIGRATE"} {
+				unsetEnv(t, key)
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			conf, err := Load(tt.configFile)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPort, conf.SERVER_PORT)
+			assert.Equal(t, "/var/log/time-tracker.log", conf.LOG_FILE)
+			assert.False(t, conf.AUTO_MIGRATE)
+		})
+	}
+}
+
+// без файла конфигурации в текущем каталоге используются только окружение и значения по умолчанию
+func TestLoadWithoutFile(t *testing.T) {
+	unsetEnv(t, "CONFIG_FILE")
+	unsetEnv(t, "AUTO_MIGRATE")
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	conf, err := Load("")
+	require.NoError(t, err)
+	assert.True(t, conf.AUTO_MIGRATE)
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Ошибки предметной области. Хранилище и внешние клиенты оборачивают их через %w,
+// а хендлеры выбирают код ответа через errors.Is, не опираясь на текст сообщения.
+var (
+	ErrUserNotFound       = errors.New("пользователь не найден")
+	ErrTaskNotFound       = errors.New("задача не найдена")
+	ErrDuplicatePassport  = errors.New("пользователь с таким номером паспорта уже существует")
+	ErrAlreadyStarted     = errors.New("время старта задачи уже установлено")
+	ErrNotStarted         = errors.New("задача еще не запущена")
+	ErrAlreadyFinished    = errors.New("задача уже завершена")
+	ErrAlreadyPaused      = errors.New("задача уже приостановлена")
+	ErrAlreadyRunning     = errors.New("задача уже выполняется")
+	ErrEnrichmentFailed   = errors.New("ошибка запроса к стороннему API")
+	ErrTimeOverlap        = errors.New("интервал пересекается с другими задачами пользователя")
+	ErrTaskRunning        = errors.New("задача выполняется, ее нужно приостановить или завершить")
+	ErrInvalidToken       = errors.New("неверный или отозванный токен доступа")
+	ErrClientNotFound     = errors.New("клиент не найден")
+	ErrProjectNotFound    = errors.New("проект не найден")
+	ErrDuplicateName      = errors.New("запись с таким названием уже существует")
+	ErrClientHasProjects  = errors.New("у клиента есть проекты, сначала удалите их или перенесите к другому клиенту")
+	ErrUnauthorized       = errors.New("требуется аутентификация")
+	ErrForbidden          = errors.New("недостаточно прав")
+	ErrInvalidCredentials = errors.New("неверный логин или пароль")
+	ErrDuplicateLogin     = errors.New("пользователь с таким логином уже существует")
+	ErrAPIKeyNotFound     = errors.New("ключ API не найден")
+)
+
+// ValidationError - ошибка валидации входных данных с описанием проблемы по каждому полю
+type ValidationError struct {
+	Fields map[string]string
+}
+
+// NewValidationError создает ошибку валидации для одного поля
+func NewValidationError(field, message string) *ValidationError {
+	return &ValidationError{Fields: map[string]string{field: message}}
+}
+
+// Add добавляет описание ошибки для поля
+func (e *ValidationError) Add(field, message string) {
+	if e.Fields == nil {
+		e.Fields = map[string]string{}
+	}
+	e.Fields[field] = message
+}
+
+// HasErrors сообщает, найдены ли ошибки хотя бы в одном поле
+func (e *ValidationError) HasErrors() bool {
+	return len(e.Fields) > 0
+}
+
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Fields))
+	for field := range e.Fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	parts := make([]string, 0, len(fields))
+	for _, field := range fields {
+		parts = append(parts, field+": "+e.Fields[field])
+	}
+	return "ошибка валидации: " + strings.Join(parts, "; ")
+}
@@ -0,0 +1,162 @@
+package enrichment
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+	"time-tracker/internal/API/apiDataUser"
+	"time-tracker/internal/config"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/logger"
+	"time-tracker/internal/metrics"
+	"time-tracker/internal/models"
+	"time-tracker/internal/validator"
+)
+
+// leaseMargin - запас срока, на который берется задача, сверх ENRICHMENT_JOB_TIMEOUT: после запроса
+// к API результат еще нужно записать в БД, и до этого задача не должна достаться другому обработчику
+const leaseMargin = 30 * time.Second
+
+// Queue - очередь задач обогащения в хранилище
+type Queue interface {
+	ClaimEnrichmentJob(ctx context.Context, lease time.Duration) (models.EnrichmentJob, bool, error)
+	CompleteEnrichmentJob(ctx context.Context, userID int, userData models.UserData) error
+	RescheduleEnrichmentJob(ctx context.Context, userID int, runAt time.Time, lastError string) error
+	FailEnrichmentJob(ctx context.Context, userID int, lastError string) error
+}
+
+// Worker обрабатывает очередь обогащения: берет задачу, запрашивает ФИО и адрес в стороннем API
+// и записывает их пользователю. Неудачная попытка повторяется с растущей паузой, пока не исчерпан
+// лимит; если API не нашел или отклонил паспорт, обогащение сразу отмечается неудавшимся.
+type Worker struct {
+	queue    Queue
+	enricher apiDataUser.Enricher
+
+	workers     int
+	poll        time.Duration
+	jobTimeout  time.Duration
+	lease       time.Duration
+	maxAttempts int
+	retryDelay  time.Duration
+	maxDelay    time.Duration
+}
+
+// NewWorker создает обработчик очереди с настройками ENRICHMENT_* из conf
+func NewWorker(queue Queue, enricher apiDataUser.Enricher, conf *config.Config) *Worker {
+	return &Worker{
+		queue:       queue,
+		enricher:    enricher,
+		workers:     conf.ENRICHMENT_WORKERS,
+		poll:        conf.ENRICHMENT_POLL_INTERVAL,
+		jobTimeout:  conf.ENRICHMENT_JOB_TIMEOUT,
+		lease:       conf.ENRICHMENT_JOB_TIMEOUT + leaseMargin,
+		maxAttempts: conf.ENRICHMENT_MAX_ATTEMPTS,
+		retryDelay:  conf.ENRICHMENT_RETRY_DELAY,
+		maxDelay:    conf.ENRICHMENT_RETRY_MAX_DELAY,
+	}
+}
+
+// Run запускает обработчики и блокируется, пока не отменен ctx и не завершены взятые задачи
+func (w *Worker) Run(ctx context.Context) {
+	if w.workers <= 0 {
+		return
+	}
+	logger.SugaredLogger().Infow("Запуск обработчиков очереди обогащения", "workers", w.workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < w.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.loop(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+// loop обрабатывает задачи подряд, пока они есть, затем ждет poll
+func (w *Worker) loop(ctx context.Context) {
+	for {
+		processed, err := w.processNext(ctx)
+		if err != nil {
+			logger.SugaredLogger().Errorw("Ошибка обработки очереди обогащения", "error", err)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if processed && err == nil {
+			continue
+		}
+
+		timer := time.NewTimer(w.poll)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// processNext берет и обрабатывает одну задачу; processed - false, если задач нет
+func (w *Worker) processNext(ctx context.Context) (bool, error) {
+	job, ok, err := w.queue.ClaimEnrichmentJob(ctx, w.lease)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	err = w.process(ctx, job)
+	// пользователя удалили, пока задача выполнялась, - вместе с ним удалена и задача
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return true, nil
+	}
+	return true, err
+}
+
+func (w *Worker) process(ctx context.Context, job models.EnrichmentJob) error {
+	log := logger.SugaredLogger().With("user_id", job.UserID, "attempt", job.Attempts)
+
+	jobCtx, cancel := context.WithTimeout(ctx, w.jobTimeout)
+	defer cancel()
+
+	series, number, err := validator.ValidatePassport("passport_number", job.PassportNumber)
+	var userData *models.UserData
+	if err == nil {
+		userData, err = w.enricher.GetPeopleInfo(jobCtx, series, number)
+	}
+	// сервер останавливается: задача вернется в очередь, когда истечет срок, на который она взята
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	if err == nil {
+		metrics.EnrichmentJob(metrics.EnrichmentJobDone)
+		log.Infow("Данные пользователя обогащены")
+		return w.queue.CompleteEnrichmentJob(ctx, job.UserID, *userData)
+	}
+
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) || job.Attempts >= w.maxAttempts {
+		metrics.EnrichmentJob(metrics.EnrichmentJobFailed)
+		log.Warnw("Обогащение данных пользователя не удалось", "error", err)
+		return w.queue.FailEnrichmentJob(ctx, job.UserID, err.Error())
+	}
+
+	delay := w.delay(job.Attempts)
+	metrics.EnrichmentJob(metrics.EnrichmentJobRetry)
+	log.Warnw("Обогащение данных пользователя отложено", "error", err, "delay", delay)
+	return w.queue.RescheduleEnrichmentJob(ctx, job.UserID, time.Now().Add(delay), err.Error())
+}
+
+// delay - пауза после неудачной попытки attempt: retryDelay, удвоенный с каждой попыткой, не больше maxDelay
+func (w *Worker) delay(attempt int) time.Duration {
+	delay := w.retryDelay
+	for i := 1; i < attempt && delay < w.maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > w.maxDelay {
+		delay = w.maxDelay
+	}
+	return delay
+}
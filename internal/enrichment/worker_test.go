@@ -0,0 +1,183 @@
+package enrichment
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"time-tracker/internal/API/apiDataUser"
+	apimocks "time-tracker/internal/API/apiDataUser/mocks"
+	"time-tracker/internal/config"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/logger"
+	"time-tracker/internal/models"
+	"time-tracker/internal/storage/mocks"
+)
+
+var testConf = &config.Config{
+	ENRICHMENT_WORKERS:         1,
+	ENRICHMENT_POLL_INTERVAL:   time.Millisecond,
+	ENRICHMENT_JOB_TIMEOUT:     time.Second,
+	ENRICHMENT_MAX_ATTEMPTS:    3,
+	ENRICHMENT_RETRY_DELAY:     time.Minute,
+	ENRICHMENT_RETRY_MAX_DELAY: 3 * time.Minute,
+}
+
+// runAtMatcher проверяет, что задача отложена примерно на delay
+type runAtMatcher struct {
+	delay time.Duration
+}
+
+func (m runAtMatcher) Matches(x interface{}) bool {
+	runAt, ok := x.(time.Time)
+	if !ok {
+		return false
+	}
+	d := time.Until(runAt)
+	return d > m.delay-time.Second && d <= m.delay
+}
+
+func (m runAtMatcher) String() string {
+	return "через " + m.delay.String()
+}
+
+func TestProcessNext(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	queue := mocks.NewMockRepositoryDB(ctrl)
+	enricher := apimocks.NewMockEnricher(ctrl)
+	worker := NewWorker(queue, enricher, testConf)
+
+	job := func(attempts int) models.EnrichmentJob {
+		return models.EnrichmentJob{UserID: 1, PassportNumber: "1234 567890", Attempts: attempts}
+	}
+	userData := models.UserData{PassportNumber: "1234 567890", Surname: "Иванов", Name: "Иван"}
+	runAt := func(delay time.Duration) gomock.Matcher { return runAtMatcher{delay} }
+
+	tests := []struct {
+		name          string
+		mockCreate    func()
+		wantProcessed bool
+		wantErr       bool
+	}{
+		{
+			name: "#1 Очередь пуста",
+			mockCreate: func() {
+				queue.EXPECT().ClaimEnrichmentJob(gomock.Any(), time.Second+leaseMargin).Return(models.EnrichmentJob{}, false, nil)
+			},
+		},
+		{
+			name: "#2 Данные получены",
+			mockCreate: func() {
+				queue.EXPECT().ClaimEnrichmentJob(gomock.Any(), time.Second+leaseMargin).Return(job(1), true, nil)
+				enricher.EXPECT().GetPeopleInfo(gomock.Any(), "1234", "567890").Return(&userData, nil)
+				queue.EXPECT().CompleteEnrichmentJob(gomock.Any(), 1, userData).Return(nil)
+			},
+			wantProcessed: true,
+		},
+		{
+			name: "#3 API недоступен - задача отложена",
+			mockCreate: func() {
+				queue.EXPECT().ClaimEnrichmentJob(gomock.Any(), time.Second+leaseMargin).Return(job(2), true, nil)
+				enricher.EXPECT().GetPeopleInfo(gomock.Any(), "1234", "567890").Return(nil, apiDataUser.ErrCircuitOpen)
+				queue.EXPECT().RescheduleEnrichmentJob(gomock.Any(), 1, runAt(2*time.Minute), apiDataUser.ErrCircuitOpen.Error()).Return(nil)
+			},
+			wantProcessed: true,
+		},
+		{
+			name: "#4 Попытки исчерпаны",
+			mockCreate: func() {
+				queue.EXPECT().ClaimEnrichmentJob(gomock.Any(), time.Second+leaseMargin).Return(job(3), true, nil)
+				enricher.EXPECT().GetPeopleInfo(gomock.Any(), "1234", "567890").Return(nil, domain.ErrEnrichmentFailed)
+				queue.EXPECT().FailEnrichmentJob(gomock.Any(), 1, domain.ErrEnrichmentFailed.Error()).Return(nil)
+			},
+			wantProcessed: true,
+		},
+		{
+			name: "#5 API не нашел паспорт - без повторов",
+			mockCreate: func() {
+				notFound := domain.NewValidationError("passportNumber", "not found by enrichment API")
+				queue.EXPECT().ClaimEnrichmentJob(gomock.Any(), time.Second+leaseMargin).Return(job(1), true, nil)
+				enricher.EXPECT().GetPeopleInfo(gomock.Any(), "1234", "567890").Return(nil, notFound)
+				queue.EXPECT().FailEnrichmentJob(gomock.Any(), 1, notFound.Error()).Return(nil)
+			},
+			wantProcessed: true,
+		},
+		{
+			name: "#6 Пользователь удален во время обработки",
+			mockCreate: func() {
+				queue.EXPECT().ClaimEnrichmentJob(gomock.Any(), time.Second+leaseMargin).Return(job(1), true, nil)
+				enricher.EXPECT().GetPeopleInfo(gomock.Any(), "1234", "567890").Return(&userData, nil)
+				queue.EXPECT().CompleteEnrichmentJob(gomock.Any(), 1, userData).Return(domain.ErrUserNotFound)
+			},
+			wantProcessed: true,
+		},
+		{
+			name: "#7 Ошибка БД",
+			mockCreate: func() {
+				queue.EXPECT().ClaimEnrichmentJob(gomock.Any(), time.Second+leaseMargin).Return(models.EnrichmentJob{}, false, errors.New("connection refused"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockCreate()
+
+			processed, err := worker.processNext(context.Background())
+			assert.Equal(t, tt.wantProcessed, processed)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWorkerDelay(t *testing.T) {
+	worker := NewWorker(nil, nil, testConf)
+
+	assert.Equal(t, time.Minute, worker.delay(1))
+	assert.Equal(t, 2*time.Minute, worker.delay(2))
+	assert.Equal(t, 3*time.Minute, worker.delay(3))
+	assert.Equal(t, 3*time.Minute, worker.delay(10))
+}
+
+func TestWorkerRunStops(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	queue := mocks.NewMockRepositoryDB(ctrl)
+	queue.EXPECT().ClaimEnrichmentJob(gomock.Any(), gomock.Any()).Return(models.EnrichmentJob{}, false, nil).MinTimes(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		NewWorker(queue, apimocks.NewMockEnricher(ctrl), testConf).Run(ctx)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("обработчик не остановился после отмены контекста")
+	}
+}
@@ -0,0 +1,60 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time-tracker/internal/models"
+)
+
+// csvWriter пишет строки сразу в w через буфер encoding/csv; итогов в CSV нет
+type csvWriter struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) writeHeader() error {
+	if c.headerWritten {
+		return nil
+	}
+	c.headerWritten = true
+	return c.w.Write(header)
+}
+
+func (c *csvWriter) WriteRow(row models.ExportRow) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	start, end := "", ""
+	if row.Start.Valid {
+		start = row.Start.Time.Format(timeLayout)
+	}
+	if row.End.Valid {
+		end = row.End.Time.Format(timeLayout)
+	}
+	return c.w.Write([]string{
+		row.UserName,
+		strconv.Itoa(row.TaskID),
+		row.NameTask,
+		row.ProjectName,
+		row.ClientName,
+		start,
+		end,
+		strconv.FormatInt(row.DurationSeconds, 10),
+	})
+}
+
+func (c *csvWriter) Close() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvWriter) Abort() {}
@@ -0,0 +1,101 @@
+package export
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"time-tracker/internal/models"
+)
+
+// форматы выгрузки
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+const (
+	ContentTypeCSV  = "text/csv; charset=utf-8"
+	ContentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// ErrUnknownFormat - формат выгрузки не поддерживается
+var ErrUnknownFormat = errors.New("неизвестный формат выгрузки")
+
+// формат даты и времени в ячейках выгрузки
+const timeLayout = "2006-01-02 15:04:05"
+
+// заголовки колонок выгрузки
+var header = []string{"Пользователь", "ID задачи", "Задача", "Проект", "Клиент", "Начало", "Окончание", "Длительность, сек"}
+
+// Writer пишет строки выгрузки по одной. Close дописывает итоги и должен вызываться
+// после последней строки, в том числе если строк не было. Если выгрузка прервана,
+// вместо Close вызывается Abort, чтобы освободить ресурсы.
+type Writer interface {
+	WriteRow(row models.ExportRow) error
+	Close() error
+	Abort()
+}
+
+// New создает Writer нужного формата поверх w
+func New(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	case FormatXLSX:
+		return newXLSXWriter(w)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// ContentType возвращает Content-Type ответа для формата выгрузки
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return ContentTypeXLSX
+	}
+	return ContentTypeCSV
+}
+
+// FormatFromAccept выбирает формат выгрузки по заголовку Accept. Если подходящего
+// типа нет, возвращается JSON.
+func FormatFromAccept(accept string) string {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType := strings.TrimSpace(strings.Split(part, ";")[0])
+		switch mediaType {
+		case "text/csv":
+			return FormatCSV
+		case ContentTypeXLSX:
+			return FormatXLSX
+		}
+	}
+	return FormatJSON
+}
+
+// taskTotal - итог по задаче для сводки
+type taskTotal struct {
+	taskID   int
+	nameTask string
+	seconds  int64
+}
+
+// totals копит итоги по задачам в порядке их первого появления в выгрузке
+type totals struct {
+	tasks []*taskTotal
+	index map[int]*taskTotal
+	all   int64
+}
+
+func (t *totals) add(row models.ExportRow) {
+	if t.index == nil {
+		t.index = map[int]*taskTotal{}
+	}
+	total, ok := t.index[row.TaskID]
+	if !ok {
+		total = &taskTotal{taskID: row.TaskID, nameTask: row.NameTask}
+		t.index[row.TaskID] = total
+		t.tasks = append(t.tasks, total)
+	}
+	total.seconds += row.DurationSeconds
+	t.all += row.DurationSeconds
+}
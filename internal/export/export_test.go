@@ -0,0 +1,115 @@
+package export
+
+import (
+	"bytes"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+	"testing"
+	"time"
+	"time-tracker/internal/models"
+)
+
+var testRows = []models.ExportRow{
+	{
+		UserName:        "Иванов Иван Иванович",
+		TaskID:          1,
+		NameTask:        "Отчет",
+		ProjectName:     "Сайт",
+		ClientName:      "ООО Ромашка",
+		Start:           sql.NullTime{Time: time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC), Valid: true},
+		End:             sql.NullTime{Time: time.Date(2024, 1, 10, 10, 30, 0, 0, time.UTC), Valid: true},
+		DurationSeconds: 5400,
+	},
+	{
+		UserName:        "Иванов Иван Иванович",
+		TaskID:          2,
+		NameTask:        "Созвон, \"планерка\"",
+		Start:           sql.NullTime{Time: time.Date(2024, 1, 10, 11, 0, 0, 0, time.UTC), Valid: true},
+		DurationSeconds: 600,
+	},
+	{
+		UserName:        "Иванов Иван Иванович",
+		TaskID:          1,
+		NameTask:        "Отчет",
+		Start:           sql.NullTime{Time: time.Date(2024, 1, 11, 9, 0, 0, 0, time.UTC), Valid: true},
+		End:             sql.NullTime{Time: time.Date(2024, 1, 11, 9, 30, 0, 0, time.UTC), Valid: true},
+		DurationSeconds: 1800,
+	},
+}
+
+func writeAll(t *testing.T, format string) *bytes.Buffer {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	w, err := New(format, buf)
+	require.NoError(t, err)
+	for _, row := range testRows {
+		require.NoError(t, w.WriteRow(row))
+	}
+	require.NoError(t, w.Close())
+	return buf
+}
+
+func TestCSV(t *testing.T) {
+	buf := writeAll(t, FormatCSV)
+
+	want := "Пользователь,ID задачи,Задача,Проект,Клиент,Начало,Окончание,\"Длительность, сек\"\n" +
+		"Иванов Иван Иванович,1,Отчет,Сайт,ООО Ромашка,2024-01-10 09:00:00,2024-01-10 10:30:00,5400\n" +
+		"Иванов Иван Иванович,2,\"Созвон, \"\"планерка\"\"\",,,2024-01-10 11:00:00,,600\n" +
+		"Иванов Иван Иванович,1,Отчет,,,2024-01-11 09:00:00,2024-01-11 09:30:00,1800\n"
+	assert.Equal(t, want, buf.String())
+}
+
+func TestCSVEmpty(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w, err := New(FormatCSV, buf)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, "Пользователь,ID задачи,Задача,Проект,Клиент,Начало,Окончание,\"Длительность, сек\"\n", buf.String())
+}
+
+func TestXLSX(t *testing.T) {
+	buf := writeAll(t, FormatXLSX)
+
+	file, err := excelize.OpenReader(buf)
+	require.NoError(t, err)
+	defer file.Close()
+
+	rows, err := file.GetRows(sheetIntervals)
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	assert.Equal(t, []string{"Иванов Иван Иванович", "1", "Отчет", "Сайт", "ООО Ромашка", "2024-01-10 09:00:00", "2024-01-10 10:30:00", "5400"}, rows[1])
+	assert.Equal(t, "", rows[2][6])
+
+	summary, err := file.GetRows(sheetSummary)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"ID задачи", "Задача", "Длительность, сек", "Длительность, ч"},
+		{"1", "Отчет", "7200", "2"},
+		{"2", "Созвон, \"планерка\"", "600", "0.17"},
+		{"", "Всего", "7800", "2.17"},
+	}, summary)
+}
+
+func TestFormatFromAccept(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", FormatJSON},
+		{"application/json", FormatJSON},
+		{"text/csv", FormatCSV},
+		{"text/html;q=0.9, text/csv;q=0.8", FormatCSV},
+		{ContentTypeXLSX, FormatXLSX},
+		{"*/*", FormatJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			assert.Equal(t, tt.want, FormatFromAccept(tt.accept))
+		})
+	}
+}
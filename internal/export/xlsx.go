@@ -0,0 +1,132 @@
+package export
+
+import (
+	"github.com/xuri/excelize/v2"
+	"io"
+	"math"
+	"time-tracker/internal/models"
+)
+
+const (
+	sheetIntervals = "Интервалы"
+	sheetSummary   = "Итого"
+)
+
+// xlsxWriter пишет строки через StreamWriter excelize: строки сбрасываются во временный файл,
+// а не копятся в памяти. Книга целиком отдается в w при Close вместе с листом итогов.
+type xlsxWriter struct {
+	w         io.Writer
+	file      *excelize.File
+	stream    *excelize.StreamWriter
+	timeStyle int
+	row       int
+	totals    totals
+}
+
+func newXLSXWriter(w io.Writer) (*xlsxWriter, error) {
+	file := excelize.NewFile()
+	if err := file.SetSheetName("Sheet1", sheetIntervals); err != nil {
+		return nil, err
+	}
+
+	layout := "yyyy-mm-dd hh:mm:ss"
+	timeStyle, err := file.NewStyle(&excelize.Style{CustomNumFmt: &layout})
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := file.NewStreamWriter(sheetIntervals)
+	if err != nil {
+		return nil, err
+	}
+
+	x := &xlsxWriter{w: w, file: file, stream: stream, timeStyle: timeStyle, row: 1}
+	if err := x.setRow(x.stream, stringsToRow(header)); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+func (x *xlsxWriter) setRow(stream *excelize.StreamWriter, values []interface{}) error {
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+	x.row++
+	return stream.SetRow(cell, values)
+}
+
+func (x *xlsxWriter) WriteRow(row models.ExportRow) error {
+	x.totals.add(row)
+
+	var start, end interface{}
+	if row.Start.Valid {
+		start = excelize.Cell{StyleID: x.timeStyle, Value: row.Start.Time}
+	}
+	if row.End.Valid {
+		end = excelize.Cell{StyleID: x.timeStyle, Value: row.End.Time}
+	}
+	return x.setRow(x.stream, []interface{}{
+		row.UserName,
+		row.TaskID,
+		row.NameTask,
+		row.ProjectName,
+		row.ClientName,
+		start,
+		end,
+		row.DurationSeconds,
+	})
+}
+
+// Close дописывает лист итогов по задачам и отдает книгу в w
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+
+	if _, err := x.file.NewSheet(sheetSummary); err != nil {
+		return err
+	}
+	summary, err := x.file.NewStreamWriter(sheetSummary)
+	if err != nil {
+		return err
+	}
+
+	x.row = 1
+	if err := x.setRow(summary, stringsToRow([]string{"ID задачи", "Задача", "Длительность, сек", "Длительность, ч"})); err != nil {
+		return err
+	}
+	for _, task := range x.totals.tasks {
+		if err := x.setRow(summary, []interface{}{task.taskID, task.nameTask, task.seconds, hours(task.seconds)}); err != nil {
+			return err
+		}
+	}
+	if err := x.setRow(summary, []interface{}{nil, "Всего", x.totals.all, hours(x.totals.all)}); err != nil {
+		return err
+	}
+	if err := summary.Flush(); err != nil {
+		return err
+	}
+
+	return x.file.Write(x.w)
+}
+
+// Abort удаляет временные файлы книги
+func (x *xlsxWriter) Abort() {
+	x.file.Close()
+}
+
+// hours переводит секунды в часы с точностью до сотых
+func hours(seconds int64) float64 {
+	return math.Round(float64(seconds)/36) / 100
+}
+
+func stringsToRow(values []string) []interface{} {
+	row := make([]interface{}, len(values))
+	for i, v := range values {
+		row[i] = v
+	}
+	return row
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/models"
+	"time-tracker/internal/usecase"
+	"time-tracker/internal/validator"
+)
+
+// @Summary Создание ключа API
+// @Description Выпускает ключ API для ботов и скриптов. Ключ передается в заголовке Authorization: ApiKey <key>,
+// @Description действует от имени пользователя и только в пределах scopes: tasks:read, tasks:write, reports:read, catalog:read.
+// @Description Ключ показывается только в этом ответе, сервер хранит лишь его хеш. Без expires_at ключ бессрочный.
+// @Tags API keys
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userID path int true "ID пользователя"
+// @Param body body models.APIKeyRequest true "Название (не длиннее 100 символов), права и срок действия (RFC3339)"
+// @Success 200 {object} models.APIKeyCreated "Ключ"
+// @Failure 400 {object} models.ErrorResponse "Ошибка декодирования тела запроса"
+// @Failure 401 {object} models.ErrorResponse "Требуется аутентификация"
+// @Failure 403 {object} models.ErrorResponse "Недостаточно прав"
+// @Failure 404 {object} models.ErrorResponse "Пользователь не найден"
+// @Failure 422 {object} models.ErrorResponse "Ошибка UserID, пустое название, неизвестное право или срок в прошлом"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /users/{userID}/api-keys [post]
+func HandlerCreateAPIKey(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodPost {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	userID, err := urlParamInt(r, "userID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req models.APIKeyRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := validator.ValidateTaskName("name", req.Name); err != nil {
+		writeError(w, err)
+		return
+	}
+	if req.Scopes, err = validator.NormalizeScopes("scopes", req.Scopes); err != nil {
+		writeError(w, err)
+		return
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		writeError(w, domain.NewValidationError("expires_at", "must be in the future"))
+		return
+	}
+
+	key, err := useCase.UseCaseCreateAPIKey(r.Context(), userID, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, key)
+}
+
+// @Summary Список ключей API
+// @Description Ключи пользователя, включая отозванные и истекшие, с временем последнего использования. Сами ключи не возвращаются.
+// @Tags API keys
+// @Produce json
+// @Security BearerAuth
+// @Param userID path int true "ID пользователя"
+// @Success 200 {array} models.APIKey "Ключи"
+// @Failure 401 {object} models.ErrorResponse "Требуется аутентификация"
+// @Failure 403 {object} models.ErrorResponse "Недостаточно прав"
+// @Failure 422 {object} models.ErrorResponse "Ошибка конвертирования UserID"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /users/{userID}/api-keys [get]
+func HandlerListAPIKeys(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodGet {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	userID, err := urlParamInt(r, "userID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	keys, err := useCase.UseCaseListAPIKeys(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, keys)
+}
+
+// @Summary Отзыв ключа API
+// @Description Ключ перестает действовать сразу; запись остается в списке с revoked_at.
+// @Tags API keys
+// @Produce json
+// @Security BearerAuth
+// @Param userID path int true "ID пользователя"
+// @Param keyID path int true "ID ключа"
+// @Success 200 {string} string "Ключ отозван"
+// @Failure 401 {object} models.ErrorResponse "Требуется аутентификация"
+// @Failure 403 {object} models.ErrorResponse "Недостаточно прав"
+// @Failure 404 {object} models.ErrorResponse "Ключ не найден"
+// @Failure 422 {object} models.ErrorResponse "Ошибка конвертирования UserID или KeyID"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /users/{userID}/api-keys/{keyID} [delete]
+func HandlerRevokeAPIKey(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodDelete {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	userID, err := urlParamInt(r, "userID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	keyID, err := urlParamInt(r, "keyID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := useCase.UseCaseRevokeAPIKey(r.Context(), userID, keyID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,341 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time-tracker/internal/auth"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/models"
+	"time-tracker/internal/usecase"
+	"time-tracker/internal/validator"
+)
+
+// withAuth пропускает только запросы с действующими учетными данными в заголовке Authorization -
+// токеном доступа (Bearer) или ключом API (ApiKey) - и кладет пользователя в контекст запроса.
+// Права на конкретные данные проверяет usecase.
+func withAuth(tokens *auth.Tokens, useCase usecase.UseCaseStorage) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var principal auth.Principal
+			var err error
+
+			scheme, credentials := authorization(r)
+			switch {
+			case credentials == "":
+				err = domain.ErrUnauthorized
+			case strings.EqualFold(scheme, "Bearer"):
+				principal, err = tokens.ParseAccessToken(credentials)
+			case strings.EqualFold(scheme, "ApiKey"):
+				principal, err = useCase.UseCaseAuthenticateAPIKey(r.Context(), credentials)
+			default:
+				err = domain.ErrUnauthorized
+			}
+
+			if err != nil {
+				w.Header().Add("WWW-Authenticate", "Bearer")
+				w.Header().Add("WWW-Authenticate", "ApiKey")
+				writeError(w, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+// authorization разбирает заголовок Authorization на схему и учетные данные
+func authorization(r *http.Request) (string, string) {
+	scheme, credentials, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+	return scheme, strings.TrimSpace(credentials)
+}
+
+// @Summary Вход
+// @Description Проверяет логин и пароль и выдает токен доступа (JWT, заголовок Authorization: Bearer) и refresh-токен.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body models.Credentials true "Логин и пароль"
+// @Success 200 {object} models.TokenResponse "Токены"
+// @Failure 400 {object} models.ErrorResponse "Ошибка декодирования тела запроса"
+// @Failure 401 {object} models.ErrorResponse "Неверный логин или пароль"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /auth/login [post]
+func HandlerLogin(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodPost {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	var creds models.Credentials
+	if err := decodeJSON(r, &creds); err != nil {
+		writeError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	tokens, err := useCase.UseCaseLogin(r.Context(), creds)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, tokens)
+}
+
+// @Summary Обновление токенов
+// @Description Обменивает refresh-токен на новую пару токенов. Refresh-токен одноразовый: повторное использование
+// @Description отзывает всю цепочку токенов, выданных после входа, и пользователю нужно войти заново.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body models.RefreshRequest true "Refresh-токен"
+// @Success 200 {object} models.TokenResponse "Новые токены"
+// @Failure 400 {object} models.ErrorResponse "Ошибка декодирования тела запроса"
+// @Failure 401 {object} models.ErrorResponse "Неверный, истекший или отозванный refresh-токен"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /auth/refresh [post]
+func HandlerRefresh(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodPost {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	var req models.RefreshRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	tokens, err := useCase.UseCaseRefresh(r.Context(), req.RefreshToken)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, tokens)
+}
+
+// @Summary Выход
+// @Description Отзывает refresh-токен и всю его цепочку. Токен доступа действует до истечения срока (ACCESS_TOKEN_TTL).
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body models.RefreshRequest true "Refresh-токен"
+// @Success 200 {string} string "Токены отозваны"
+// @Failure 400 {object} models.ErrorResponse "Ошибка декодирования тела запроса"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /auth/logout [post]
+func HandlerLogout(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodPost {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	var req models.RefreshRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := useCase.UseCaseLogout(r.Context(), req.RefreshToken); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Логин и пароль пользователя
+// @Description Задает пользователю логин и пароль (сам пользователь или администратор). Все refresh-токены пользователя отзываются.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userID path int true "ID пользователя"
+// @Param body body models.Credentials true "Логин (3-50 символов: буквы, цифры, . _ -) и пароль (8-72 байта)"
+// @Success 200 {string} string "Учетные данные сохранены"
+// @Failure 400 {object} models.ErrorResponse "Ошибка декодирования тела запроса"
+// @Failure 401 {object} models.ErrorResponse "Требуется аутентификация"
+// @Failure 403 {object} models.ErrorResponse "Недостаточно прав"
+// @Failure 404 {object} models.ErrorResponse "Пользователь не найден"
+// @Failure 409 {object} models.ErrorResponse "Логин занят"
+// @Failure 422 {object} models.ErrorResponse "Ошибка UserID, логина или пароля"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /users/{userID}/credentials [put]
+func HandlerSetCredentials(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodPut {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	userID, err := urlParamInt(r, "userID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var creds models.Credentials
+	if err := decodeJSON(r, &creds); err != nil {
+		writeError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := validator.ValidateCredentials(creds.Login, creds.Password); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := useCase.UseCaseSetCredentials(r.Context(), userID, creds); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Роль пользователя
+// @Description Назначает роль member, manager или admin (только администратор). Новая роль действует в токенах, выданных после изменения.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userID path int true "ID пользователя"
+// @Param body body models.RoleRequest true "Роль: member, manager или admin"
+// @Success 200 {string} string "Роль изменена"
+// @Failure 400 {object} models.ErrorResponse "Ошибка декодирования тела запроса"
+// @Failure 401 {object} models.ErrorResponse "Требуется аутентификация"
+// @Failure 403 {object} models.ErrorResponse "Недостаточно прав"
+// @Failure 404 {object} models.ErrorResponse "Пользователь не найден"
+// @Failure 422 {object} models.ErrorResponse "Ошибка UserID или неизвестная роль"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /users/{userID}/role [put]
+func HandlerSetUserRole(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodPut {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	userID, err := urlParamInt(r, "userID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req models.RoleRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := useCase.UseCaseSetUserRole(r.Context(), userID, req.Role); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Список ролей
+// @Description Роли с описанием прав: member - свои задачи, manager - плюс профили и отчеты своей команды, admin - все.
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.Role "Роли"
+// @Failure 401 {object} models.ErrorResponse "Требуется аутентификация"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /roles [get]
+func HandlerListRoles(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodGet {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	roles, err := useCase.UseCaseListRoles(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, roles)
+}
+
+// @Summary Менеджер пользователя
+// @Description Включает пользователя в команду менеджера (только администратор); manager_id: null исключает из команды.
+// @Description Менеджер видит профили и отчеты своей команды.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userID path int true "ID пользователя"
+// @Param body body models.ManagerRequest true "ID менеджера или null"
+// @Success 200 {string} string "Менеджер назначен"
+// @Failure 400 {object} models.ErrorResponse "Ошибка декодирования тела запроса"
+// @Failure 401 {object} models.ErrorResponse "Требуется аутентификация"
+// @Failure 403 {object} models.ErrorResponse "Недостаточно прав"
+// @Failure 404 {object} models.ErrorResponse "Пользователь или менеджер не найден"
+// @Failure 422 {object} models.ErrorResponse "Ошибка UserID или пользователь назначен своим менеджером"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /users/{userID}/manager [put]
+func HandlerSetUserManager(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodPut {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	userID, err := urlParamInt(r, "userID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req models.ManagerRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := useCase.UseCaseSetUserManager(r.Context(), userID, req.ManagerID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Команда менеджера
+// @Description Пользователи, у которых userID назначен менеджером. Доступно самому менеджеру и администратору.
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Param userID path int true "ID менеджера"
+// @Success 200 {array} models.UserData "Команда"
+// @Failure 401 {object} models.ErrorResponse "Требуется аутентификация"
+// @Failure 403 {object} models.ErrorResponse "Недостаточно прав"
+// @Failure 422 {object} models.ErrorResponse "Ошибка конвертирования UserID"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /users/{userID}/team [get]
+func HandlerListTeam(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodGet {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	userID, err := urlParamInt(r, "userID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	team, err := useCase.UseCaseListTeam(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, team)
+}
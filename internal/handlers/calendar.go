@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time-tracker/internal/ical"
+	"time-tracker/internal/logger"
+	"time-tracker/internal/models"
+	"time-tracker/internal/usecase"
+	"time-tracker/internal/validator"
+)
+
+// @Summary Выпуск токена подписки на календарь
+// @Description Выпускает секретный токен, по которому календарные приложения получают /users/{userID}/calendar.ics без других учетных данных.
+// @Description Прежний токен перестает действовать. Токен показывается только в этом ответе, сервер хранит лишь его хеш.
+// @Tags Calendar
+// @Produce json
+// @Security BearerAuth
+// @Param userID path int true "ID пользователя"
+// @Success 200 {object} models.CalendarToken "Токен и адрес подписки"
+// @Failure 404 {object} models.ErrorResponse "Пользователь не найден"
+// @Failure 422 {object} models.ErrorResponse "Ошибка конвертирования UserID"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /users/{userID}/calendar/token [post]
+func HandlerCreateCalendarToken(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodPost {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	userID, err := urlParamInt(r, "userID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	token, err := useCase.UseCaseCreateCalendarToken(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	feed := url.URL{
+		Scheme:   scheme,
+		Host:     r.Host,
+		Path:     fmt.Sprintf("/users/%d/calendar.ics", userID),
+		RawQuery: url.Values{"token": {token}}.Encode(),
+	}
+
+	writeJSON(w, models.CalendarToken{Token: token, URL: feed.String()})
+}
+
+// @Summary Календарь завершенных задач
+// @Description Отдает завершенные задачи пользователя в формате iCalendar: название задачи - заголовок события, время старта и окончания - время события.
+// @Description UID события постоянный (task-{id}@time-tracker), поэтому календарь обновляет события, а не дублирует их.
+// @Description Доступ по токену из POST /users/{userID}/calendar/token.
+// @Tags Calendar
+// @Produce text/calendar
+// @Param userID path int true "ID пользователя"
+// @Param token query string true "Токен подписки"
+// @Success 200 {string} string "Календарь (text/calendar)"
+// @Failure 401 {object} models.ErrorResponse "Неверный токен"
+// @Failure 422 {object} models.ErrorResponse "Ошибка конвертирования UserID"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /users/{userID}/calendar.ics [get]
+func HandlerCalendar(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodGet {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	userID, err := urlParamInt(r, "userID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp := &exportResponse{
+		w:           w,
+		contentType: ical.ContentType,
+		filename:    "calendar.ics",
+	}
+	out := ical.NewWriter(resp, "Учет времени")
+
+	err = useCase.UseCaseCalendarEvents(r.Context(), userID, r.URL.Query().Get("token"), func(e models.CalendarEvent) error {
+		return out.WriteEvent(ical.Event{
+			UID:         fmt.Sprintf("task-%d@time-tracker", e.TaskID),
+			Summary:     e.NameTask,
+			Description: "Затрачено: " + validator.SecondToString(int(e.AllTime)),
+			Start:       e.Start,
+			End:         e.End,
+			Stamp:       e.End,
+		})
+	})
+	if err == nil {
+		err = out.Close()
+	}
+	if err == nil {
+		return
+	}
+
+	if !resp.started {
+		writeError(w, err)
+		return
+	}
+	logger.SugaredLogger().Errorw("Выгрузка календаря прервана", "userID", userID, "error", err)
+}
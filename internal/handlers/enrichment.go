@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+	"time-tracker/internal/usecase"
+)
+
+// @Summary Состояние обогащения данных пользователя
+// @Description Показывает, заполнены ли ФИО и адрес из стороннего API: pending - задача в очереди (next_attempt_at - срок следующей попытки),
+// @Description done - данные получены, failed - API не нашел или отклонил паспорт либо попытки исчерпаны (причина в last_error).
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Param userID path int true "ID пользователя"
+// @Success 200 {object} models.EnrichmentStatus "Состояние обогащения"
+// @Failure 401 {object} models.ErrorResponse "Требуется аутентификация"
+// @Failure 403 {object} models.ErrorResponse "Недостаточно прав"
+// @Failure 404 {object} models.ErrorResponse "Пользователь не найден"
+// @Failure 422 {object} models.ErrorResponse "Ошибка конвертирования UserID"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /users/{userID}/enrichment [get]
+func HandlerEnrichmentStatus(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodGet {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	userID, err := urlParamInt(r, "userID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	status, err := useCase.UseCaseReadEnrichmentStatus(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, status)
+}
+
+// @Summary Повторное обогащение данных пользователя
+// @Description Заново ставит пользователя в очередь обогащения: задача выполняется сразу, счетчик попыток сбрасывается.
+// @Description Подходит для неудавшегося обогащения или если часть полей осталась пустой: данные API заполняют только пустые поля, правки через PUT /user/{userID} не затираются.
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Param userID path int true "ID пользователя"
+// @Success 202 {object} models.EnrichmentStatus "Задача поставлена в очередь"
+// @Failure 401 {object} models.ErrorResponse "Требуется аутентификация"
+// @Failure 403 {object} models.ErrorResponse "Недостаточно прав"
+// @Failure 404 {object} models.ErrorResponse "Пользователь не найден"
+// @Failure 422 {object} models.ErrorResponse "Ошибка конвертирования UserID"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /users/{userID}/enrichment [post]
+func HandlerRetryEnrichment(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodPost {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	userID, err := urlParamInt(r, "userID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	status, err := useCase.UseCaseRetryEnrichment(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSONStatus(w, http.StatusAccepted, status)
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/logger"
+	"time-tracker/internal/models"
+)
+
+// ошибки уровня HTTP, не относящиеся к предметной области
+var (
+	errInvalidBody      = errors.New("некорректное тело запроса")
+	errMethodNotAllowed = errors.New("метод не поддерживается")
+	errRouteNotFound    = errors.New("маршрут не найден")
+)
+
+// statusClientClosedRequest - нестандартный статус (как в nginx) для запросов, прерванных клиентом
+const statusClientClosedRequest = 499
+
+// errorMapping связывает ошибку с HTTP статусом и машинночитаемым кодом
+type errorMapping struct {
+	err    error
+	status int
+	code   string
+}
+
+var errorMappings = []errorMapping{
+	{domain.ErrUserNotFound, http.StatusNotFound, "user_not_found"},
+	{domain.ErrTaskNotFound, http.StatusNotFound, "task_not_found"},
+	{domain.ErrClientNotFound, http.StatusNotFound, "client_not_found"},
+	{domain.ErrProjectNotFound, http.StatusNotFound, "project_not_found"},
+	{domain.ErrAPIKeyNotFound, http.StatusNotFound, "api_key_not_found"},
+	{domain.ErrDuplicatePassport, http.StatusConflict, "duplicate_passport"},
+	{domain.ErrDuplicateName, http.StatusConflict, "duplicate_name"},
+	{domain.ErrDuplicateLogin, http.StatusConflict, "duplicate_login"},
+	{domain.ErrClientHasProjects, http.StatusConflict, "client_has_projects"},
+	{domain.ErrAlreadyStarted, http.StatusConflict, "already_started"},
+	{domain.ErrAlreadyFinished, http.StatusConflict, "already_finished"},
+	{domain.ErrAlreadyPaused, http.StatusConflict, "already_paused"},
+	{domain.ErrAlreadyRunning, http.StatusConflict, "already_running"},
+	{domain.ErrTimeOverlap, http.StatusConflict, "time_overlap"},
+	{domain.ErrTaskRunning, http.StatusConflict, "task_running"},
+	{domain.ErrInvalidToken, http.StatusUnauthorized, "invalid_token"},
+	{domain.ErrUnauthorized, http.StatusUnauthorized, "unauthorized"},
+	{domain.ErrInvalidCredentials, http.StatusUnauthorized, "invalid_credentials"},
+	{domain.ErrForbidden, http.StatusForbidden, "forbidden"},
+	{domain.ErrNotStarted, http.StatusPreconditionRequired, "not_started"},
+	{domain.ErrEnrichmentFailed, http.StatusServiceUnavailable, "enrichment_failed"},
+	{context.DeadlineExceeded, http.StatusGatewayTimeout, "timeout"},
+	{context.Canceled, statusClientClosedRequest, "request_canceled"},
+	{errInvalidBody, http.StatusBadRequest, "invalid_body"},
+	{errMethodNotAllowed, http.StatusMethodNotAllowed, "method_not_allowed"},
+	{errRouteNotFound, http.StatusNotFound, "not_found"},
+}
+
+// mapError возвращает HTTP статус и тело ответа для ошибки.
+// Неизвестные ошибки отдаются как 500 без подробностей, чтобы не раскрывать внутренности.
+func mapError(err error) (int, models.ErrorResponse) {
+	var vErr *domain.ValidationError
+	if errors.As(err, &vErr) {
+		return http.StatusUnprocessableEntity, models.ErrorResponse{Error: models.ErrorBody{
+			Code:    "validation_failed",
+			Message: vErr.Error(),
+			Details: vErr.Fields,
+		}}
+	}
+
+	for _, m := range errorMappings {
+		if errors.Is(err, m.err) {
+			return m.status, models.ErrorResponse{Error: models.ErrorBody{Code: m.code, Message: err.Error()}}
+		}
+	}
+	return http.StatusInternalServerError, models.ErrorResponse{Error: models.ErrorBody{
+		Code:    "internal",
+		Message: "внутренняя ошибка сервера",
+	}}
+}
+
+// writeError логирует ошибку и пишет ответ с соответствующим статусом
+func writeError(w http.ResponseWriter, err error) {
+	status, body := mapError(err)
+	if status == http.StatusInternalServerError {
+		logger.SugaredLogger().Errorw("Ошибка обработки запроса", "error", err)
+	} else {
+		logger.SugaredLogger().Debug(err)
+	}
+
+	res, mErr := json.Marshal(body)
+	if mErr != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(res)
+}
@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/logger"
+	"time-tracker/internal/models"
+	"time-tracker/internal/validator"
+)
+
+func TestMapError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "#1 Пользователь не найден",
+			err:        fmt.Errorf("%w: id %d", domain.ErrUserNotFound, 1),
+			wantStatus: http.StatusNotFound,
+			wantCode:   "user_not_found",
+		},
+		{
+			name:       "#2 Задача не найдена",
+			err:        fmt.Errorf("%w: id %d", domain.ErrTaskNotFound, 1),
+			wantStatus: http.StatusNotFound,
+			wantCode:   "task_not_found",
+		},
+		{
+			name:       "#3 Повторяющийся паспорт",
+			err:        fmt.Errorf("%w: %s", domain.ErrDuplicatePassport, "1234 123456"),
+			wantStatus: http.StatusConflict,
+			wantCode:   "duplicate_passport",
+		},
+		{
+			name:       "#4 Задача уже запущена",
+			err:        domain.ErrAlreadyStarted,
+			wantStatus: http.StatusConflict,
+			wantCode:   "already_started",
+		},
+		{
+			name:       "#5 Задача уже завершена",
+			err:        domain.ErrAlreadyFinished,
+			wantStatus: http.StatusConflict,
+			wantCode:   "already_finished",
+		},
+		{
+			name:       "#6 Задача уже на паузе",
+			err:        domain.ErrAlreadyPaused,
+			wantStatus: http.StatusConflict,
+			wantCode:   "already_paused",
+		},
+		{
+			name:       "#7 Задача уже выполняется",
+			err:        domain.ErrAlreadyRunning,
+			wantStatus: http.StatusConflict,
+			wantCode:   "already_running",
+		},
+		{
+			name:       "#8 Задача не запущена",
+			err:        fmt.Errorf("%w: id %d", domain.ErrNotStarted, 1),
+			wantStatus: http.StatusPreconditionRequired,
+			wantCode:   "not_started",
+		},
+		{
+			name:       "#9 Ошибка стороннего API",
+			err:        fmt.Errorf("%w: неправильный статус код API: %d", domain.ErrEnrichmentFailed, 502),
+			wantStatus: http.StatusServiceUnavailable,
+			wantCode:   "enrichment_failed",
+		},
+		{
+			name:       "#10 Ошибка валидации",
+			err:        domain.NewValidationError("userID", "must be an integer"),
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   "validation_failed",
+		},
+		{
+			name:       "#11 Некорректное тело запроса",
+			err:        fmt.Errorf("%w: unexpected EOF", errInvalidBody),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "invalid_body",
+		},
+		{
+			name:       "#12 Истекло время запроса",
+			err:        fmt.Errorf("timeout: %w", context.DeadlineExceeded),
+			wantStatus: http.StatusGatewayTimeout,
+			wantCode:   "timeout",
+		},
+		{
+			name:       "#13 Пересечение интервалов",
+			err:        fmt.Errorf("%w: 2024-07-01T09:00:00Z - 2024-07-01T10:00:00Z", domain.ErrTimeOverlap),
+			wantStatus: http.StatusConflict,
+			wantCode:   "time_overlap",
+		},
+		{
+			name:       "#14 Удаление запущенной задачи",
+			err:        fmt.Errorf("%w: id %d", domain.ErrTaskRunning, 1),
+			wantStatus: http.StatusConflict,
+			wantCode:   "task_running",
+		},
+		{
+			name:       "#15 Неверный токен",
+			err:        domain.ErrInvalidToken,
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   "invalid_token",
+		},
+		{
+			name:       "#16 Проект не найден",
+			err:        fmt.Errorf("%w: id %d", domain.ErrProjectNotFound, 1),
+			wantStatus: http.StatusNotFound,
+			wantCode:   "project_not_found",
+		},
+		{
+			name:       "#17 Удаление клиента с проектами",
+			err:        fmt.Errorf("%w: id %d", domain.ErrClientHasProjects, 1),
+			wantStatus: http.StatusConflict,
+			wantCode:   "client_has_projects",
+		},
+		{
+			name:       "#18 Нет прав",
+			err:        domain.ErrForbidden,
+			wantStatus: http.StatusForbidden,
+			wantCode:   "forbidden",
+		},
+		{
+			name:       "#19 Неверный пароль",
+			err:        domain.ErrInvalidCredentials,
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   "invalid_credentials",
+		},
+		{
+			name:       "#20 Неизвестная ошибка",
+			err:        errors.New("connection refused"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "internal",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := mapError(tt.err)
+
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.wantCode, body.Error.Code)
+		})
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	rr := httptest.NewRecorder()
+	writeError(rr, fmt.Errorf("%w: id %d", domain.ErrTaskNotFound, 7))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+	var body models.ErrorResponse
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	assert.Equal(t, "task_not_found", body.Error.Code)
+	assert.Equal(t, "задача не найдена: id 7", body.Error.Message)
+
+	// внутренние ошибки не раскрываются клиенту
+	rr = httptest.NewRecorder()
+	writeError(rr, errors.New("pq: password authentication failed"))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	assert.Equal(t, "internal", body.Error.Code)
+	assert.NotContains(t, body.Error.Message, "password")
+}
+
+func TestWriteErrorValidationDetails(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	_, _, err := validator.ValidatePassport("passportNumber", "12a4 56789")
+
+	rr := httptest.NewRecorder()
+	writeError(rr, err)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+	var body models.ErrorResponse
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	assert.Equal(t, "validation_failed", body.Error.Code)
+	assert.Equal(t, map[string]string{
+		"passportNumber.series": "input character is not a digit",
+		"passportNumber.number": "input length must be 6 characters",
+	}, body.Error.Details)
+}
@@ -2,147 +2,267 @@ package handlers
 
 import (
 	"encoding/json"
-	"errors"
+	"fmt"
 	"github.com/go-chi/chi/v5"
-	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	httpSwagger "github.com/swaggo/http-swagger/v2"
 	"log"
 	"net/http"
 	"strconv"
-	"strings"
 	"time"
 	_ "time-tracker/docs"
 	"time-tracker/internal/API/apiDataUser"
+	"time-tracker/internal/auth"
 	"time-tracker/internal/config"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/export"
 	"time-tracker/internal/logger"
+	"time-tracker/internal/metrics"
 	"time-tracker/internal/models"
 	"time-tracker/internal/usecase"
 	"time-tracker/internal/validator"
 )
 
-func InitRoutes(useCase usecase.UseCaseStorage, conf *config.Config) chi.Router {
+func InitRoutes(useCase usecase.UseCaseStorage, conf *config.Config, tokens *auth.Tokens, enricher apiDataUser.Enricher) chi.Router {
 	r := chi.NewRouter()
 
 	r.Use(logger.WithLogging)
+	r.Use(metrics.WithMetrics)
 
-	r.Get("/swagger/*", httpSwagger.Handler(
-		httpSwagger.URL("http://"+conf.SERVER_HOST+":"+conf.SERVER_PORT+"/swagger/doc.json"), //The url pointing to API definition
-	))
-
-	r.Post("/user", func(w http.ResponseWriter, r *http.Request) {
-		HandlerAddUser(w, r, useCase, conf)
-	})
-	r.Delete("/user/{userID}", func(w http.ResponseWriter, r *http.Request) {
-		HandlerDelete(w, r, useCase)
-	})
-	r.Put("/user/{userID}", func(w http.ResponseWriter, r *http.Request) {
-		HandlerUpdate(w, r, useCase)
-	})
-	r.Get("/user/{userID}", func(w http.ResponseWriter, r *http.Request) {
-		HandlerGetUser(w, r, useCase)
-	})
-	r.Post("/users/{page}/{limit}", func(w http.ResponseWriter, r *http.Request) {
-		HandlerGetUsers(w, r, useCase)
+	r.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, errRouteNotFound)
 	})
-	r.Post("/task/{userID}", func(w http.ResponseWriter, r *http.Request) {
-		HandlerAddTask(w, r, useCase)
+	r.MethodNotAllowed(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, errMethodNotAllowed)
 	})
-	r.Put("/task/start/{taskID}", func(w http.ResponseWriter, r *http.Request) {
-		HandlerStartTime(w, r, useCase)
-	})
-	r.Put("/task/end/{taskID}", func(w http.ResponseWriter, r *http.Request) {
-		HandlerEndTime(w, r, useCase)
-	})
-	r.Post("/tasks/{userID}", func(w http.ResponseWriter, r *http.Request) {
-		HandlerGetTasks(w, r, useCase)
+
+	r.Group(func(r chi.Router) {
+		r.Use(withRequestTimeout(conf.REQUEST_TIMEOUT))
+
+		r.Get("/swagger/*", httpSwagger.Handler(
+			// относительный адрес: UI открывается на том же хосте и схеме, что и запрос, в том числе за прокси
+			httpSwagger.URL("doc.json"),
+		))
+
+		r.Handle("/metrics", promhttp.Handler())
+		r.Get("/healthz", HandlerHealthz)
+		r.Get("/readyz", func(w http.ResponseWriter, r *http.Request) {
+			HandlerReadyz(w, r, useCase, conf, enricher)
+		})
+
+		r.Post("/auth/login", func(w http.ResponseWriter, r *http.Request) {
+			HandlerLogin(w, r, useCase)
+		})
+		r.Post("/auth/refresh", func(w http.ResponseWriter, r *http.Request) {
+			HandlerRefresh(w, r, useCase)
+		})
+		r.Post("/auth/logout", func(w http.ResponseWriter, r *http.Request) {
+			HandlerLogout(w, r, useCase)
+		})
+		// календарь открывают календарные приложения, они авторизуются токеном подписки в query
+		r.Get("/users/{userID}/calendar.ics", func(w http.ResponseWriter, r *http.Request) {
+			HandlerCalendar(w, r, useCase)
+		})
+
+		// остальные маршруты доступны только с токеном доступа или ключом API
+		r.Group(func(r chi.Router) {
+			r.Use(withAuth(tokens, useCase))
+
+			r.Post("/user", func(w http.ResponseWriter, r *http.Request) {
+				HandlerAddUser(w, r, useCase)
+			})
+			r.Delete("/user/{userID}", func(w http.ResponseWriter, r *http.Request) {
+				HandlerDelete(w, r, useCase)
+			})
+			r.Put("/user/{userID}", func(w http.ResponseWriter, r *http.Request) {
+				HandlerUpdate(w, r, useCase)
+			})
+			r.Get("/user/{userID}", func(w http.ResponseWriter, r *http.Request) {
+				HandlerGetUser(w, r, useCase)
+			})
+			r.Post("/users/{page}/{limit}", func(w http.ResponseWriter, r *http.Request) {
+				HandlerGetUsers(w, r, useCase)
+			})
+			r.Post("/task/{userID}", func(w http.ResponseWriter, r *http.Request) {
+				HandlerAddTask(w, r, useCase)
+			})
+			r.Patch("/task/{taskID}", func(w http.ResponseWriter, r *http.Request) {
+				HandlerRenameTask(w, r, useCase)
+			})
+			r.Delete("/task/{taskID}", func(w http.ResponseWriter, r *http.Request) {
+				HandlerDeleteTask(w, r, useCase)
+			})
+			r.Put("/task/start/{taskID}", func(w http.ResponseWriter, r *http.Request) {
+				HandlerStartTime(w, r, useCase)
+			})
+			r.Put("/task/end/{taskID}", func(w http.ResponseWriter, r *http.Request) {
+				HandlerEndTime(w, r, useCase)
+			})
+			r.Put("/task/pause/{taskID}", func(w http.ResponseWriter, r *http.Request) {
+				HandlerPauseTask(w, r, useCase)
+			})
+			r.Put("/task/resume/{taskID}", func(w http.ResponseWriter, r *http.Request) {
+				HandlerResumeTask(w, r, useCase)
+			})
+			r.Put("/task/project/{taskID}", func(w http.ResponseWriter, r *http.Request) {
+				HandlerSetTaskProject(w, r, useCase)
+			})
+			r.Post("/task/tags/{taskID}", func(w http.ResponseWriter, r *http.Request) {
+				HandlerAddTaskTags(w, r, useCase)
+			})
+			r.Delete("/task/tags/{taskID}/{tag}", func(w http.ResponseWriter, r *http.Request) {
+				HandlerRemoveTaskTag(w, r, useCase)
+			})
+			r.Put("/task/time/{taskID}", func(w http.ResponseWriter, r *http.Request) {
+				HandlerUpdateTaskTime(w, r, useCase)
+			})
+			r.Post("/tasks/{userID}", func(w http.ResponseWriter, r *http.Request) {
+				HandlerGetTasks(w, r, useCase)
+			})
+			r.Post("/users/{userID}/tasks", func(w http.ResponseWriter, r *http.Request) {
+				HandlerAddManualTask(w, r, useCase)
+			})
+			r.Post("/users/{userID}/import", func(w http.ResponseWriter, r *http.Request) {
+				HandlerImportTasks(w, r, useCase)
+			})
+			r.Get("/users/{userID}/timer", func(w http.ResponseWriter, r *http.Request) {
+				HandlerGetTimer(w, r, useCase)
+			})
+			r.Get("/users/{userID}/settings", func(w http.ResponseWriter, r *http.Request) {
+				HandlerGetUserSettings(w, r, useCase)
+			})
+			r.Patch("/users/{userID}/settings", func(w http.ResponseWriter, r *http.Request) {
+				HandlerUpdateUserSettings(w, r, useCase)
+			})
+			r.Post("/users/{userID}/calendar/token", func(w http.ResponseWriter, r *http.Request) {
+				HandlerCreateCalendarToken(w, r, useCase)
+			})
+			r.Post("/clients", func(w http.ResponseWriter, r *http.Request) {
+				HandlerCreateClient(w, r, useCase)
+			})
+			r.Get("/clients", func(w http.ResponseWriter, r *http.Request) {
+				HandlerListClients(w, r, useCase)
+			})
+			r.Get("/clients/{clientID}", func(w http.ResponseWriter, r *http.Request) {
+				HandlerGetClient(w, r, useCase)
+			})
+			r.Put("/clients/{clientID}", func(w http.ResponseWriter, r *http.Request) {
+				HandlerUpdateClient(w, r, useCase)
+			})
+			r.Delete("/clients/{clientID}", func(w http.ResponseWriter, r *http.Request) {
+				HandlerDeleteClient(w, r, useCase)
+			})
+			r.Post("/projects", func(w http.ResponseWriter, r *http.Request) {
+				HandlerCreateProject(w, r, useCase)
+			})
+			r.Get("/projects", func(w http.ResponseWriter, r *http.Request) {
+				HandlerListProjects(w, r, useCase)
+			})
+			r.Get("/projects/{projectID}", func(w http.ResponseWriter, r *http.Request) {
+				HandlerGetProject(w, r, useCase)
+			})
+			r.Put("/projects/{projectID}", func(w http.ResponseWriter, r *http.Request) {
+				HandlerUpdateProject(w, r, useCase)
+			})
+			r.Delete("/projects/{projectID}", func(w http.ResponseWriter, r *http.Request) {
+				HandlerDeleteProject(w, r, useCase)
+			})
+			r.Put("/users/{userID}/credentials", func(w http.ResponseWriter, r *http.Request) {
+				HandlerSetCredentials(w, r, useCase)
+			})
+			r.Put("/users/{userID}/role", func(w http.ResponseWriter, r *http.Request) {
+				HandlerSetUserRole(w, r, useCase)
+			})
+			r.Put("/users/{userID}/manager", func(w http.ResponseWriter, r *http.Request) {
+				HandlerSetUserManager(w, r, useCase)
+			})
+			r.Get("/users/{userID}/team", func(w http.ResponseWriter, r *http.Request) {
+				HandlerListTeam(w, r, useCase)
+			})
+			r.Get("/roles", func(w http.ResponseWriter, r *http.Request) {
+				HandlerListRoles(w, r, useCase)
+			})
+			r.Post("/users/{userID}/api-keys", func(w http.ResponseWriter, r *http.Request) {
+				HandlerCreateAPIKey(w, r, useCase)
+			})
+			r.Get("/users/{userID}/api-keys", func(w http.ResponseWriter, r *http.Request) {
+				HandlerListAPIKeys(w, r, useCase)
+			})
+			r.Delete("/users/{userID}/api-keys/{keyID}", func(w http.ResponseWriter, r *http.Request) {
+				HandlerRevokeAPIKey(w, r, useCase)
+			})
+			r.Get("/users/{userID}/enrichment", func(w http.ResponseWriter, r *http.Request) {
+				HandlerEnrichmentStatus(w, r, useCase)
+			})
+			r.Post("/users/{userID}/enrichment", func(w http.ResponseWriter, r *http.Request) {
+				HandlerRetryEnrichment(w, r, useCase)
+			})
+			r.Get("/tags", func(w http.ResponseWriter, r *http.Request) {
+				HandlerListTags(w, r, useCase)
+			})
+			///тесты
+			r.Post("/test", func(w http.ResponseWriter, r *http.Request) {
+				HandlerCreat(w, r, useCase)
+			})
+		})
 	})
-	///тесты
-	r.Post("/test", func(w http.ResponseWriter, r *http.Request) {
-		HandlerCreat(w, r, useCase)
+
+	// список задач и отчет отдаются и файлами, выгрузка ограничена EXPORT_TIMEOUT вместо REQUEST_TIMEOUT
+	r.Group(func(r chi.Router) {
+		r.Use(withExportTimeout(conf.REQUEST_TIMEOUT, conf.EXPORT_TIMEOUT))
+		r.Use(withAuth(tokens, useCase))
+
+		r.Get("/users/{userID}/tasks", func(w http.ResponseWriter, r *http.Request) {
+			HandlerListTasks(w, r, useCase)
+		})
+		r.Get("/users/{userID}/report", func(w http.ResponseWriter, r *http.Request) {
+			HandlerReport(w, r, useCase)
+		})
 	})
 
 	return r
 }
 
 // @Summary Добавление нового пользователя
-// @Description Добавляет нового пользователя на основе серии и номера паспорта, обогащает информацию через внешний API (если в .env не указан URL API - получим ответ 500)
+// @Description Добавляет нового пользователя по серии и номеру паспорта и сразу отвечает 202. ФИО и адрес заполняются в фоне
+// @Description из стороннего API (API_URL) через очередь с повторами; состояние - GET /users/{userID}/enrichment.
 // @Tags Users
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param body body models.PassportRequest true "Серия и номер пасспорта в формате `1234 123456` (4 цифры, пробел, 6 цифр)"
-// @Success 200 {string} string "UserID"
-// @Failure 400 {string} string "Ошибка декодирования тела запроса"
-// @Failure 409 {string} string "Ошибка записи: Пользователь с таким номером паспорта уже существует"
-// @Failure 422 {string} string "Ошибка валидации серии паспорта или номера паспорта"
-// @Failure 500 {string} string "Ошибка сервера"
-// @Failure 503 {string} string "Ошибка запроса к стороннему API"
+// @Success 202 {object} models.UserCreated "ID пользователя, обогащение данных в очереди"
+// @Failure 400 {object} models.ErrorResponse "Ошибка декодирования тела запроса"
+// @Failure 409 {object} models.ErrorResponse "Ошибка записи: Пользователь с таким номером паспорта уже существует"
+// @Failure 422 {object} models.ErrorResponse "Ошибка валидации серии паспорта или номера паспорта (в details - какая часть не прошла проверку)"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
 // @Router /user [post]
-func HandlerAddUser(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage, conf *config.Config) {
+func HandlerAddUser(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
 	if r.Method != http.MethodPost {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		writeError(w, errMethodNotAllowed)
 		return
 	}
 	var req models.PassportRequest
-	dec := json.NewDecoder(r.Body)
-
-	dec.DisallowUnknownFields()
-
 	// Попытка декодировать JSON в структуру UserData
-	if err := dec.Decode(&req); err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
 		return
 	}
 	defer r.Body.Close()
 
-	parts := strings.Split(req.PassportNumber, " ")
-
-	if err := validator.ValidateDigits(parts[0], 4); err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		return
-	}
-
-	if err := validator.ValidateDigits(parts[1], 6); err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		return
-	}
-
-	userData, err := apiDataUser.GetPeopleInfoFromAPI(parts[0], parts[1], conf.API_URL)
-	if err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusServiceUnavailable)
-		return
-	}
-
-	user_id, err := useCase.UseCaseCreate(*userData)
+	passportSeries, passportNumber, err := validator.ValidatePassport("passportNumber", req.PassportNumber)
 	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) {
-			if pgErr.Code == "23505" {
-				logger.SugaredLogger().Debug(err)
-				w.WriteHeader(http.StatusConflict)
-				return
-			}
-		}
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Ошибка записи в БД"))
+		writeError(w, err)
 		return
 	}
 
-	response := map[string]int{"UserID": user_id}
-	res, err := json.Marshal(response)
+	userID, err := useCase.UseCaseCreatePendingUser(r.Context(), passportSeries+" "+passportNumber)
 	if err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	w.Write(res)
+	writeJSONStatus(w, http.StatusAccepted, models.UserCreated{UserID: userID, EnrichmentStatus: models.EnrichmentPending})
 }
 
 // @Summary		Тестовый хендлер: добавление пользователя в обход стороннего API
@@ -152,35 +272,22 @@ func HandlerAddUser(w http.ResponseWriter, r *http.Request, useCase usecase.UseC
 // @Produce		plain
 // @Param		body	body		models.PassportRequest	true	"Серия и номер пасспорта в формате `1234 123456` (4 цифры, пробел, 6 цифр)"
 // @Success 200 {string} string "UserID"
-// @Failure 400 {string} string "Ошибка декодирования тела запроса"
-// @Failure 409 {string} string "Ошибка записи: Пользователь с таким номером паспорта уже существует"
-// @Failure 422 {string} string "Ошибка валидации серии паспорта или номера паспорта"
-// @Failure 500 {string} string "Ошибка сервера"
+// @Failure 400 {object} models.ErrorResponse "Ошибка декодирования тела запроса"
+// @Failure 409 {object} models.ErrorResponse "Ошибка записи: Пользователь с таким номером паспорта уже существует"
+// @Failure 422 {object} models.ErrorResponse "Ошибка валидации серии паспорта или номера паспорта (в details - какая часть не прошла проверку)"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
 // @Router	/test [post]
 func HandlerCreat(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
 	var req models.PassportRequest
-	dec := json.NewDecoder(r.Body)
-	dec.DisallowUnknownFields()
-
 	// Попытка декодировать JSON в структуру UserData
-	if err := dec.Decode(&req); err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
 		return
 	}
 	defer r.Body.Close()
 
-	parts := strings.Split(req.PassportNumber, " ")
-	passportSeries := parts[0]
-	if err := validator.ValidateDigits(passportSeries, 4); err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		return
-	}
-	passportNumber := parts[1]
-	if err := validator.ValidateDigits(passportNumber, 6); err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusUnprocessableEntity)
+	if _, _, err := validator.ValidatePassport("passportNumber", req.PassportNumber); err != nil {
+		writeError(w, err)
 		return
 	}
 
@@ -192,27 +299,16 @@ func HandlerCreat(w http.ResponseWriter, r *http.Request, useCase usecase.UseCas
 		Address:        validator.GenerateRandomString(15),
 	}
 
-	userID, err := useCase.UseCaseCreate(userData)
+	userID, err := useCase.UseCaseCreate(r.Context(), userData)
 	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) {
-			if pgErr.Code == "23505" {
-				logger.SugaredLogger().Debug(err)
-				w.WriteHeader(http.StatusConflict)
-				return
-			}
-		}
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Ошибка записи в БД"))
+		writeError(w, err)
 		return
 	}
 
 	response := map[string]int{"UserID": userID}
 	res, err := json.Marshal(response)
 	if err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
@@ -226,36 +322,28 @@ func HandlerCreat(w http.ResponseWriter, r *http.Request, useCase usecase.UseCas
 // @Tags Users
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param userID path int true "User ID" Format(int)
 // @Success 200 {string} string "Пользователь успешно удален"
-// @Failure 404 {string} string "Пользователь не найден"
-// @Failure 422 {string} string "Ошибка конвертирования ID"
-// @Failure 500 {string} string "Ошибка сервера"
+// @Failure 404 {object} models.ErrorResponse "Пользователь не найден"
+// @Failure 422 {object} models.ErrorResponse "Ошибка конвертирования ID"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
 // @Router /user/{userID} [delete]
 func HandlerDelete(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
 	if r.Method != http.MethodDelete {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		writeError(w, errMethodNotAllowed)
 		return
 	}
 
-	userIDStr := chi.URLParam(r, "userID")
-
-	userID, err := strconv.Atoi(userIDStr)
+	userID, err := urlParamInt(r, "userID")
 	if err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusUnprocessableEntity)
+		writeError(w, err)
 		return
 	}
 
-	err = useCase.UseCaseDelete(userID)
+	err = useCase.UseCaseDelete(r.Context(), userID)
 	if err != nil {
-		if strings.Contains(err.Error(), "не найден") {
-			logger.SugaredLogger().Debug(err)
-			w.WriteHeader(http.StatusNotFound)
-		} else {
-			logger.SugaredLogger().Debug(err)
-			w.WriteHeader(http.StatusInternalServerError)
-		}
+		writeError(w, err)
 		return
 	}
 
@@ -267,72 +355,45 @@ func HandlerDelete(w http.ResponseWriter, r *http.Request, useCase usecase.UseCa
 // @Tags Users
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param userID path int true "User ID" Format(int64)
 // @Param body body models.UserData true "Данные пользователя (неменяемые поля оставляем пустыми)"
 // @Success 200 {string} string "Данные пользователя успешно обновлены"
-// @Failure 400 {string} string "Ошибка декодирования тела запроса"
-// @Failure 404 {string} string "Пользователь не найден"
-// @Failure 409 {string} string "Ошибка записи: Пользователь с таким номером паспорта уже существует"
-// @Failure 422 {string} string "Ошибка конвертирования ID"
-// @Failure 500 {string} string "Ошибка сервера"
+// @Failure 400 {object} models.ErrorResponse "Ошибка декодирования тела запроса"
+// @Failure 404 {object} models.ErrorResponse "Пользователь не найден"
+// @Failure 409 {object} models.ErrorResponse "Ошибка записи: Пользователь с таким номером паспорта уже существует"
+// @Failure 422 {object} models.ErrorResponse "Ошибка конвертирования ID"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
 // @Router /user/{userID} [patch]
 func HandlerUpdate(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
 	if r.Method != http.MethodPut {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		writeError(w, errMethodNotAllowed)
 		return
 	}
 
-	userIDstr := chi.URLParam(r, "userID")
-
-	userID, err := strconv.Atoi(userIDstr)
+	userID, err := urlParamInt(r, "userID")
 	if err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusUnprocessableEntity)
+		writeError(w, err)
 		return
 	}
 
 	var req models.UserData
-	dec := json.NewDecoder(r.Body)
-	dec.DisallowUnknownFields()
-
-	if err := dec.Decode(&req); err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
 		return
 	}
 	defer r.Body.Close()
 
 	if len(req.PassportNumber) > 0 {
-		parts := strings.Split(req.PassportNumber, " ")
-		if err := validator.ValidateDigits(parts[0], 4); err != nil {
-			logger.SugaredLogger().Debug(err)
-			w.WriteHeader(http.StatusBadRequest)
-			return
-		}
-		if err := validator.ValidateDigits(parts[1], 6); err != nil {
-			logger.SugaredLogger().Debug(err)
-			w.WriteHeader(http.StatusBadRequest)
+		if _, _, err := validator.ValidatePassport("passport_number", req.PassportNumber); err != nil {
+			writeError(w, err)
 			return
 		}
 	}
 
-	err = useCase.UseCaseUpdate(userID, req)
+	err = useCase.UseCaseUpdate(r.Context(), userID, req)
 	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) {
-			if pgErr.Code == "23505" {
-				logger.SugaredLogger().Debug("Ошибка:", err)
-				w.WriteHeader(http.StatusConflict)
-				return
-			}
-		}
-		if strings.Contains(err.Error(), "не найден") {
-			logger.SugaredLogger().Debug(err)
-			w.WriteHeader(http.StatusNotFound)
-		} else {
-			logger.SugaredLogger().Debug(err)
-			w.WriteHeader(http.StatusInternalServerError)
-		}
+		writeError(w, err)
 		return
 	}
 
@@ -344,43 +405,34 @@ func HandlerUpdate(w http.ResponseWriter, r *http.Request, useCase usecase.UseCa
 // @Tags Users
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param userID path int true "User ID"
 // @Success 200 {object} models.UserData "Успешный ответ с данными пользователя"
-// @Failure 404 {string} string "Пользователь не найден"
-// @Failure 422 {string} string "Ошибка конвертирования ID"
-// @Failure 500 {string} string "Ошибка сервера"
+// @Failure 404 {object} models.ErrorResponse "Пользователь не найден"
+// @Failure 422 {object} models.ErrorResponse "Ошибка конвертирования ID"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
 // @Router /user/{userID} [get]
 func HandlerGetUser(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
 	if r.Method != http.MethodGet {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		writeError(w, errMethodNotAllowed)
 		return
 	}
 
-	userIDStr := chi.URLParam(r, "userID")
-
-	userID, err := strconv.Atoi(userIDStr)
+	userID, err := urlParamInt(r, "userID")
 	if err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusUnprocessableEntity)
+		writeError(w, err)
 		return
 	}
 
-	userData, err := useCase.UseCaseRead(userID)
+	userData, err := useCase.UseCaseRead(r.Context(), userID)
 	if err != nil {
-		if strings.Contains(err.Error(), "не найден") {
-			logger.SugaredLogger().Debug(err)
-			w.WriteHeader(http.StatusNotFound)
-		} else {
-			logger.SugaredLogger().Debug(err)
-			w.WriteHeader(http.StatusInternalServerError)
-		}
+		writeError(w, err)
 		return
 	}
 
 	response, err := json.Marshal(userData)
 	if err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
@@ -394,25 +446,22 @@ func HandlerGetUser(w http.ResponseWriter, r *http.Request, useCase usecase.UseC
 // @Tags Users
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param page path int true "Номер страницы"
 // @Param limit path int true "Количество элементов на странице"
 // @Param body body models.UserData false "Фильтр пользователей (выбираем по каким полям будет фильтрация, вписываем туда ключ фильтра. Ненужные делаем пусытими или удаляем)"
 // @Success 200 {array} models.UserData "Успешный ответ с данными пользователей"
-// @Failure 500 {string} string "Ошибка сервера"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
 // @Router /users/{page}/{limit} [post]
 func HandlerGetUsers(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
 	if r.Method != http.MethodPost {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		writeError(w, errMethodNotAllowed)
 		return
 	}
 
 	var req models.UserData
-	dec := json.NewDecoder(r.Body)
-	dec.DisallowUnknownFields()
-
-	if err := dec.Decode(&req); err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusInternalServerError)
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
 		return
 	}
 
@@ -439,17 +488,15 @@ func HandlerGetUsers(w http.ResponseWriter, r *http.Request, useCase usecase.Use
 	}
 
 	// Используем параметры фильтрации и пагинации в запросе к базе данных
-	users, err := useCase.UseCaseGetUsers(req, page, limit)
+	users, err := useCase.UseCaseGetUsers(r.Context(), req, page, limit)
 	if err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
 	res, err := json.Marshal(users)
 	if err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
@@ -464,60 +511,52 @@ func HandlerGetUsers(w http.ResponseWriter, r *http.Request, useCase usecase.Use
 // @Tags Tasks
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param userID path int true "ID пользователя"
-// @Param body body models.TaskName true "Название задачи"
+// @Param body body models.TaskName true "Название задачи (не длиннее 100 символов)"
 // @Success 200 {string} string "TaskID: {taskID}"
-// @Failure 404 {string} string "Пользователь не найден"
-// @Failure 422 {string} string "Ошибка конвертирования UserID"
-// @Failure 500 {string} string "Ошибка сервера"
+// @Failure 400 {object} models.ErrorResponse "Ошибка декодирования тела запроса"
+// @Failure 404 {object} models.ErrorResponse "Пользователь не найден"
+// @Failure 422 {object} models.ErrorResponse "Ошибка конвертирования UserID или пустое/слишком длинное название задачи"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
 // @Router /task/{userID} [post]
 func HandlerAddTask(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
 	if r.Method != http.MethodPost {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		writeError(w, errMethodNotAllowed)
 		return
 	}
 
-	userIDstr := chi.URLParam(r, "userID")
-	userID, err := strconv.Atoi(userIDstr)
+	userID, err := urlParamInt(r, "userID")
 	if err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusUnprocessableEntity)
+		writeError(w, err)
 		return
 	}
 
 	var taskName models.TaskName
 
-	dec := json.NewDecoder(r.Body)
-	dec.DisallowUnknownFields()
-
 	// Попытка декодировать JSON в структуру UserData
-	if err := dec.Decode(&taskName); err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusInternalServerError)
+	if err := decodeJSON(r, &taskName); err != nil {
+		writeError(w, err)
 		return
 	}
 
 	defer r.Body.Close()
 
-	taskID, err := useCase.UseCaseCreateTask(userID, taskName.Name)
+	if err := validator.ValidateTaskName("task_name", taskName.Name); err != nil {
+		writeError(w, err)
+		return
+	}
 
+	taskID, err := useCase.UseCaseCreateTask(r.Context(), userID, taskName.Name)
 	if err != nil {
-
-		if strings.Contains(err.Error(), "не найден") {
-			logger.SugaredLogger().Debug(err)
-			w.WriteHeader(http.StatusNotFound)
-		} else {
-			logger.SugaredLogger().Debug(err)
-			w.WriteHeader(http.StatusInternalServerError)
-		}
+		writeError(w, err)
 		return
 	}
 
 	response := map[string]int{"TaskID": taskID}
 	res, err := json.Marshal(response)
 	if err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
@@ -526,44 +565,115 @@ func HandlerAddTask(w http.ResponseWriter, r *http.Request, useCase usecase.UseC
 	w.Write(res)
 }
 
+// @Summary Переименование задачи
+// @Description Изменяет название задачи по её ID.
+// @Tags Tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param taskID path int true "ID задачи"
+// @Param body body models.TaskName true "Новое название задачи (не длиннее 100 символов)"
+// @Success 200 {string} string "Задача переименована"
+// @Failure 400 {object} models.ErrorResponse "Ошибка декодирования тела запроса"
+// @Failure 404 {object} models.ErrorResponse "Задача не найдена"
+// @Failure 422 {object} models.ErrorResponse "Ошибка Task ID или пустое/слишком длинное название задачи"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /task/{taskID} [patch]
+func HandlerRenameTask(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodPatch {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	taskID, err := urlParamInt(r, "taskID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var taskName models.TaskName
+	if err := decodeJSON(r, &taskName); err != nil {
+		writeError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := validator.ValidateTaskName("task_name", taskName.Name); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	err = useCase.UseCaseRenameTask(r.Context(), taskID, taskName.Name)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Удаление задачи
+// @Description Удаляет задачу вместе с её интервалами. Запущенную задачу нужно сначала приостановить или завершить.
+// @Tags Tasks
+// @Produce json
+// @Security BearerAuth
+// @Param taskID path int true "ID задачи"
+// @Success 200 {string} string "Задача успешно удалена"
+// @Failure 404 {object} models.ErrorResponse "Задача не найдена"
+// @Failure 409 {object} models.ErrorResponse "Задача выполняется"
+// @Failure 422 {object} models.ErrorResponse "Ошибка Task ID"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /task/{taskID} [delete]
+func HandlerDeleteTask(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodDelete {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	taskID, err := urlParamInt(r, "taskID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	err = useCase.UseCaseDeleteTask(r.Context(), taskID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
 // @Summary Начать отсчет времени по задаче для пользователя
 // @Description Устанавливает время начала выполнения задачи по её ID.
+// @Description Если у пользователя включена политика одного таймера (single_timer), его запущенная задача сначала завершается.
 // @Tags Tasks
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param taskID path int true "ID задачи"
 // @Success 200 {string} string "TaskID: {taskID}"
-// @Failure 404 {string} string "Задача не найдена"
-// @Failure 409 {string} string "Время начала уже установлено"
-// @Failure 422 {string} string "Ошибка Task ID"
-// @Failure 500 {string} string "Ошибка сервера"
+// @Failure 404 {object} models.ErrorResponse "Задача не найдена"
+// @Failure 409 {object} models.ErrorResponse "Время начала уже установлено"
+// @Failure 422 {object} models.ErrorResponse "Ошибка Task ID"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
 // @Router /task/start/{taskID} [put]
 func HandlerStartTime(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
 	if r.Method != http.MethodPut {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		writeError(w, errMethodNotAllowed)
 		return
 	}
 
-	taskIDStr := chi.URLParam(r, "taskID")
-	taskID, err := strconv.Atoi(taskIDStr)
+	taskID, err := urlParamInt(r, "taskID")
 	if err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusUnprocessableEntity)
+		writeError(w, err)
 		return
 	}
 
-	err = useCase.UseCaseAddStartTime(taskID)
+	err = useCase.UseCaseAddStartTime(r.Context(), taskID)
 	if err != nil {
-		if strings.Contains(err.Error(), "не найдена") {
-			logger.SugaredLogger().Debug(err)
-			w.WriteHeader(http.StatusNotFound)
-		} else if strings.Contains(err.Error(), "уже заполнено") {
-			logger.SugaredLogger().Debug(err)
-			w.WriteHeader(http.StatusConflict)
-		} else {
-			logger.SugaredLogger().Debug(err)
-			w.WriteHeader(http.StatusInternalServerError)
-		}
+		writeError(w, err)
 		return
 	}
 
@@ -575,43 +685,215 @@ func HandlerStartTime(w http.ResponseWriter, r *http.Request, useCase usecase.Us
 // @Tags Tasks
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param taskID path int true "ID задачи"
 // @Success 200 {string} string "TaskID: {taskID}"
-// @Failure 404 {string} string "Задача не найдена"
-// @Failure 409 {string} string "Время старта уже задано"
-// @Failure 422 {string} string "Ошибка Task ID"
-// @Failure 428 {string} string "Не заполнено поле StartTime"
-// @Failure 500 {string} string "Ошибка сервера"
+// @Failure 404 {object} models.ErrorResponse "Задача не найдена"
+// @Failure 409 {object} models.ErrorResponse "Время старта уже задано"
+// @Failure 422 {object} models.ErrorResponse "Ошибка Task ID"
+// @Failure 428 {object} models.ErrorResponse "Не заполнено поле StartTime"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
 // @Router /task/end/{taskID} [put]
 func HandlerEndTime(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
 	if r.Method != http.MethodPut {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		writeError(w, errMethodNotAllowed)
 		return
 	}
 
-	taskIDStr := chi.URLParam(r, "taskID")
-	taskID, err := strconv.Atoi(taskIDStr)
+	taskID, err := urlParamInt(r, "taskID")
 	if err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusUnprocessableEntity)
+		writeError(w, err)
 		return
 	}
 
-	err = useCase.UseCaseAddEndTime(taskID)
+	err = useCase.UseCaseAddEndTime(r.Context(), taskID)
 	if err != nil {
-		if strings.Contains(err.Error(), "не найдена") {
-			logger.SugaredLogger().Debug(err)
-			w.WriteHeader(http.StatusNotFound)
-		} else if strings.Contains(err.Error(), "уже заполнено") {
-			logger.SugaredLogger().Debug(err)
-			w.WriteHeader(http.StatusConflict)
-		} else if strings.Contains(err.Error(), "не заполнено") {
-			logger.SugaredLogger().Debug(err)
-			w.WriteHeader(http.StatusPreconditionRequired)
-		} else {
-			logger.SugaredLogger().Debug(err)
-			w.WriteHeader(http.StatusInternalServerError)
-		}
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Поставить задачу на паузу
+// @Description Закрывает текущий интервал работы над задачей. В all_time попадает сумма всех закрытых интервалов.
+// @Tags Tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param taskID path int true "ID задачи"
+// @Success 200 {string} string "Задача приостановлена"
+// @Failure 404 {object} models.ErrorResponse "Задача не найдена"
+// @Failure 409 {object} models.ErrorResponse "Задача уже на паузе или завершена"
+// @Failure 422 {object} models.ErrorResponse "Ошибка Task ID"
+// @Failure 428 {object} models.ErrorResponse "Не заполнено поле StartTime"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /task/pause/{taskID} [put]
+func HandlerPauseTask(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodPut {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	taskID, err := urlParamInt(r, "taskID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	err = useCase.UseCasePauseTask(r.Context(), taskID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Возобновить задачу после паузы
+// @Description Открывает новый интервал работы над задачей, поставленной на паузу.
+// @Description Если у пользователя включена политика одного таймера (single_timer), остальные его запущенные задачи завершаются.
+// @Tags Tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param taskID path int true "ID задачи"
+// @Success 200 {string} string "Задача возобновлена"
+// @Failure 404 {object} models.ErrorResponse "Задача не найдена"
+// @Failure 409 {object} models.ErrorResponse "Задача уже выполняется или завершена"
+// @Failure 422 {object} models.ErrorResponse "Ошибка Task ID"
+// @Failure 428 {object} models.ErrorResponse "Не заполнено поле StartTime"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /task/resume/{taskID} [put]
+func HandlerResumeTask(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodPut {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	taskID, err := urlParamInt(r, "taskID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	err = useCase.UseCaseResumeTask(r.Context(), taskID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Добавление задачи вручную
+// @Description Создает завершенную задачу с указанными временем начала и окончания, например если таймер забыли запустить.
+// @Description Период не должен пересекаться с другими задачами пользователя. Задача помечается как ручная (is_manual).
+// @Tags Tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userID path int true "ID пользователя"
+// @Param body body models.ManualTask true "Название задачи, начало и конец в формате RFC3339"
+// @Success 200 {string} string "TaskID: {taskID}"
+// @Failure 400 {object} models.ErrorResponse "Ошибка декодирования тела запроса"
+// @Failure 404 {object} models.ErrorResponse "Пользователь не найден"
+// @Failure 409 {object} models.ErrorResponse "Период пересекается с другими задачами пользователя"
+// @Failure 422 {object} models.ErrorResponse "Ошибка UserID, названия задачи или периода (конец должен быть позже начала)"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /users/{userID}/tasks [post]
+func HandlerAddManualTask(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodPost {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	userID, err := urlParamInt(r, "userID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var task models.ManualTask
+	if err := decodeJSON(r, &task); err != nil {
+		writeError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := validator.ValidateTaskName("task_name", task.Name); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	start, end, err := parsePeriod(task.Start, task.End)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	taskID, err := useCase.UseCaseCreateManualTask(r.Context(), userID, task.Name, start, end)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	response := map[string]int{"TaskID": taskID}
+	res, err := json.Marshal(response)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+}
+
+// @Summary Изменение времени начала и окончания задачи
+// @Description Задает задаче время начала и окончания вручную и пересчитывает all_time.
+// @Description Интервалы задачи заменяются одним интервалом, запущенная задача становится завершенной. Задача помечается как ручная (is_manual).
+// @Tags Tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param taskID path int true "ID задачи"
+// @Param body body models.TaskPeriod true "Начало и конец в формате RFC3339"
+// @Success 200 {string} string "Время задачи изменено"
+// @Failure 400 {object} models.ErrorResponse "Ошибка декодирования тела запроса"
+// @Failure 404 {object} models.ErrorResponse "Задача не найдена"
+// @Failure 409 {object} models.ErrorResponse "Период пересекается с другими задачами пользователя"
+// @Failure 422 {object} models.ErrorResponse "Ошибка Task ID или периода (конец должен быть позже начала)"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /task/time/{taskID} [put]
+func HandlerUpdateTaskTime(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodPut {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	taskID, err := urlParamInt(r, "taskID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var period models.TaskPeriod
+	if err := decodeJSON(r, &period); err != nil {
+		writeError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	start, end, err := parsePeriod(period.Start, period.End)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	err = useCase.UseCaseUpdateTaskTime(r.Context(), taskID, start, end)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
@@ -623,35 +905,29 @@ func HandlerEndTime(w http.ResponseWriter, r *http.Request, useCase usecase.UseC
 // @Tags Tasks
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param userID path int true "ID пользователя"
 // @Param body body models.TaskTime true "Фильтрация по периоду времени: start - начало периода, end - конец периода. Начало и конец прописывать в формате ДД.ММ.ГГГГ"
 // @Success 200 {array} models.Tasks "Список задач пользователя"
-// @Failure 404 {string} string "Данные не найдены"
-// @Failure 422 {string} string "Неправильный ID пользователя"
-// @Failure 500 {string} string "Ошибка сервера"
+// @Failure 404 {object} models.ErrorResponse "Данные не найдены"
+// @Failure 422 {object} models.ErrorResponse "Неправильный ID пользователя"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
 // @Router /tasks/{userID} [post]
 func HandlerGetTasks(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
 	if r.Method != http.MethodPost {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		writeError(w, errMethodNotAllowed)
 		return
 	}
 
-	userIDstr := chi.URLParam(r, "userID")
-
-	userID, err := strconv.Atoi(userIDstr)
+	userID, err := urlParamInt(r, "userID")
 	if err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusUnprocessableEntity)
+		writeError(w, err)
 		return
 	}
 
 	var period models.TaskTime
-	dec := json.NewDecoder(r.Body)
-	dec.DisallowUnknownFields()
-
-	if err := dec.Decode(&period); err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusInternalServerError)
+	if err := decodeJSON(r, &period); err != nil {
+		writeError(w, err)
 		return
 	}
 	defer r.Body.Close()
@@ -670,14 +946,12 @@ func HandlerGetTasks(w http.ResponseWriter, r *http.Request, useCase usecase.Use
 	// Преобразуем строки Start и End в тип time.Time
 	sTime, err := time.Parse("02.01.2006", period.Start)
 	if err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusInternalServerError)
+		writeError(w, domain.NewValidationError("start", "expected format: DD.MM.YYYY"))
 		return
 	}
 	eTime, err := time.Parse("02.01.2006", period.End)
 	if err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusInternalServerError)
+		writeError(w, domain.NewValidationError("end", "expected format: DD.MM.YYYY"))
 		return
 	}
 	period.Start = sTime.Format("2006-01-02 15:04:05")
@@ -685,18 +959,15 @@ func HandlerGetTasks(w http.ResponseWriter, r *http.Request, useCase usecase.Use
 
 	log.Println(period.Start, period.End)
 
-	userData, err := useCase.UseCaseGetTasksUser(userID, period)
-
+	userData, err := useCase.UseCaseGetTasksUser(r.Context(), userID, period)
 	if err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
 	res, err := json.Marshal(userData)
 	if err != nil {
-		logger.SugaredLogger().Debug(err)
-		w.WriteHeader(http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
@@ -705,3 +976,152 @@ func HandlerGetTasks(w http.ResponseWriter, r *http.Request, useCase usecase.Use
 	w.Write(res)
 
 }
+
+// @Summary Список задач пользователя с фильтрами
+// @Description Возвращает задачи пользователя полностью (id, время старта и окончания, длительность в секундах) с фильтрацией, сортировкой и пагинацией по курсору.
+// @Description Для следующей страницы передайте next_cursor из ответа в параметр cursor, не меняя сортировку.
+// @Description Выгрузка в CSV или XLSX выбирается параметром format или заголовком Accept (text/csv, application/vnd.openxmlformats-officedocument.spreadsheetml.sheet):
+// @Description по строке на каждую задачу (пользователь, задача, проект, клиент, начало, окончание, длительность) в часовом поясе пользователя, в XLSX также лист итогов.
+// @Description В выгрузку попадают все задачи по фильтру в заданном порядке, limit и cursor не учитываются.
+// @Tags Tasks
+// @Produce json
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param userID path int true "ID пользователя"
+// @Param status query string false "Статус задачи" Enums(not_started, running, paused, finished)
+// @Param name query string false "Подстрока названия задачи (без учета регистра)"
+// @Param project_id query int false "ID проекта"
+// @Param client_id query int false "ID клиента (задачи всех его проектов)"
+// @Param tags query string false "Теги через запятую"
+// @Param tag_match query string false "Задачи с любым из тегов или со всеми тегами" Enums(any, all) default(any)
+// @Param from query string false "Задачи, начатые не раньше даты (ГГГГ-ММ-ДД в часовом поясе пользователя или RFC3339)"
+// @Param to query string false "Задачи, начатые не позже даты (ГГГГ-ММ-ДД включительно в часовом поясе пользователя или RFC3339)"
+// @Param sort query string false "Поле сортировки; duration запущенной задачи сортируется без текущего интервала" Enums(id, name, start_time, end_time, duration) default(id)
+// @Param order query string false "Направление сортировки" Enums(asc, desc) default(asc)
+// @Param limit query int false "Количество задач на странице (1-100)" default(20)
+// @Param cursor query string false "Курсор следующей страницы"
+// @Param format query string false "Формат ответа (по умолчанию - по заголовку Accept, иначе json)" Enums(json, csv, xlsx)
+// @Success 200 {object} models.TaskPage "Страница задач"
+// @Failure 404 {object} models.ErrorResponse "Пользователь не найден"
+// @Failure 422 {object} models.ErrorResponse "Ошибка валидации параметров (в details - какие параметры неверны)"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /users/{userID}/tasks [get]
+func HandlerListTasks(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodGet {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	userID, err := urlParamInt(r, "userID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	filter, format, err := parseTaskFilter(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if format != export.FormatJSON {
+		writeExport(w, r, format, fmt.Sprintf("tasks-%d.%s", userID, format), func(row func(models.ExportRow) error) error {
+			return useCase.UseCaseExportTasks(r.Context(), userID, filter, row)
+		})
+		return
+	}
+
+	page, err := useCase.UseCaseListTasks(r.Context(), userID, filter)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	res, err := json.Marshal(page)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+}
+
+// parseTaskFilter собирает фильтр списка задач и формат ответа из query-параметров
+func parseTaskFilter(r *http.Request) (models.TaskFilter, string, error) {
+	query := r.URL.Query()
+	vErr := &domain.ValidationError{}
+
+	filter := models.TaskFilter{
+		Status: query.Get("status"),
+		Name:   query.Get("name"),
+		SortBy: models.TaskSortID,
+		Cursor: query.Get("cursor"),
+		Limit:  20,
+	}
+
+	filter.ProjectID = queryID(r, "project_id", vErr)
+	filter.ClientID = queryID(r, "client_id", vErr)
+	filter.Tags, filter.TagMatch = parseTagsQuery(r, vErr)
+
+	switch filter.Status {
+	case "", models.TaskStatusNotStarted, models.TaskStatusRunning, models.TaskStatusPaused, models.TaskStatusFinished:
+	default:
+		vErr.Add("status", "must be one of: not_started, running, paused, finished")
+	}
+
+	// дату без времени хранилище переводит в часовой пояс пользователя, как в отчете
+	for _, param := range []struct {
+		name       string
+		value, day *time.Time
+	}{{"from", &filter.From, &filter.FromDay}, {"to", &filter.To, &filter.ToDay}} {
+		value := query.Get(param.name)
+		if value == "" {
+			continue
+		}
+		t, dateOnly, err := parseTimeQuery(value)
+		switch {
+		case err != nil:
+			vErr.Add(param.name, err.Error())
+		case dateOnly:
+			*param.day = t
+		default:
+			*param.value = t
+		}
+	}
+
+	if sortBy := query.Get("sort"); sortBy != "" {
+		switch sortBy {
+		case models.TaskSortID, models.TaskSortName, models.TaskSortStartTime, models.TaskSortEndTime, models.TaskSortDuration:
+			filter.SortBy = sortBy
+		default:
+			vErr.Add("sort", "must be one of: id, name, start_time, end_time, duration")
+		}
+	}
+
+	switch query.Get("order") {
+	case "", "asc":
+	case "desc":
+		filter.SortDesc = true
+	default:
+		vErr.Add("order", "must be asc or desc")
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > 100 {
+			vErr.Add("limit", "must be an integer between 1 and 100")
+		} else {
+			filter.Limit = limit
+		}
+	}
+
+	format := parseFormat(r, vErr)
+
+	if vErr.HasErrors() {
+		return models.TaskFilter{}, "", vErr
+	}
+	return filter, format, nil
+}
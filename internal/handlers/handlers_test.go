@@ -2,14 +2,23 @@ package handlers
 
 import (
 	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
+	"time-tracker/internal/API/apiDataUser"
+	"time-tracker/internal/auth"
 	"time-tracker/internal/config"
+	"time-tracker/internal/domain"
 	"time-tracker/internal/logger"
 	"time-tracker/internal/models"
 	"time-tracker/internal/usecase/mocks"
@@ -42,6 +51,32 @@ func NewMockServer() *httptest.Server {
 	return httptest.NewServer(handler)
 }
 
+// ключ подписи токенов доступа в тестах
+var testTokens = func() *auth.Tokens {
+	tokens, err := auth.NewTokens(strings.Repeat("s", auth.MinSecretLength), 15*time.Minute, 720*time.Hour)
+	if err != nil {
+		panic(err)
+	}
+	return tokens
+}()
+
+// bearer возвращает заголовок Authorization с токеном доступа пользователя p
+func bearer(p auth.Principal) string {
+	token, err := testTokens.IssueAccessToken(p, time.Now())
+	if err != nil {
+		panic(err)
+	}
+	return "Bearer " + token
+}
+
+// authorize подписывает запрос токеном администратора, если заголовок Authorization еще не задан
+func authorize(req *http.Request) *http.Request {
+	if req.Header.Get("Authorization") == "" {
+		req.Header.Set("Authorization", bearer(auth.Principal{UserID: 1, Role: auth.RoleAdmin}))
+	}
+	return req
+}
+
 func TestHandlerAddUser(t *testing.T) {
 	if err := logger.InitLogger(""); err != nil {
 		panic("cannot initialize zap")
@@ -53,17 +88,12 @@ func TestHandlerAddUser(t *testing.T) {
 
 	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
 
-	// Создание мок-сервера
-	mockServer := NewMockServer()
-	defer mockServer.Close()
-
 	// Создаем конфигурацию и роутер с использованием моков
 	conf := &config.Config{
 		SERVER_HOST: "localhost",
 		SERVER_PORT: "8080",
-		API_URL:     mockServer.URL,
 	}
-	router := InitRoutes(mockUseCase, conf)
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
 
 	type args struct {
 		body io.Reader
@@ -82,9 +112,9 @@ func TestHandlerAddUser(t *testing.T) {
 			url:    "/user",
 			body:   args{bytes.NewBufferString(`{"passportNumber": "1234 567890"}`)},
 			mockCreate: func() {
-				mockUseCase.EXPECT().UseCaseCreate(gomock.Any()).Return(1, nil)
+				mockUseCase.EXPECT().UseCaseCreatePendingUser(gomock.Any(), "1234 567890").Return(1, nil)
 			},
-			wantStatus: http.StatusOK,
+			wantStatus: http.StatusAccepted,
 		},
 		{
 			name:       "#2 Неверный метод запроса",
@@ -119,7 +149,15 @@ func TestHandlerAddUser(t *testing.T) {
 			wantStatus: http.StatusUnprocessableEntity,
 		},
 		{
-			name:       "#6 Неверный JSON",
+			name:       "#6 Паспорт без пробела",
+			method:     http.MethodPost,
+			url:        "/user",
+			body:       args{bytes.NewBufferString(`{"passportNumber": "1214567890"}`)},
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "#7 Неверный JSON",
 			method:     http.MethodPost,
 			url:        "/user",
 			body:       args{bytes.NewBufferString(`{"par": "1214 561890"}`)},
@@ -138,9 +176,106 @@ func TestHandlerAddUser(t *testing.T) {
 			}
 
 			rr := httptest.NewRecorder()
-			router.ServeHTTP(rr, req)
+			router.ServeHTTP(rr, authorize(req))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
+
+func TestHandlerEnrichment(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
+
+	conf := &config.Config{SERVER_HOST: "localhost", SERVER_PORT: "8080"}
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
+
+	nextAttempt := time.Date(2024, 5, 1, 12, 0, 30, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		mockCreate func()
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:   "#1 Состояние обогащения",
+			method: http.MethodGet,
+			url:    "/users/1/enrichment",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseReadEnrichmentStatus(gomock.Any(), 1).Return(models.EnrichmentStatus{
+					UserID: 1, Status: models.EnrichmentPending, Attempts: 2,
+					LastError: "ошибка запроса к стороннему API", NextAttemptAt: &nextAttempt,
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{"user_id":1,"status":"pending","attempts":2,"last_error":"ошибка запроса к стороннему API",` +
+				`"next_attempt_at":"2024-05-01T12:00:30Z"}`,
+		},
+		{
+			name:   "#2 Пользователь не найден",
+			method: http.MethodGet,
+			url:    "/users/7/enrichment",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseReadEnrichmentStatus(gomock.Any(), 7).Return(models.EnrichmentStatus{}, domain.ErrUserNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "#3 Неверный UserID",
+			method:     http.MethodGet,
+			url:        "/users/abc/enrichment",
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "#4 Повторное обогащение",
+			method: http.MethodPost,
+			url:    "/users/1/enrichment",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseRetryEnrichment(gomock.Any(), 1).Return(models.EnrichmentStatus{
+					UserID: 1, Status: models.EnrichmentPending, NextAttemptAt: &nextAttempt,
+				}, nil)
+			},
+			wantStatus: http.StatusAccepted,
+			wantBody:   `{"user_id":1,"status":"pending","attempts":0,"next_attempt_at":"2024-05-01T12:00:30Z"}`,
+		},
+		{
+			name:   "#5 Повторное обогащение без прав",
+			method: http.MethodPost,
+			url:    "/users/1/enrichment",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseRetryEnrichment(gomock.Any(), 1).Return(models.EnrichmentStatus{}, domain.ErrForbidden)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockCreate()
+
+			req, err := http.NewRequest(tt.method, tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, authorize(req))
 
 			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, rr.Body.String())
+			}
 		})
 	}
 }
@@ -161,7 +296,7 @@ func TestHandlerDelete(t *testing.T) {
 		SERVER_HOST: "localhost",
 		SERVER_PORT: "8080",
 	}
-	router := InitRoutes(mockUseCase, conf)
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
 
 	tests := []struct {
 		name       string
@@ -175,7 +310,7 @@ func TestHandlerDelete(t *testing.T) {
 			method: http.MethodDelete,
 			url:    "/user/123",
 			mockCreate: func() {
-				mockUseCase.EXPECT().UseCaseDelete(gomock.Any()).Return(nil)
+				mockUseCase.EXPECT().UseCaseDelete(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			wantStatus: http.StatusOK,
 		},
@@ -193,6 +328,15 @@ func TestHandlerDelete(t *testing.T) {
 			mockCreate: func() {},
 			wantStatus: http.StatusUnprocessableEntity,
 		},
+		{
+			name:   "#4 Пользователь не найден",
+			method: http.MethodDelete,
+			url:    "/user/123",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseDelete(gomock.Any(), 123).Return(fmt.Errorf("%w: id %d", domain.ErrUserNotFound, 123))
+			},
+			wantStatus: http.StatusNotFound,
+		},
 	}
 
 	for _, tt := range tests {
@@ -205,7 +349,7 @@ func TestHandlerDelete(t *testing.T) {
 			}
 
 			rr := httptest.NewRecorder()
-			router.ServeHTTP(rr, req)
+			router.ServeHTTP(rr, authorize(req))
 
 			assert.Equal(t, tt.wantStatus, rr.Code)
 		})
@@ -228,7 +372,7 @@ func TestHandlerUpdate(t *testing.T) {
 		SERVER_HOST: "localhost",
 		SERVER_PORT: "8080",
 	}
-	router := InitRoutes(mockUseCase, conf)
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
 
 	type args struct {
 		body io.Reader
@@ -247,7 +391,7 @@ func TestHandlerUpdate(t *testing.T) {
 			url:    "/user/1",
 			body:   args{bytes.NewBufferString(`{"id": "1", "surname": "dfd"}`)},
 			mockCreate: func() {
-				mockUseCase.EXPECT().UseCaseUpdate(gomock.Any(), gomock.Any()).Return(nil)
+				mockUseCase.EXPECT().UseCaseUpdate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 			},
 			wantStatus: http.StatusOK,
 		},
@@ -279,7 +423,7 @@ func TestHandlerUpdate(t *testing.T) {
 			}
 
 			rr := httptest.NewRecorder()
-			router.ServeHTTP(rr, req)
+			router.ServeHTTP(rr, authorize(req))
 
 			assert.Equal(t, tt.wantStatus, rr.Code)
 		})
@@ -302,7 +446,7 @@ func TestHandlerGetUser(t *testing.T) {
 		SERVER_HOST: "localhost",
 		SERVER_PORT: "8080",
 	}
-	router := InitRoutes(mockUseCase, conf)
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
 
 	tests := []struct {
 		name       string
@@ -316,7 +460,7 @@ func TestHandlerGetUser(t *testing.T) {
 			method: http.MethodGet,
 			url:    "/user/1",
 			mockCreate: func() {
-				mockUseCase.EXPECT().UseCaseRead(gomock.Any()).Return(models.UserData{}, nil)
+				mockUseCase.EXPECT().UseCaseRead(gomock.Any(), gomock.Any()).Return(models.UserData{}, nil)
 			},
 			wantStatus: http.StatusOK,
 		},
@@ -348,7 +492,7 @@ func TestHandlerGetUser(t *testing.T) {
 			}
 
 			rr := httptest.NewRecorder()
-			router.ServeHTTP(rr, req)
+			router.ServeHTTP(rr, authorize(req))
 
 			assert.Equal(t, tt.wantStatus, rr.Code)
 		})
@@ -371,7 +515,7 @@ func TestHandlerGetUsers(t *testing.T) {
 		SERVER_HOST: "localhost",
 		SERVER_PORT: "8080",
 	}
-	router := InitRoutes(mockUseCase, conf)
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
 
 	type args struct {
 		body io.Reader
@@ -390,7 +534,7 @@ func TestHandlerGetUsers(t *testing.T) {
 			url:    "/users/1/5",
 			body:   args{bytes.NewBufferString(`{"name": "name"}`)},
 			mockCreate: func() {
-				mockUseCase.EXPECT().UseCaseGetUsers(gomock.Any(), 1, 5).Return([]models.UserData{}, nil)
+				mockUseCase.EXPECT().UseCaseGetUsers(gomock.Any(), gomock.Any(), 1, 5).Return([]models.UserData{}, nil)
 			},
 			wantStatus: http.StatusOK,
 		},
@@ -408,7 +552,7 @@ func TestHandlerGetUsers(t *testing.T) {
 			url:        "/users/1/5",
 			body:       args{bytes.NewBufferString(`{"name": "name}`)},
 			mockCreate: func() {},
-			wantStatus: http.StatusInternalServerError,
+			wantStatus: http.StatusBadRequest,
 		},
 		{
 			name:       "#4 Неверные поля в теле запроса",
@@ -416,7 +560,7 @@ func TestHandlerGetUsers(t *testing.T) {
 			url:        "/users/1/5",
 			body:       args{bytes.NewBufferString(`{"nae": "name"}`)},
 			mockCreate: func() {},
-			wantStatus: http.StatusInternalServerError,
+			wantStatus: http.StatusBadRequest,
 		},
 	}
 
@@ -430,7 +574,7 @@ func TestHandlerGetUsers(t *testing.T) {
 			}
 
 			rr := httptest.NewRecorder()
-			router.ServeHTTP(rr, req)
+			router.ServeHTTP(rr, authorize(req))
 
 			assert.Equal(t, tt.wantStatus, rr.Code)
 		})
@@ -453,7 +597,7 @@ func TestHandlerAddTask(t *testing.T) {
 		SERVER_HOST: "localhost",
 		SERVER_PORT: "8080",
 	}
-	router := InitRoutes(mockUseCase, conf)
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
 
 	type args struct {
 		body io.Reader
@@ -472,7 +616,7 @@ func TestHandlerAddTask(t *testing.T) {
 			url:    "/task/1",
 			body:   args{bytes.NewBufferString(`{"task_name": "name"}`)},
 			mockCreate: func() {
-				mockUseCase.EXPECT().UseCaseCreateTask(gomock.Any(), gomock.Any()).Return(1, nil)
+				mockUseCase.EXPECT().UseCaseCreateTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(1, nil)
 			},
 			wantStatus: http.StatusOK,
 		},
@@ -498,7 +642,7 @@ func TestHandlerAddTask(t *testing.T) {
 			url:        "/task/1",
 			body:       args{bytes.NewBufferString(`{"task_name": "name}`)},
 			mockCreate: func() {},
-			wantStatus: http.StatusInternalServerError,
+			wantStatus: http.StatusBadRequest,
 		},
 		{
 			name:       "#5 Неверные поля запроса",
@@ -506,7 +650,15 @@ func TestHandlerAddTask(t *testing.T) {
 			url:        "/task/1",
 			body:       args{bytes.NewBufferString(`{"taskme": "name"}`)},
 			mockCreate: func() {},
-			wantStatus: http.StatusInternalServerError,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "#6 Название длиннее 100 символов",
+			method:     http.MethodPost,
+			url:        "/task/1",
+			body:       args{bytes.NewBufferString(`{"task_name": "` + strings.Repeat("a", 101) + `"}`)},
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
 		},
 	}
 
@@ -520,14 +672,14 @@ func TestHandlerAddTask(t *testing.T) {
 			}
 
 			rr := httptest.NewRecorder()
-			router.ServeHTTP(rr, req)
+			router.ServeHTTP(rr, authorize(req))
 
 			assert.Equal(t, tt.wantStatus, rr.Code)
 		})
 	}
 }
 
-func TestHandlerStartTime(t *testing.T) {
+func TestHandlerRenameTask(t *testing.T) {
 	if err := logger.InitLogger(""); err != nil {
 		panic("cannot initialize zap")
 	}
@@ -543,35 +695,146 @@ func TestHandlerStartTime(t *testing.T) {
 		SERVER_HOST: "localhost",
 		SERVER_PORT: "8080",
 	}
-	router := InitRoutes(mockUseCase, conf)
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
 
+	type args struct {
+		body io.Reader
+	}
 	tests := []struct {
 		name       string
 		method     string
 		url        string
+		body       args
 		mockCreate func()
 		wantStatus int
 	}{
 		{
 			name:   "#1 Успешный запрос",
-			method: http.MethodPut,
-			url:    "/task/start/1",
+			method: http.MethodPatch,
+			url:    "/task/3",
+			body:   args{bytes.NewBufferString(`{"task_name": "new name"}`)},
 			mockCreate: func() {
-				mockUseCase.EXPECT().UseCaseAddStartTime(gomock.Any()).Return(nil)
+				mockUseCase.EXPECT().UseCaseRenameTask(gomock.Any(), 3, "new name").Return(nil)
 			},
 			wantStatus: http.StatusOK,
 		},
 		{
-			name:       "#2 Неверный метод",
-			method:     http.MethodPost,
-			url:        "/task/start/1",
+			name:   "#2 Задача не найдена",
+			method: http.MethodPatch,
+			url:    "/task/3",
+			body:   args{bytes.NewBufferString(`{"task_name": "new name"}`)},
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseRenameTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.ErrTaskNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "#3 Название длиннее 100 символов",
+			method:     http.MethodPatch,
+			url:        "/task/3",
+			body:       args{bytes.NewBufferString(`{"task_name": "` + strings.Repeat("я", 101) + `"}`)},
 			mockCreate: func() {},
-			wantStatus: http.StatusMethodNotAllowed,
+			wantStatus: http.StatusUnprocessableEntity,
 		},
 		{
-			name:       "#3 Некорректный TaskID",
-			method:     http.MethodPut,
-			url:        "/task/start/trt",
+			name:       "#4 Пустое название",
+			method:     http.MethodPatch,
+			url:        "/task/3",
+			body:       args{bytes.NewBufferString(`{"task_name": " "}`)},
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "#5 Некорректный TaskID",
+			method:     http.MethodPatch,
+			url:        "/task/abc",
+			body:       args{bytes.NewBufferString(`{"task_name": "new name"}`)},
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "#6 Неверное тело запроса",
+			method:     http.MethodPatch,
+			url:        "/task/3",
+			body:       args{bytes.NewBufferString(`{"name": "new name"}`)},
+			mockCreate: func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockCreate()
+
+			req, err := http.NewRequest(tt.method, tt.url, tt.body.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, authorize(req))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
+
+func TestHandlerDeleteTask(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
+
+	// Создаем конфигурацию и роутер с использованием моков
+	conf := &config.Config{
+		SERVER_HOST: "localhost",
+		SERVER_PORT: "8080",
+	}
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
+
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		mockCreate func()
+		wantStatus int
+	}{
+		{
+			name:   "#1 Успешный запрос",
+			method: http.MethodDelete,
+			url:    "/task/3",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseDeleteTask(gomock.Any(), 3).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "#2 Задача не найдена",
+			method: http.MethodDelete,
+			url:    "/task/3",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseDeleteTask(gomock.Any(), gomock.Any()).Return(fmt.Errorf("%w: id %d", domain.ErrTaskNotFound, 3))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "#3 Задача выполняется",
+			method: http.MethodDelete,
+			url:    "/task/3",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseDeleteTask(gomock.Any(), gomock.Any()).Return(fmt.Errorf("%w: id %d", domain.ErrTaskRunning, 3))
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "#4 Некорректный TaskID",
+			method:     http.MethodDelete,
+			url:        "/task/abc",
 			mockCreate: func() {},
 			wantStatus: http.StatusUnprocessableEntity,
 		},
@@ -587,16 +850,14 @@ func TestHandlerStartTime(t *testing.T) {
 			}
 
 			rr := httptest.NewRecorder()
-			router.ServeHTTP(rr, req)
+			router.ServeHTTP(rr, authorize(req))
 
 			assert.Equal(t, tt.wantStatus, rr.Code)
-			// Проверка других аспектов ответа, если необходимо
 		})
 	}
 }
 
-// HandlerEndTime
-func TestHandlerEndTime(t *testing.T) {
+func TestHandlerStartTime(t *testing.T) {
 	if err := logger.InitLogger(""); err != nil {
 		panic("cannot initialize zap")
 	}
@@ -612,7 +873,7 @@ func TestHandlerEndTime(t *testing.T) {
 		SERVER_HOST: "localhost",
 		SERVER_PORT: "8080",
 	}
-	router := InitRoutes(mockUseCase, conf)
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
 
 	tests := []struct {
 		name       string
@@ -624,26 +885,35 @@ func TestHandlerEndTime(t *testing.T) {
 		{
 			name:   "#1 Успешный запрос",
 			method: http.MethodPut,
-			url:    "/task/end/1",
+			url:    "/task/start/1",
 			mockCreate: func() {
-				mockUseCase.EXPECT().UseCaseAddEndTime(gomock.Any()).Return(nil)
+				mockUseCase.EXPECT().UseCaseAddStartTime(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			wantStatus: http.StatusOK,
 		},
 		{
 			name:       "#2 Неверный метод",
 			method:     http.MethodPost,
-			url:        "/task/end/1",
+			url:        "/task/start/1",
 			mockCreate: func() {},
 			wantStatus: http.StatusMethodNotAllowed,
 		},
 		{
 			name:       "#3 Некорректный TaskID",
 			method:     http.MethodPut,
-			url:        "/task/end/trt",
+			url:        "/task/start/trt",
 			mockCreate: func() {},
 			wantStatus: http.StatusUnprocessableEntity,
 		},
+		{
+			name:   "#4 Время старта уже установлено",
+			method: http.MethodPut,
+			url:    "/task/start/1",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseAddStartTime(gomock.Any(), 1).Return(fmt.Errorf("%w: id %d", domain.ErrAlreadyStarted, 1))
+			},
+			wantStatus: http.StatusConflict,
+		},
 	}
 
 	for _, tt := range tests {
@@ -656,7 +926,7 @@ func TestHandlerEndTime(t *testing.T) {
 			}
 
 			rr := httptest.NewRecorder()
-			router.ServeHTTP(rr, req)
+			router.ServeHTTP(rr, authorize(req))
 
 			assert.Equal(t, tt.wantStatus, rr.Code)
 			// Проверка других аспектов ответа, если необходимо
@@ -664,7 +934,8 @@ func TestHandlerEndTime(t *testing.T) {
 	}
 }
 
-func TestHandlerGetTasks(t *testing.T) {
+// HandlerEndTime
+func TestHandlerEndTime(t *testing.T) {
 	if err := logger.InitLogger(""); err != nil {
 		panic("cannot initialize zap")
 	}
@@ -680,60 +951,37 @@ func TestHandlerGetTasks(t *testing.T) {
 		SERVER_HOST: "localhost",
 		SERVER_PORT: "8080",
 	}
-	router := InitRoutes(mockUseCase, conf)
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
 
-	type args struct {
-		body io.Reader
-	}
 	tests := []struct {
 		name       string
 		method     string
 		url        string
-		body       args
 		mockCreate func()
 		wantStatus int
 	}{
 		{
 			name:   "#1 Успешный запрос",
-			method: http.MethodPost,
-			url:    "/tasks/1",
-			body:   args{bytes.NewBufferString(`{"start": "12.12.2024"}`)},
+			method: http.MethodPut,
+			url:    "/task/end/1",
 			mockCreate: func() {
-				mockUseCase.EXPECT().UseCaseGetTasksUser(gomock.Any(), gomock.Any()).Return([]models.Tasks{}, nil)
+				mockUseCase.EXPECT().UseCaseAddEndTime(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			wantStatus: http.StatusOK,
 		},
 		{
 			name:       "#2 Неверный метод",
-			method:     http.MethodGet,
-			url:        "/tasks/1",
-			body:       args{bytes.NewBufferString(`{"start": "12.12.2024"}`)},
-			mockCreate: func() {},
-			wantStatus: http.StatusMethodNotAllowed,
-		},
-		{
-			name:       "#3 Неправильное тело запроса",
 			method:     http.MethodPost,
-			url:        "/tasks/1",
-			body:       args{bytes.NewBufferString(`{"start": "12.12.2024}`)},
-			mockCreate: func() {},
-			wantStatus: http.StatusInternalServerError,
-		},
-		{
-			name:       "#4 Неверные поля в теле запроса",
-			method:     http.MethodPost,
-			url:        "/tasks/1",
-			body:       args{bytes.NewBufferString(`{"startdsf": "12.12.2024"}`)},
+			url:        "/task/end/1",
 			mockCreate: func() {},
-			wantStatus: http.StatusInternalServerError,
+			wantStatus: http.StatusMethodNotAllowed,
 		},
 		{
-			name:       "#4 Невлидные поля в теле запроса",
-			method:     http.MethodPost,
-			url:        "/tasks/1",
-			body:       args{bytes.NewBufferString(`{"start": "12.авы.2024"}`)},
+			name:       "#3 Некорректный TaskID",
+			method:     http.MethodPut,
+			url:        "/task/end/trt",
 			mockCreate: func() {},
-			wantStatus: http.StatusInternalServerError,
+			wantStatus: http.StatusUnprocessableEntity,
 		},
 	}
 
@@ -741,15 +989,2453 @@ func TestHandlerGetTasks(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockCreate()
 
-			req, err := http.NewRequest(tt.method, tt.url, tt.body.body)
+			req, err := http.NewRequest(tt.method, tt.url, nil)
 			if err != nil {
 				t.Fatal(err)
 			}
 
 			rr := httptest.NewRecorder()
-			router.ServeHTTP(rr, req)
+			router.ServeHTTP(rr, authorize(req))
 
 			assert.Equal(t, tt.wantStatus, rr.Code)
+			// Проверка других аспектов ответа, если необходимо
+		})
+	}
+}
+
+func TestHandlerPauseTask(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
+
+	// Создаем конфигурацию и роутер с использованием моков
+	conf := &config.Config{
+		SERVER_HOST: "localhost",
+		SERVER_PORT: "8080",
+	}
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
+
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		mockCreate func()
+		wantStatus int
+	}{
+		{
+			name:   "#1 Успешный запрос",
+			method: http.MethodPut,
+			url:    "/task/pause/1",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCasePauseTask(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "#2 Неверный метод",
+			method:     http.MethodPost,
+			url:        "/task/pause/1",
+			mockCreate: func() {},
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "#3 Некорректный TaskID",
+			method:     http.MethodPut,
+			url:        "/task/pause/trt",
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockCreate()
+
+			req, err := http.NewRequest(tt.method, tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, authorize(req))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			// Проверка других аспектов ответа, если необходимо
+		})
+	}
+}
+
+func TestHandlerResumeTask(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
+
+	// Создаем конфигурацию и роутер с использованием моков
+	conf := &config.Config{
+		SERVER_HOST: "localhost",
+		SERVER_PORT: "8080",
+	}
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
+
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		mockCreate func()
+		wantStatus int
+	}{
+		{
+			name:   "#1 Успешный запрос",
+			method: http.MethodPut,
+			url:    "/task/resume/1",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseResumeTask(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "#2 Неверный метод",
+			method:     http.MethodPost,
+			url:        "/task/resume/1",
+			mockCreate: func() {},
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "#3 Некорректный TaskID",
+			method:     http.MethodPut,
+			url:        "/task/resume/trt",
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockCreate()
+
+			req, err := http.NewRequest(tt.method, tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, authorize(req))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			// Проверка других аспектов ответа, если необходимо
+		})
+	}
+}
+
+func TestHandlerAddManualTask(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
+
+	// Создаем конфигурацию и роутер с использованием моков
+	conf := &config.Config{
+		SERVER_HOST: "localhost",
+		SERVER_PORT: "8080",
+	}
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
+
+	start := time.Date(2024, 7, 1, 6, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 7, 1, 9, 30, 0, 0, time.UTC)
+
+	type args struct {
+		body io.Reader
+	}
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		body       args
+		mockCreate func()
+		wantStatus int
+	}{
+		{
+			name:   "#1 Успешный запрос",
+			method: http.MethodPost,
+			url:    "/users/1/tasks",
+			body:   args{bytes.NewBufferString(`{"task_name": "name", "start": "2024-07-01T09:00:00+03:00", "end": "2024-07-01T12:30:00+03:00"}`)},
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseCreateManualTask(gomock.Any(), 1, "name", timeEq(start), timeEq(end)).Return(1, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "#2 Конец раньше начала",
+			method:     http.MethodPost,
+			url:        "/users/1/tasks",
+			body:       args{bytes.NewBufferString(`{"task_name": "name", "start": "2024-07-01T12:30:00+03:00", "end": "2024-07-01T09:00:00+03:00"}`)},
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "#3 Дата не в формате RFC3339",
+			method:     http.MethodPost,
+			url:        "/users/1/tasks",
+			body:       args{bytes.NewBufferString(`{"task_name": "name", "start": "01.07.2024", "end": "2024-07-01T09:00:00+03:00"}`)},
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "#4 Пересечение с другой задачей",
+			method: http.MethodPost,
+			url:    "/users/1/tasks",
+			body:   args{bytes.NewBufferString(`{"task_name": "name", "start": "2024-07-01T09:00:00+03:00", "end": "2024-07-01T12:30:00+03:00"}`)},
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseCreateManualTask(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(0, domain.ErrTimeOverlap)
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:   "#5 Пользователь не найден",
+			method: http.MethodPost,
+			url:    "/users/1/tasks",
+			body:   args{bytes.NewBufferString(`{"task_name": "name", "start": "2024-07-01T09:00:00+03:00", "end": "2024-07-01T12:30:00+03:00"}`)},
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseCreateManualTask(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(0, domain.ErrUserNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "#6 Неверное тело запроса",
+			method:     http.MethodPost,
+			url:        "/users/1/tasks",
+			body:       args{bytes.NewBufferString(`{"task_name": "name", "begin": "2024-07-01T09:00:00+03:00"}`)},
+			mockCreate: func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockCreate()
+
+			req, err := http.NewRequest(tt.method, tt.url, tt.body.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, authorize(req))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
+
+func TestHandlerUpdateTaskTime(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
+
+	// Создаем конфигурацию и роутер с использованием моков
+	conf := &config.Config{
+		SERVER_HOST: "localhost",
+		SERVER_PORT: "8080",
+	}
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
+
+	type args struct {
+		body io.Reader
+	}
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		body       args
+		mockCreate func()
+		wantStatus int
+	}{
+		{
+			name:   "#1 Успешный запрос",
+			method: http.MethodPut,
+			url:    "/task/time/1",
+			body:   args{bytes.NewBufferString(`{"start": "2024-07-01T09:00:00Z", "end": "2024-07-01T10:00:00Z"}`)},
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseUpdateTaskTime(gomock.Any(), 1, gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "#2 Неверный метод",
+			method:     http.MethodPost,
+			url:        "/task/time/1",
+			body:       args{bytes.NewBufferString(`{"start": "2024-07-01T09:00:00Z", "end": "2024-07-01T10:00:00Z"}`)},
+			mockCreate: func() {},
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "#3 Конец равен началу",
+			method:     http.MethodPut,
+			url:        "/task/time/1",
+			body:       args{bytes.NewBufferString(`{"start": "2024-07-01T09:00:00Z", "end": "2024-07-01T09:00:00Z"}`)},
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "#4 Задача не найдена",
+			method: http.MethodPut,
+			url:    "/task/time/1",
+			body:   args{bytes.NewBufferString(`{"start": "2024-07-01T09:00:00Z", "end": "2024-07-01T10:00:00Z"}`)},
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseUpdateTaskTime(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.ErrTaskNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "#5 Пересечение с другой задачей",
+			method: http.MethodPut,
+			url:    "/task/time/1",
+			body:   args{bytes.NewBufferString(`{"start": "2024-07-01T09:00:00Z", "end": "2024-07-01T10:00:00Z"}`)},
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseUpdateTaskTime(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.ErrTimeOverlap)
+			},
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockCreate()
+
+			req, err := http.NewRequest(tt.method, tt.url, tt.body.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, authorize(req))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
+
+// timeEq сравнивает моменты времени без учета часового пояса
+func timeEq(want time.Time) gomock.Matcher {
+	return timeMatcher{want}
+}
+
+type timeMatcher struct {
+	want time.Time
+}
+
+func (m timeMatcher) Matches(x interface{}) bool {
+	got, ok := x.(time.Time)
+	return ok && got.Equal(m.want)
+}
+
+func (m timeMatcher) String() string {
+	return "equal to " + m.want.Format(time.RFC3339)
+}
+
+func TestHandlerGetTimer(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
+
+	// Создаем конфигурацию и роутер с использованием моков
+	conf := &config.Config{
+		SERVER_HOST: "localhost",
+		SERVER_PORT: "8080",
+	}
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
+
+	running := models.Timer{
+		Running: true,
+		Task: &models.TimerTask{
+			TaskID:          5,
+			NameTask:        "name",
+			RunningSince:    time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC),
+			RunningSeconds:  600,
+			DurationSeconds: 4200,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		mockCreate func()
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:   "#1 Запущенная задача",
+			method: http.MethodGet,
+			url:    "/users/1/timer",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseGetActiveTimer(gomock.Any(), 1).Return(running, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"running":true,"task":{"id":5,"name_task":"name","running_since":"2024-07-01T09:00:00Z","running_seconds":600,"duration_seconds":4200}}`,
+		},
+		{
+			name:   "#2 Нет запущенных задач",
+			method: http.MethodGet,
+			url:    "/users/1/timer",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseGetActiveTimer(gomock.Any(), 1).Return(models.Timer{}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"running":false}`,
+		},
+		{
+			name:   "#3 Пользователь не найден",
+			method: http.MethodGet,
+			url:    "/users/1/timer",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseGetActiveTimer(gomock.Any(), gomock.Any()).Return(models.Timer{}, domain.ErrUserNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "#4 Некорректный UserID",
+			method:     http.MethodGet,
+			url:        "/users/abc/timer",
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockCreate()
+
+			req, err := http.NewRequest(tt.method, tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, authorize(req))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandlerUserSettings(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
+
+	// Создаем конфигурацию и роутер с использованием моков
+	conf := &config.Config{
+		SERVER_HOST: "localhost",
+		SERVER_PORT: "8080",
+	}
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
+
+	enabled := true
+
+	type args struct {
+		body io.Reader
+	}
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		body       args
+		mockCreate func()
+		wantStatus int
+	}{
+		{
+			name:   "#1 Получение настроек",
+			method: http.MethodGet,
+			url:    "/users/1/settings",
+			body:   args{nil},
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseReadUserSettings(gomock.Any(), 1).Return(models.UserSettings{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "#2 Включение политики одного таймера",
+			method: http.MethodPatch,
+			url:    "/users/1/settings",
+			body:   args{bytes.NewBufferString(`{"single_timer": true}`)},
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseUpdateUserSettings(gomock.Any(), 1, models.UserSettingsUpdate{SingleTimer: &enabled}).
+					Return(models.UserSettings{SingleTimer: true}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "#3 Пользователь не найден",
+			method: http.MethodPatch,
+			url:    "/users/1/settings",
+			body:   args{bytes.NewBufferString(`{"single_timer": true}`)},
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseUpdateUserSettings(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.UserSettings{}, domain.ErrUserNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "#4 Неизвестная настройка",
+			method:     http.MethodPatch,
+			url:        "/users/1/settings",
+			body:       args{bytes.NewBufferString(`{"timer": true}`)},
+			mockCreate: func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockCreate()
+
+			req, err := http.NewRequest(tt.method, tt.url, tt.body.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, authorize(req))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
+
+func TestHandlerGetTasks(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
+
+	// Создаем конфигурацию и роутер с использованием моков
+	conf := &config.Config{
+		SERVER_HOST: "localhost",
+		SERVER_PORT: "8080",
+	}
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
+
+	type args struct {
+		body io.Reader
+	}
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		body       args
+		mockCreate func()
+		wantStatus int
+	}{
+		{
+			name:   "#1 Успешный запрос",
+			method: http.MethodPost,
+			url:    "/tasks/1",
+			body:   args{bytes.NewBufferString(`{"start": "12.12.2024"}`)},
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseGetTasksUser(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.Tasks{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "#2 Неверный метод",
+			method:     http.MethodGet,
+			url:        "/tasks/1",
+			body:       args{bytes.NewBufferString(`{"start": "12.12.2024"}`)},
+			mockCreate: func() {},
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "#3 Неправильное тело запроса",
+			method:     http.MethodPost,
+			url:        "/tasks/1",
+			body:       args{bytes.NewBufferString(`{"start": "12.12.2024}`)},
+			mockCreate: func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "#4 Неверные поля в теле запроса",
+			method:     http.MethodPost,
+			url:        "/tasks/1",
+			body:       args{bytes.NewBufferString(`{"startdsf": "12.12.2024"}`)},
+			mockCreate: func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "#4 Невлидные поля в теле запроса",
+			method:     http.MethodPost,
+			url:        "/tasks/1",
+			body:       args{bytes.NewBufferString(`{"start": "12.авы.2024"}`)},
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockCreate()
+
+			req, err := http.NewRequest(tt.method, tt.url, tt.body.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, authorize(req))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
+
+func TestHandlerReport(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
+
+	// Создаем конфигурацию и роутер с использованием моков
+	conf := &config.Config{
+		SERVER_HOST: "localhost",
+		SERVER_PORT: "8080",
+	}
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
+
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		mockCreate func()
+		wantStatus int
+	}{
+		{
+			name:   "#1 Отчет по дням по умолчанию",
+			method: http.MethodGet,
+			url:    "/users/1/report?from=2024-01-01&to=2024-01-31",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseReport(gomock.Any(), 1, models.ReportFilter{
+					From:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+					To:      time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+					GroupBy: models.ReportGroupDay,
+				}).Return(models.Report{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "#2 Отчет по задачам",
+			method: http.MethodGet,
+			url:    "/users/1/report?from=2024-01-01&to=2024-01-01&group_by=task",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseReport(gomock.Any(), 1, gomock.Any()).Return(models.Report{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "#3 Нет дат периода",
+			method:     http.MethodGet,
+			url:        "/users/1/report",
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "#4 Конец раньше начала",
+			method:     http.MethodGet,
+			url:        "/users/1/report?from=2024-02-01&to=2024-01-01",
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "#5 Неизвестная группировка",
+			method:     http.MethodGet,
+			url:        "/users/1/report?from=2024-01-01&to=2024-01-31&group_by=year",
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "#6 Пользователь не найден",
+			method: http.MethodGet,
+			url:    "/users/1/report?from=2024-01-01&to=2024-01-31&group_by=week",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseReport(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.Report{}, domain.ErrUserNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "#7 Отчет по проектам клиента",
+			method: http.MethodGet,
+			url:    "/users/1/report?from=2024-01-01&to=2024-01-31&group_by=project&client_id=3",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseReport(gomock.Any(), 1, models.ReportFilter{
+					From:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+					To:       time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+					GroupBy:  models.ReportGroupProject,
+					ClientID: 3,
+				}).Return(models.Report{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "#9 Отчет по тегам",
+			method: http.MethodGet,
+			url:    "/users/1/report?from=2024-01-01&to=2024-01-31&group_by=tag&tags=bugfix",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseReport(gomock.Any(), 1, models.ReportFilter{
+					From:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+					To:       time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+					GroupBy:  models.ReportGroupTag,
+					Tags:     []string{"bugfix"},
+					TagMatch: models.TagMatchAny,
+				}).Return(models.Report{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "#8 Некорректный project_id",
+			method:     http.MethodGet,
+			url:        "/users/1/report?from=2024-01-01&to=2024-01-31&project_id=0",
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockCreate()
+
+			req, err := http.NewRequest(tt.method, tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, authorize(req))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
+
+func TestHandlerReportExport(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
+
+	// Создаем конфигурацию и роутер с использованием моков
+	conf := &config.Config{
+		SERVER_HOST: "localhost",
+		SERVER_PORT: "8080",
+	}
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
+
+	row := models.ExportRow{
+		UserName:        "Иванов Иван Иванович",
+		TaskID:          1,
+		NameTask:        "Отчет",
+		Start:           sql.NullTime{Time: time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC), Valid: true},
+		DurationSeconds: 600,
+	}
+	exportRows := func(ctx context.Context, userID int, filter models.ReportFilter, fn func(models.ExportRow) error) error {
+		return fn(row)
+	}
+
+	tests := []struct {
+		name            string
+		url             string
+		accept          string
+		mockCreate      func()
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name: "#1 CSV по параметру format",
+			url:  "/users/1/report?from=2024-01-01&to=2024-01-31&format=csv",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseExportReport(gomock.Any(), 1, gomock.Any(), gomock.Any()).DoAndReturn(exportRows)
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			wantBody: "Пользователь,ID задачи,Задача,Проект,Клиент,Начало,Окончание,\"Длительность, сек\"\n" +
+				"Иванов Иван Иванович,1,Отчет,,,2024-01-10 09:00:00,,600\n",
+		},
+		{
+			name:   "#2 XLSX по заголовку Accept",
+			url:    "/users/1/report?from=2024-01-01&to=2024-01-31",
+			accept: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseExportReport(gomock.Any(), 1, gomock.Any(), gomock.Any()).DoAndReturn(exportRows)
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		},
+		{
+			name:   "#3 Пользователь не найден до начала выгрузки",
+			url:    "/users/1/report?from=2024-01-01&to=2024-01-31",
+			accept: "text/csv",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseExportReport(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.ErrUserNotFound)
+			},
+			wantStatus:      http.StatusNotFound,
+			wantContentType: "application/json",
+		},
+		{
+			name:       "#4 Неизвестный формат",
+			url:        "/users/1/report?from=2024-01-01&to=2024-01-31&format=pdf",
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockCreate()
+
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, authorize(req))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantContentType != "" {
+				assert.Equal(t, tt.wantContentType, rr.Header().Get("Content-Type"))
+			}
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, rr.Body.String())
+				// данные сбрасываются клиенту через обертки логгера и метрик
+				assert.True(t, rr.Flushed)
+			}
+		})
+	}
+}
+
+func TestHandlerCalendar(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
+
+	// Создаем конфигурацию и роутер с использованием моков
+	conf := &config.Config{
+		SERVER_HOST: "localhost",
+		SERVER_PORT: "8080",
+	}
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
+
+	event := models.CalendarEvent{
+		TaskID:   7,
+		NameTask: "Отчет",
+		Start:    time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC),
+		End:      time.Date(2024, 1, 10, 10, 30, 0, 0, time.UTC),
+		AllTime:  5400,
+	}
+	events := func(ctx context.Context, userID int, token string, fn func(models.CalendarEvent) error) error {
+		return fn(event)
+	}
+
+	tests := []struct {
+		name            string
+		method          string
+		url             string
+		mockCreate      func()
+		wantStatus      int
+		wantContentType string
+		wantBody        []string
+	}{
+		{
+			name:   "#1 Выпуск токена",
+			method: http.MethodPost,
+			url:    "/users/1/calendar/token",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseCreateCalendarToken(gomock.Any(), 1).Return("secret", nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   []string{`{"token":"secret","url":"http://example.com/users/1/calendar.ics?token=secret"}`},
+		},
+		{
+			name:   "#2 Выпуск токена для несуществующего пользователя",
+			method: http.MethodPost,
+			url:    "/users/2/calendar/token",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseCreateCalendarToken(gomock.Any(), 2).Return("", domain.ErrUserNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "#3 Календарь",
+			method: http.MethodGet,
+			url:    "/users/1/calendar.ics?token=secret",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseCalendarEvents(gomock.Any(), 1, "secret", gomock.Any()).DoAndReturn(events)
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/calendar; charset=utf-8",
+			wantBody: []string{
+				"BEGIN:VCALENDAR\r\n",
+				"UID:task-7@time-tracker\r\n",
+				"DTSTART:20240110T090000Z\r\nDTEND:20240110T103000Z\r\nSUMMARY:Отчет\r\n",
+				"END:VCALENDAR\r\n",
+			},
+		},
+		{
+			name:   "#4 Неверный токен",
+			method: http.MethodGet,
+			url:    "/users/1/calendar.ics?token=wrong",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseCalendarEvents(gomock.Any(), 1, "wrong", gomock.Any()).Return(domain.ErrInvalidToken)
+			},
+			wantStatus:      http.StatusUnauthorized,
+			wantContentType: "application/json",
+		},
+		{
+			name:       "#5 Некорректный userID",
+			method:     http.MethodGet,
+			url:        "/users/abc/calendar.ics?token=secret",
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockCreate()
+
+			req := httptest.NewRequest(tt.method, tt.url, nil)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, authorize(req))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantContentType != "" {
+				assert.Equal(t, tt.wantContentType, rr.Header().Get("Content-Type"))
+			}
+			for _, part := range tt.wantBody {
+				assert.Contains(t, rr.Body.String(), part)
+			}
+		})
+	}
+}
+
+func TestHandlerImportTasks(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
+
+	// Создаем конфигурацию и роутер с использованием моков
+	conf := &config.Config{
+		SERVER_HOST: "localhost",
+		SERVER_PORT: "8080",
+	}
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
+
+	result := models.ImportResult{DryRun: true, Total: 1, Imported: 1, Issues: []models.ImportIssue{}}
+
+	tests := []struct {
+		name        string
+		url         string
+		contentType string
+		mockCreate  func()
+		wantStatus  int
+		wantBody    string
+	}{
+		{
+			name:        "#1 CSV по Content-Type, dry_run",
+			url:         "/users/1/import?dry_run=true",
+			contentType: "text/csv",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseImportTasks(gomock.Any(), 1, "csv", gomock.Any(), true).Return(result, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"dry_run":true,"total":1,"imported":1,"duplicates":0,"skipped":0,"issues":[]}`,
+		},
+		{
+			name:        "#2 Формат из параметра важнее Content-Type",
+			url:         "/users/1/import?format=ics",
+			contentType: "application/octet-stream",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseImportTasks(gomock.Any(), 1, "ics", gomock.Any(), false).Return(models.ImportResult{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "#3 Формат не определен",
+			url:        "/users/1/import",
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "#4 Некорректный dry_run",
+			url:         "/users/1/import?dry_run=maybe",
+			contentType: "text/csv",
+			mockCreate:  func() {},
+			wantStatus:  http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "#5 Пользователь не найден",
+			url:         "/users/2/import",
+			contentType: "text/calendar",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseImportTasks(gomock.Any(), 2, "ics", gomock.Any(), false).Return(models.ImportResult{}, domain.ErrUserNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockCreate()
+
+			req := httptest.NewRequest(http.MethodPost, tt.url, strings.NewReader("file"))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, authorize(req))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandlerClientsProjects(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
+
+	// Создаем конфигурацию и роутер с использованием моков
+	conf := &config.Config{
+		SERVER_HOST: "localhost",
+		SERVER_PORT: "8080",
+	}
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
+
+	clientID := 3
+
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		body       string
+		mockCreate func()
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:   "#1 Создание клиента",
+			method: http.MethodPost,
+			url:    "/clients",
+			body:   `{"name":"ООО Ромашка"}`,
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseCreateClient(gomock.Any(), "ООО Ромашка").Return(3, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"ClientID":3}`,
+		},
+		{
+			name:       "#2 Пустое название клиента",
+			method:     http.MethodPost,
+			url:        "/clients",
+			body:       `{"name":" "}`,
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "#3 Клиент с таким названием уже есть",
+			method: http.MethodPut,
+			url:    "/clients/3",
+			body:   `{"name":"ООО Ромашка"}`,
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseUpdateClient(gomock.Any(), 3, "ООО Ромашка").Return(domain.ErrDuplicateName)
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:   "#4 Удаление клиента с проектами",
+			method: http.MethodDelete,
+			url:    "/clients/3",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseDeleteClient(gomock.Any(), 3).Return(domain.ErrClientHasProjects)
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:   "#5 Клиент не найден",
+			method: http.MethodGet,
+			url:    "/clients/4",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseReadClient(gomock.Any(), 4).Return(models.Client{}, domain.ErrClientNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "#6 Создание проекта клиента",
+			method: http.MethodPost,
+			url:    "/projects",
+			body:   `{"name":"Сайт","client_id":3}`,
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseCreateProject(gomock.Any(), models.ProjectRequest{Name: "Сайт", ClientID: &clientID}).Return(5, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"ProjectID":5}`,
+		},
+		{
+			name:   "#7 Список проектов клиента",
+			method: http.MethodGet,
+			url:    "/projects?client_id=3",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseListProjects(gomock.Any(), 3).Return([]models.Project{{ProjectID: 5, ClientID: &clientID, Name: "Сайт"}}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `[{"id":5,"client_id":3,"name":"Сайт"}]`,
+		},
+		{
+			name:       "#8 Некорректный client_id",
+			method:     http.MethodGet,
+			url:        "/projects?client_id=abc",
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "#9 Внутренний проект",
+			method: http.MethodPut,
+			url:    "/projects/5",
+			body:   `{"name":"Сайт","client_id":null}`,
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseUpdateProject(gomock.Any(), 5, models.ProjectRequest{Name: "Сайт"}).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "#10 Привязка задачи к проекту",
+			method: http.MethodPut,
+			url:    "/task/project/7",
+			body:   `{"project_id":3}`,
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseSetTaskProject(gomock.Any(), 7, &clientID).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "#11 Снятие задачи с проекта",
+			method: http.MethodPut,
+			url:    "/task/project/7",
+			body:   `{"project_id":null}`,
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseSetTaskProject(gomock.Any(), 7, nil).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "#12 Проект не найден",
+			method: http.MethodDelete,
+			url:    "/projects/6",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseDeleteProject(gomock.Any(), 6).Return(domain.ErrProjectNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockCreate()
+
+			req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, authorize(req))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandlerTags(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
+
+	// Создаем конфигурацию и роутер с использованием моков
+	conf := &config.Config{
+		SERVER_HOST: "localhost",
+		SERVER_PORT: "8080",
+	}
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
+
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		body       string
+		mockCreate func()
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:   "#1 Добавление тегов",
+			method: http.MethodPost,
+			url:    "/task/tags/7",
+			body:   `{"tags":["Meeting"," review ","meeting"]}`,
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseAddTaskTags(gomock.Any(), 7, []string{"meeting", "review"}).
+					Return([]string{"bugfix", "meeting", "review"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"tags":["bugfix","meeting","review"]}`,
+		},
+		{
+			name:       "#2 Пустой список тегов",
+			method:     http.MethodPost,
+			url:        "/task/tags/7",
+			body:       `{"tags":[]}`,
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "#3 Запятая в теге",
+			method:     http.MethodPost,
+			url:        "/task/tags/7",
+			body:       `{"tags":["a,b"]}`,
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "#4 Задача не найдена",
+			method: http.MethodPost,
+			url:    "/task/tags/8",
+			body:   `{"tags":["review"]}`,
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseAddTaskTags(gomock.Any(), 8, []string{"review"}).Return(nil, domain.ErrTaskNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "#5 Снятие тега",
+			method: http.MethodDelete,
+			url:    "/task/tags/7/%D0%A1%D0%BE%D0%B7%D0%B2%D0%BE%D0%BD",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseRemoveTaskTag(gomock.Any(), 7, "созвон").Return([]string{}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"tags":[]}`,
+		},
+		{
+			name:   "#6 Список тегов",
+			method: http.MethodGet,
+			url:    "/tags",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseListTags(gomock.Any()).Return([]models.Tag{{TagID: 1, Name: "meeting"}}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `[{"id":1,"name":"meeting"}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockCreate()
+
+			req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, authorize(req))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestWithAuth(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
+
+	conf := &config.Config{
+		SERVER_HOST: "localhost",
+		SERVER_PORT: "8080",
+	}
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
+
+	otherTokens, err := auth.NewTokens(strings.Repeat("x", auth.MinSecretLength), time.Minute, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	foreign, err := otherTokens.IssueAccessToken(auth.Principal{UserID: 1, Role: auth.RoleAdmin}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired, err := testTokens.IssueAccessToken(auth.Principal{UserID: 1, Role: auth.RoleAdmin}, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+		mockCreate    func()
+		wantStatus    int
+	}{
+		{
+			name:          "#1 Токен пользователя попадает в контекст",
+			authorization: bearer(auth.Principal{UserID: 7, Role: auth.RoleMember}),
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseRead(gomock.Any(), 7).DoAndReturn(func(ctx context.Context, userID int) (models.UserData, error) {
+					p, err := auth.FromContext(ctx)
+					assert.NoError(t, err)
+					assert.Equal(t, auth.Principal{UserID: 7, Role: auth.RoleMember}, p)
+					return models.UserData{}, nil
+				})
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:          "#2 Схема без учета регистра",
+			authorization: strings.Replace(bearer(auth.Principal{UserID: 7, Role: auth.RoleMember}), "Bearer", "bearer", 1),
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseRead(gomock.Any(), 7).Return(models.UserData{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:          "#3 Без заголовка",
+			authorization: "",
+			mockCreate:    func() {},
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "#4 Другая схема",
+			authorization: "Basic dXNlcjpwYXNz",
+			mockCreate:    func() {},
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "#5 Подпись другим ключом",
+			authorization: "Bearer " + foreign,
+			mockCreate:    func() {},
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "#6 Истекший токен",
+			authorization: "Bearer " + expired,
+			mockCreate:    func() {},
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "#7 Запрет доступа из use case",
+			authorization: bearer(auth.Principal{UserID: 8, Role: auth.RoleMember}),
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseRead(gomock.Any(), 7).Return(models.UserData{}, domain.ErrForbidden)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:          "#8 Ключ API",
+			authorization: "ApiKey tt_key",
+			mockCreate: func() {
+				key := auth.Principal{UserID: 7, Role: auth.RoleMember, Scopes: []string{auth.ScopeTasksWrite}}
+				mockUseCase.EXPECT().UseCaseAuthenticateAPIKey(gomock.Any(), "tt_key").Return(key, nil)
+				mockUseCase.EXPECT().UseCaseRead(gomock.Any(), 7).DoAndReturn(func(ctx context.Context, userID int) (models.UserData, error) {
+					p, err := auth.FromContext(ctx)
+					assert.NoError(t, err)
+					assert.Equal(t, key, p)
+					return models.UserData{}, nil
+				})
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:          "#9 Отозванный ключ API",
+			authorization: "apikey tt_revoked",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseAuthenticateAPIKey(gomock.Any(), "tt_revoked").Return(auth.Principal{}, domain.ErrUnauthorized)
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockCreate()
+
+			req, err := http.NewRequest(http.MethodGet, "/user/7", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus == http.StatusUnauthorized {
+				assert.Contains(t, rr.Header().Get("WWW-Authenticate"), "Bearer")
+				assert.Contains(t, rr.Body.String(), `"code":"unauthorized"`)
+			}
+		})
+	}
+
+	t.Run("#10 Публичные маршруты без токена", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/healthz", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestHandlerAuth(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
+
+	conf := &config.Config{
+		SERVER_HOST: "localhost",
+		SERVER_PORT: "8080",
+	}
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
+
+	tokens := models.TokenResponse{AccessToken: "access", TokenType: "Bearer", ExpiresIn: 900, RefreshToken: "refresh"}
+	creds := models.Credentials{Login: "ivanov", Password: "password1"}
+
+	tests := []struct {
+		name          string
+		method        string
+		url           string
+		body          string
+		authorization string
+		mockCreate    func()
+		wantStatus    int
+		wantBody      string
+	}{
+		{
+			name:          "#1 Вход",
+			method:        http.MethodPost,
+			url:           "/auth/login",
+			authorization: "-",
+			body:          `{"login": "ivanov", "password": "password1"}`,
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseLogin(gomock.Any(), creds).Return(tokens, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"access_token":"access","token_type":"Bearer","expires_in":900,"refresh_token":"refresh"}`,
+		},
+		{
+			name:          "#2 Неверный пароль",
+			method:        http.MethodPost,
+			url:           "/auth/login",
+			authorization: "-",
+			body:          `{"login": "ivanov", "password": "wrong"}`,
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseLogin(gomock.Any(), gomock.Any()).Return(models.TokenResponse{}, domain.ErrInvalidCredentials)
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "#3 Ошибка в теле запроса",
+			method:        http.MethodPost,
+			url:           "/auth/login",
+			authorization: "-",
+			body:          `{"login": "ivanov"`,
+			mockCreate:    func() {},
+			wantStatus:    http.StatusBadRequest,
+		},
+		{
+			name:          "#4 Обновление токенов",
+			method:        http.MethodPost,
+			url:           "/auth/refresh",
+			authorization: "-",
+			body:          `{"refresh_token": "old"}`,
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseRefresh(gomock.Any(), "old").Return(tokens, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:          "#5 Отозванный refresh-токен",
+			method:        http.MethodPost,
+			url:           "/auth/refresh",
+			authorization: "-",
+			body:          `{"refresh_token": "old"}`,
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseRefresh(gomock.Any(), "old").Return(models.TokenResponse{}, domain.ErrInvalidToken)
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "#6 Выход",
+			method:        http.MethodPost,
+			url:           "/auth/logout",
+			authorization: "-",
+			body:          `{"refresh_token": "old"}`,
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseLogout(gomock.Any(), "old").Return(nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:          "#7 Смена своих учетных данных",
+			method:        http.MethodPut,
+			url:           "/users/7/credentials",
+			body:          `{"login": "ivanov", "password": "password1"}`,
+			authorization: bearer(auth.Principal{UserID: 7, Role: auth.RoleMember}),
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseSetCredentials(gomock.Any(), 7, creds).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:          "#8 Короткий пароль",
+			method:        http.MethodPut,
+			url:           "/users/7/credentials",
+			body:          `{"login": "ivanov", "password": "short"}`,
+			authorization: bearer(auth.Principal{UserID: 7, Role: auth.RoleMember}),
+			mockCreate:    func() {},
+			wantStatus:    http.StatusUnprocessableEntity,
+		},
+		{
+			name:          "#9 Логин занят",
+			method:        http.MethodPut,
+			url:           "/users/7/credentials",
+			body:          `{"login": "ivanov", "password": "password1"}`,
+			authorization: bearer(auth.Principal{UserID: 7, Role: auth.RoleMember}),
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseSetCredentials(gomock.Any(), 7, creds).Return(domain.ErrDuplicateLogin)
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:          "#10 Учетные данные без токена",
+			method:        http.MethodPut,
+			url:           "/users/7/credentials",
+			body:          `{"login": "ivanov", "password": "password1"}`,
+			authorization: "-",
+			mockCreate:    func() {},
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:   "#11 Назначение роли",
+			method: http.MethodPut,
+			url:    "/users/7/role",
+			body:   `{"role": "admin"}`,
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseSetUserRole(gomock.Any(), 7, auth.RoleAdmin).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:          "#12 Назначение роли не администратором",
+			method:        http.MethodPut,
+			url:           "/users/7/role",
+			body:          `{"role": "admin"}`,
+			authorization: bearer(auth.Principal{UserID: 7, Role: auth.RoleMember}),
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseSetUserRole(gomock.Any(), 7, auth.RoleAdmin).Return(domain.ErrForbidden)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockCreate()
+
+			req, err := http.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			switch tt.authorization {
+			case "":
+				authorize(req)
+			case "-":
+				// запрос без заголовка Authorization
+			default:
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandlerRoles(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
+
+	conf := &config.Config{
+		SERVER_HOST: "localhost",
+		SERVER_PORT: "8080",
+	}
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
+
+	managerID := 2
+	manager := bearer(auth.Principal{UserID: 2, Role: auth.RoleManager})
+
+	tests := []struct {
+		name          string
+		method        string
+		url           string
+		body          string
+		authorization string
+		mockCreate    func()
+		wantStatus    int
+		wantBody      string
+	}{
+		{
+			name:   "#1 Список ролей",
+			method: http.MethodGet,
+			url:    "/roles",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseListRoles(gomock.Any()).Return([]models.Role{{Name: auth.RoleMember, Description: "свои задачи"}}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `[{"name":"member","description":"свои задачи"}]`,
+		},
+		{
+			name:   "#2 Назначение менеджера",
+			method: http.MethodPut,
+			url:    "/users/1/manager",
+			body:   `{"manager_id": 2}`,
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseSetUserManager(gomock.Any(), 1, &managerID).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "#3 Исключение из команды",
+			method: http.MethodPut,
+			url:    "/users/1/manager",
+			body:   `{"manager_id": null}`,
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseSetUserManager(gomock.Any(), 1, (*int)(nil)).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "#4 Менеджер не найден",
+			method: http.MethodPut,
+			url:    "/users/1/manager",
+			body:   `{"manager_id": 99}`,
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseSetUserManager(gomock.Any(), 1, gomock.Any()).Return(domain.ErrUserNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:          "#5 Назначение менеджера не администратором",
+			method:        http.MethodPut,
+			url:           "/users/1/manager",
+			body:          `{"manager_id": 2}`,
+			authorization: manager,
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseSetUserManager(gomock.Any(), 1, &managerID).Return(domain.ErrForbidden)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:          "#6 Команда менеджера",
+			method:        http.MethodGet,
+			url:           "/users/2/team",
+			authorization: manager,
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseListTeam(gomock.Any(), 2).Return([]models.UserData{{UserID: "1", Name: "Иван"}}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "#7 Ошибка в UserID",
+			method:     http.MethodGet,
+			url:        "/users/abc/team",
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockCreate()
+
+			req, err := http.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, authorize(req))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandlerAPIKeys(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
+
+	conf := &config.Config{
+		SERVER_HOST: "localhost",
+		SERVER_PORT: "8080",
+	}
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
+
+	created := models.APIKeyCreated{
+		APIKey: models.APIKey{ID: 5, Name: "ci-bot", Prefix: "tt_abcdefg", Scopes: []string{auth.ScopeTasksWrite, auth.ScopeReportsRead}},
+		Key:    "tt_abcdefgsecret",
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		body       string
+		mockCreate func()
+		wantStatus int
+	}{
+		{
+			name:   "#1 Создание ключа",
+			method: http.MethodPost,
+			url:    "/users/1/api-keys",
+			body:   `{"name": "ci-bot", "scopes": ["reports:read", "tasks:write", "tasks:write"]}`,
+			mockCreate: func() {
+				// права приходят без повторов и в порядке auth.Scopes
+				mockUseCase.EXPECT().UseCaseCreateAPIKey(gomock.Any(), 1, models.APIKeyRequest{
+					Name:   "ci-bot",
+					Scopes: []string{auth.ScopeTasksWrite, auth.ScopeReportsRead},
+				}).Return(created, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "#2 Неизвестное право",
+			method:     http.MethodPost,
+			url:        "/users/1/api-keys",
+			body:       `{"name": "ci-bot", "scopes": ["users:manage"]}`,
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "#3 Без прав",
+			method:     http.MethodPost,
+			url:        "/users/1/api-keys",
+			body:       `{"name": "ci-bot", "scopes": []}`,
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "#4 Срок действия в прошлом",
+			method:     http.MethodPost,
+			url:        "/users/1/api-keys",
+			body:       `{"name": "ci-bot", "scopes": ["tasks:write"], "expires_at": "2020-01-01T00:00:00Z"}`,
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "#5 Пустое название",
+			method:     http.MethodPost,
+			url:        "/users/1/api-keys",
+			body:       `{"name": " ", "scopes": ["tasks:write"]}`,
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "#6 Список ключей",
+			method: http.MethodGet,
+			url:    "/users/1/api-keys",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseListAPIKeys(gomock.Any(), 1).Return([]models.APIKey{created.APIKey}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "#7 Отзыв ключа",
+			method: http.MethodDelete,
+			url:    "/users/1/api-keys/5",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseRevokeAPIKey(gomock.Any(), 1, 5).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "#8 Ключ не найден",
+			method: http.MethodDelete,
+			url:    "/users/1/api-keys/6",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseRevokeAPIKey(gomock.Any(), 1, 6).Return(domain.ErrAPIKeyNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "#9 Ошибка в KeyID",
+			method:     http.MethodDelete,
+			url:        "/users/1/api-keys/abc",
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockCreate()
+
+			req, err := http.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, authorize(req))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
+
+func TestExportTimeout(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
+
+	conf := &config.Config{
+		SERVER_HOST:     "localhost",
+		SERVER_PORT:     "8080",
+		REQUEST_TIMEOUT: 10 * time.Millisecond,
+		EXPORT_TIMEOUT:  time.Second,
+	}
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
+
+	row := models.ExportRow{
+		UserName:        "Иванов Иван Иванович",
+		TaskID:          1,
+		NameTask:        "Отчет",
+		Start:           sql.NullTime{Time: time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC), Valid: true},
+		DurationSeconds: 600,
+	}
+
+	// выгрузка пишет строки дольше REQUEST_TIMEOUT и завершается до EXPORT_TIMEOUT
+	mockUseCase.EXPECT().UseCaseExportReport(gomock.Any(), 1, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, userID int, filter models.ReportFilter, fn func(models.ExportRow) error) error {
+			deadline, ok := ctx.Deadline()
+			assert.True(t, ok)
+			assert.Greater(t, time.Until(deadline), conf.REQUEST_TIMEOUT)
+
+			for i := 0; i < 3; i++ {
+				if err := fn(row); err != nil {
+					return err
+				}
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(2 * conf.REQUEST_TIMEOUT):
+				}
+			}
+			return nil
+		})
+
+	req, err := http.NewRequest(http.MethodGet, "/users/1/report?from=2024-01-01&to=2024-01-31&format=csv", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, authorize(req))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Equal(t, 4, strings.Count(rr.Body.String(), "\n"))
+
+	// JSON-отчет по тому же маршруту по-прежнему ограничен REQUEST_TIMEOUT
+	mockUseCase.EXPECT().UseCaseReport(gomock.Any(), 1, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, userID int, filter models.ReportFilter) (models.Report, error) {
+			<-ctx.Done()
+			return models.Report{}, ctx.Err()
+		})
+
+	req, err = http.NewRequest(http.MethodGet, "/users/1/report?from=2024-01-01&to=2024-01-31", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, authorize(req))
+
+	assert.Equal(t, http.StatusGatewayTimeout, rr.Code)
+}
+
+func TestRequestTimeout(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
+
+	conf := &config.Config{
+		SERVER_HOST:     "localhost",
+		SERVER_PORT:     "8080",
+		REQUEST_TIMEOUT: 10 * time.Millisecond,
+	}
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
+
+	// use case получает контекст запроса с дедлайном и возвращает его ошибку по истечении времени
+	mockUseCase.EXPECT().UseCaseRead(gomock.Any(), 1).DoAndReturn(func(ctx context.Context, userID int) (models.UserData, error) {
+		_, ok := ctx.Deadline()
+		assert.True(t, ok)
+		<-ctx.Done()
+		return models.UserData{}, ctx.Err()
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "/user/1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, authorize(req))
+
+	assert.Equal(t, http.StatusGatewayTimeout, rr.Code)
+}
+
+func TestHandlerReadyz(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
+
+	// Создание мок-сервера
+	mockServer := NewMockServer()
+	defer mockServer.Close()
+
+	tests := []struct {
+		name       string
+		conf       *config.Config
+		mockCreate func()
+		wantStatus int
+		wantChecks map[string]string
+	}{
+		{
+			name: "#1 Все зависимости доступны",
+			conf: &config.Config{SERVER_HOST: "localhost", SERVER_PORT: "8080"},
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCasePing(gomock.Any()).Return(nil)
+				mockUseCase.EXPECT().UseCaseMigrationVersion(gomock.Any()).Return(uint(3), false, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantChecks: map[string]string{"database": "ok", "migrations": "ok", "enrichment_api": "skipped"},
+		},
+		{
+			name: "#2 БД недоступна",
+			conf: &config.Config{SERVER_HOST: "localhost", SERVER_PORT: "8080"},
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCasePing(gomock.Any()).Return(errors.New("connection refused"))
+				mockUseCase.EXPECT().UseCaseMigrationVersion(gomock.Any()).Return(uint(0), false, errors.New("connection refused"))
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantChecks: map[string]string{"database": "degraded", "migrations": "degraded", "enrichment_api": "skipped"},
+		},
+		{
+			name: "#3 Миграция применена не полностью",
+			conf: &config.Config{SERVER_HOST: "localhost", SERVER_PORT: "8080"},
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCasePing(gomock.Any()).Return(nil)
+				mockUseCase.EXPECT().UseCaseMigrationVersion(gomock.Any()).Return(uint(3), true, nil)
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantChecks: map[string]string{"database": "ok", "migrations": "degraded", "enrichment_api": "skipped"},
+		},
+		{
+			name: "#4 Сторонний API доступен",
+			conf: &config.Config{SERVER_HOST: "localhost", SERVER_PORT: "8080", API_URL: mockServer.URL, READINESS_CHECK_API: true},
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCasePing(gomock.Any()).Return(nil)
+				mockUseCase.EXPECT().UseCaseMigrationVersion(gomock.Any()).Return(uint(3), false, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantChecks: map[string]string{"database": "ok", "migrations": "ok", "enrichment_api": "ok"},
+		},
+		{
+			name: "#5 Сторонний API недоступен",
+			conf: &config.Config{SERVER_HOST: "localhost", SERVER_PORT: "8080", API_URL: "http://127.0.0.1:1", READINESS_CHECK_API: true},
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCasePing(gomock.Any()).Return(nil)
+				mockUseCase.EXPECT().UseCaseMigrationVersion(gomock.Any()).Return(uint(3), false, nil)
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantChecks: map[string]string{"database": "ok", "migrations": "ok", "enrichment_api": "degraded"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockCreate()
+			router := InitRoutes(mockUseCase, tt.conf, testTokens, apiDataUser.NewClient(tt.conf))
+
+			req, err := http.NewRequest(http.MethodGet, "/readyz", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, authorize(req))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+
+			var report models.HealthReport
+			assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &report))
+			for name, status := range tt.wantChecks {
+				assert.Equal(t, status, report.Checks[name].Status, name)
+			}
+		})
+	}
+}
+
+func TestHandlerHealthz(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
+
+	conf := &config.Config{
+		SERVER_HOST: "localhost",
+		SERVER_PORT: "8080",
+	}
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
+
+	req, err := http.NewRequest(http.MethodGet, "/healthz", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, authorize(req))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestMetrics(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
+
+	conf := &config.Config{
+		SERVER_HOST: "localhost",
+		SERVER_PORT: "8080",
+	}
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
+
+	req, err := http.NewRequest(http.MethodGet, "/user/abc", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	router.ServeHTTP(httptest.NewRecorder(), authorize(req))
+
+	req, err = http.NewRequest(http.MethodGet, "/metrics", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, authorize(req))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	// метки строятся по шаблону маршрута, а не по конкретному URL
+	assert.Contains(t, rr.Body.String(), `time_tracker_http_requests_total{method="GET",route="/user/{userID}",status="422"}`)
+}
+
+func TestHandlerListTasks(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
+
+	// Создаем конфигурацию и роутер с использованием моков
+	conf := &config.Config{
+		SERVER_HOST: "localhost",
+		SERVER_PORT: "8080",
+	}
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
+
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		mockCreate func()
+		wantStatus int
+	}{
+		{
+			name:   "#1 Успешный запрос без фильтров",
+			method: http.MethodGet,
+			url:    "/users/1/tasks",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseListTasks(gomock.Any(), 1, models.TaskFilter{SortBy: models.TaskSortID, Limit: 20}).
+					Return(models.TaskPage{Tasks: []models.TaskItem{}}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "#2 Успешный запрос с фильтрами",
+			method: http.MethodGet,
+			url:    "/users/1/tasks?status=finished&name=bug&from=2024-01-01&to=2024-01-31&sort=duration&order=desc&limit=5&cursor=abc",
+			mockCreate: func() {
+				// даты без времени переводятся в часовой пояс пользователя в хранилище
+				mockUseCase.EXPECT().UseCaseListTasks(gomock.Any(), 1, models.TaskFilter{
+					Status:   models.TaskStatusFinished,
+					Name:     "bug",
+					FromDay:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+					ToDay:    time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+					SortBy:   models.TaskSortDuration,
+					SortDesc: true,
+					Cursor:   "abc",
+					Limit:    5,
+				}).Return(models.TaskPage{Tasks: []models.TaskItem{}}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "#3 Границы в формате RFC3339",
+			method: http.MethodGet,
+			url:    "/users/1/tasks?from=2024-01-01T09:00:00%2B03:00&to=2024-01-31",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseListTasks(gomock.Any(), 1, models.TaskFilter{
+					From:   time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC),
+					ToDay:  time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+					SortBy: models.TaskSortID,
+					Limit:  20,
+				}).Return(models.TaskPage{Tasks: []models.TaskItem{}}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "#4 Неверные параметры",
+			method:     http.MethodGet,
+			url:        "/users/1/tasks?status=done&sort=user&limit=1000",
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "#5 Пользователь не найден",
+			method: http.MethodGet,
+			url:    "/users/2/tasks",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseListTasks(gomock.Any(), 2, gomock.Any()).
+					Return(models.TaskPage{}, fmt.Errorf("%w: id %d", domain.ErrUserNotFound, 2))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "#6 Неверный ID",
+			method:     http.MethodGet,
+			url:        "/users/a/tasks",
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "#7 Фильтр по всем тегам",
+			method: http.MethodGet,
+			url:    "/users/1/tasks?tags=Meeting,%20review,meeting&tag_match=all",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseListTasks(gomock.Any(), 1, models.TaskFilter{
+					Tags:     []string{"meeting", "review"},
+					TagMatch: models.TagMatchAll,
+					SortBy:   models.TaskSortID,
+					Limit:    20,
+				}).Return(models.TaskPage{Tasks: []models.TaskItem{}}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "#8 Неверный режим фильтра по тегам",
+			method:     http.MethodGet,
+			url:        "/users/1/tasks?tags=meeting&tag_match=some",
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockCreate()
+
+			req, err := http.NewRequest(tt.method, tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, authorize(req))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
+
+func TestHandlerListTasksExport(t *testing.T) {
+	if err := logger.InitLogger(""); err != nil {
+		panic("cannot initialize zap")
+	}
+	defer logger.SugaredLogger().Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUseCaseStorage(ctrl)
+
+	conf := &config.Config{
+		SERVER_HOST: "localhost",
+		SERVER_PORT: "8080",
+	}
+	router := InitRoutes(mockUseCase, conf, testTokens, apiDataUser.NewClient(conf))
+
+	rows := []models.ExportRow{
+		{
+			UserName:        "Иванов Иван Иванович",
+			TaskID:          1,
+			NameTask:        "Отчет",
+			ProjectName:     "Сайт",
+			Start:           sql.NullTime{Time: time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC), Valid: true},
+			End:             sql.NullTime{Time: time.Date(2024, 1, 10, 10, 30, 0, 0, time.UTC), Valid: true},
+			DurationSeconds: 5400,
+		},
+		{UserName: "Иванов Иван Иванович", TaskID: 2, NameTask: "Созвон"},
+	}
+	exportRows := func(ctx context.Context, userID int, filter models.TaskFilter, fn func(models.ExportRow) error) error {
+		for _, row := range rows {
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name            string
+		url             string
+		accept          string
+		mockCreate      func()
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name: "#1 CSV по параметру format с фильтром",
+			url:  "/users/1/tasks?format=csv&status=finished&sort=duration&order=desc",
+			mockCreate: func() {
+				filter := models.TaskFilter{Status: "finished", SortBy: models.TaskSortDuration, SortDesc: true, Limit: 20}
+				mockUseCase.EXPECT().UseCaseExportTasks(gomock.Any(), 1, filter, gomock.Any()).DoAndReturn(exportRows)
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			wantBody: "Пользователь,ID задачи,Задача,Проект,Клиент,Начало,Окончание,\"Длительность, сек\"\n" +
+				"Иванов Иван Иванович,1,Отчет,Сайт,,2024-01-10 09:00:00,2024-01-10 10:30:00,5400\n" +
+				"Иванов Иван Иванович,2,Созвон,,,,,0\n",
+		},
+		{
+			name:   "#2 XLSX по заголовку Accept",
+			url:    "/users/1/tasks",
+			accept: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseExportTasks(gomock.Any(), 1, gomock.Any(), gomock.Any()).DoAndReturn(exportRows)
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		},
+		{
+			name:   "#3 Доступ запрещен до начала выгрузки",
+			url:    "/users/1/tasks",
+			accept: "text/csv",
+			mockCreate: func() {
+				mockUseCase.EXPECT().UseCaseExportTasks(gomock.Any(), 1, gomock.Any(), gomock.Any()).Return(domain.ErrForbidden)
+			},
+			wantStatus:      http.StatusForbidden,
+			wantContentType: "application/json",
+		},
+		{
+			name:       "#4 Неизвестный формат",
+			url:        "/users/1/tasks?format=pdf",
+			mockCreate: func() {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockCreate()
+
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, authorize(req))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantContentType != "" {
+				assert.Equal(t, tt.wantContentType, rr.Header().Get("Content-Type"))
+			}
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, rr.Body.String())
+				assert.Equal(t, `attachment; filename="tasks-1.csv"`, rr.Header().Get("Content-Disposition"))
+			}
 		})
 	}
 }
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/golang-migrate/migrate/v4"
+	"net/http"
+	"time-tracker/internal/API/apiDataUser"
+	"time-tracker/internal/config"
+	"time-tracker/internal/logger"
+	"time-tracker/internal/models"
+	"time-tracker/internal/usecase"
+)
+
+const (
+	healthOK       = "ok"
+	healthDegraded = "degraded"
+	healthSkipped  = "skipped"
+)
+
+// @Summary Проверка жизнеспособности (liveness)
+// @Description Отвечает 200, пока процесс запущен. Зависимости не проверяются.
+// @Tags Health
+// @Produce json
+// @Success 200 {object} models.HealthReport "Процесс запущен"
+// @Router /healthz [get]
+func HandlerHealthz(w http.ResponseWriter, r *http.Request) {
+	writeHealthReport(w, models.HealthReport{Status: healthOK})
+}
+
+// @Summary Проверка готовности (readiness)
+// @Description Проверяет доступность БД, версию миграций и (если включено READINESS_CHECK_API) сторонний API.
+// @Description Возвращает состояние каждой зависимости и 503, если хотя бы одна из них недоступна.
+// @Tags Health
+// @Produce json
+// @Success 200 {object} models.HealthReport "Все зависимости доступны"
+// @Failure 503 {object} models.HealthReport "Одна или несколько зависимостей недоступны"
+// @Router /readyz [get]
+func HandlerReadyz(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage, conf *config.Config, enricher apiDataUser.Enricher) {
+	report := models.HealthReport{Status: healthOK, Checks: map[string]models.HealthCheck{}}
+
+	if err := useCase.UseCasePing(r.Context()); err != nil {
+		report.Checks["database"] = degradedCheck(err)
+	} else {
+		report.Checks["database"] = models.HealthCheck{Status: healthOK}
+	}
+
+	version, dirty, err := useCase.UseCaseMigrationVersion(r.Context())
+	switch {
+	case errors.Is(err, migrate.ErrNilVersion):
+		report.Checks["migrations"] = models.HealthCheck{Status: healthDegraded, Error: "миграции не применены"}
+	case err != nil:
+		report.Checks["migrations"] = degradedCheck(err)
+	case dirty:
+		report.Checks["migrations"] = models.HealthCheck{
+			Status:  healthDegraded,
+			Error:   "миграция применена не полностью (dirty)",
+			Details: map[string]interface{}{"version": version, "dirty": dirty},
+		}
+	default:
+		report.Checks["migrations"] = models.HealthCheck{
+			Status:  healthOK,
+			Details: map[string]interface{}{"version": version, "dirty": dirty},
+		}
+	}
+
+	if conf.READINESS_CHECK_API {
+		if err := enricher.Ping(r.Context()); err != nil {
+			report.Checks["enrichment_api"] = degradedCheck(err)
+		} else {
+			report.Checks["enrichment_api"] = models.HealthCheck{Status: healthOK}
+		}
+	} else {
+		report.Checks["enrichment_api"] = models.HealthCheck{Status: healthSkipped}
+	}
+
+	for _, check := range report.Checks {
+		if check.Status == healthDegraded {
+			report.Status = healthDegraded
+		}
+	}
+
+	writeHealthReport(w, report)
+}
+
+func degradedCheck(err error) models.HealthCheck {
+	logger.SugaredLogger().Warnw("Зависимость недоступна", "error", err)
+	return models.HealthCheck{Status: healthDegraded, Error: err.Error()}
+}
+
+func writeHealthReport(w http.ResponseWriter, report models.HealthReport) {
+	res, err := json.Marshal(report)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	status := http.StatusOK
+	if report.Status != healthOK {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(res)
+}
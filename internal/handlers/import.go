@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/importer"
+	"time-tracker/internal/usecase"
+)
+
+// максимальный размер импортируемого файла
+const maxImportSize = 10 << 20
+
+// @Summary Импорт задач из iCalendar или CSV
+// @Description Загружает записи из выгрузки другого трекера как завершенные ручные задачи пользователя. Файл передается телом запроса.
+// @Description Формат задается параметром format или заголовком Content-Type (text/calendar, text/csv).
+// @Description iCalendar: каждое событие VEVENT - задача (SUMMARY - название, DTSTART/DTEND - время); события на весь день и повторяющиеся события пропускаются.
+// @Description CSV: заголовок с колонками name_task (или name, task, Задача), start (Начало), end (Окончание) и необязательной external_id (uid, id); разделитель - запятая или точка с запятой.
+// @Description Время в RFC3339 или ГГГГ-ММ-ДД ЧЧ:ММ:СС; время без часового пояса считается временем пользователя (time_zone в настройках).
+// @Description Повторный импорт того же файла ничего не добавляет: записи сравниваются по UID события, external_id или, если их нет, по названию и времени.
+// @Description Записи, которые пересекаются с задачами пользователя или друг с другом, пропускаются. С dry_run=true ничего не сохраняется, а ответ показывает, что будет импортировано и какие записи будут пропущены.
+// @Tags Tasks
+// @Accept text/calendar
+// @Accept text/csv
+// @Produce json
+// @Security BearerAuth
+// @Param userID path int true "ID пользователя"
+// @Param format query string false "Формат файла (по умолчанию - по Content-Type)" Enums(ics, csv)
+// @Param dry_run query bool false "Только проверить файл, ничего не сохраняя"
+// @Param file body string true "Содержимое файла"
+// @Success 200 {object} models.ImportResult "Итог импорта и пропущенные записи"
+// @Failure 404 {object} models.ErrorResponse "Пользователь не найден"
+// @Failure 422 {object} models.ErrorResponse "Неизвестный формат или файл не читается"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /users/{userID}/import [post]
+func HandlerImportTasks(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodPost {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	userID, err := urlParamInt(r, "userID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	query := r.URL.Query()
+	vErr := &domain.ValidationError{}
+
+	format := query.Get("format")
+	if format == "" {
+		format = importer.FormatFromContentType(r.Header.Get("Content-Type"), "")
+	}
+	if format != importer.FormatICS && format != importer.FormatCSV {
+		vErr.Add("format", "must be one of: ics, csv (or Content-Type: text/calendar, text/csv)")
+	}
+
+	dryRun := false
+	if value := query.Get("dry_run"); value != "" {
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			vErr.Add("dry_run", "must be a boolean")
+		}
+	}
+
+	if vErr.HasErrors() {
+		writeError(w, vErr)
+		return
+	}
+
+	file := http.MaxBytesReader(w, r.Body, maxImportSize)
+	result, err := useCase.UseCaseImportTasks(r.Context(), userID, format, file, dryRun)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, result)
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/export"
+)
+
+// withRequestTimeout ограничивает время жизни контекста запроса.
+// Контекст передается до запросов к БД, поэтому по истечении времени они отменяются.
+// Нулевое значение отключает ограничение.
+func withRequestTimeout(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if timeout <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// withExportTimeout ограничивает время запроса к маршруту, который отдает данные и файлом:
+// выгрузка в CSV или XLSX получает exportTimeout, ответ JSON - обычный timeout.
+// Нулевое значение отключает ограничение.
+func withExportTimeout(timeout, exportTimeout time.Duration) func(http.Handler) http.Handler {
+	regular, long := withRequestTimeout(timeout), withRequestTimeout(exportTimeout)
+	return func(next http.Handler) http.Handler {
+		regularNext, exportNext := regular(next), long(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// неизвестный формат обработчик отклонит сам, время такого запроса обычное
+			if format := parseFormat(r, &domain.ValidationError{}); format == export.FormatCSV || format == export.FormatXLSX {
+				exportNext.ServeHTTP(w, r)
+				return
+			}
+			regularNext.ServeHTTP(w, r)
+		})
+	}
+}
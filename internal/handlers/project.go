@@ -0,0 +1,405 @@
+package handlers
+
+import (
+	"net/http"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/models"
+	"time-tracker/internal/usecase"
+	"time-tracker/internal/validator"
+)
+
+// @Summary Добавление клиента
+// @Description Создает клиента. Название должно быть уникальным без учета регистра.
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body models.ClientRequest true "Название клиента (не длиннее 100 символов)"
+// @Success 200 {string} string "ClientID: {clientID}"
+// @Failure 400 {object} models.ErrorResponse "Ошибка декодирования тела запроса"
+// @Failure 409 {object} models.ErrorResponse "Клиент с таким названием уже есть"
+// @Failure 422 {object} models.ErrorResponse "Пустое или слишком длинное название"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /clients [post]
+func HandlerCreateClient(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodPost {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	var client models.ClientRequest
+	if err := decodeJSON(r, &client); err != nil {
+		writeError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := validator.ValidateTaskName("name", client.Name); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	clientID, err := useCase.UseCaseCreateClient(r.Context(), client.Name)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, map[string]int{"ClientID": clientID})
+}
+
+// @Summary Список клиентов
+// @Description Возвращает всех клиентов по алфавиту.
+// @Tags Projects
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.Client "Клиенты"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /clients [get]
+func HandlerListClients(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodGet {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	clients, err := useCase.UseCaseListClients(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, clients)
+}
+
+// @Summary Получение клиента
+// @Tags Projects
+// @Produce json
+// @Security BearerAuth
+// @Param clientID path int true "ID клиента"
+// @Success 200 {object} models.Client "Клиент"
+// @Failure 404 {object} models.ErrorResponse "Клиент не найден"
+// @Failure 422 {object} models.ErrorResponse "Ошибка конвертирования ClientID"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /clients/{clientID} [get]
+func HandlerGetClient(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodGet {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	clientID, err := urlParamInt(r, "clientID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	client, err := useCase.UseCaseReadClient(r.Context(), clientID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, client)
+}
+
+// @Summary Переименование клиента
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param clientID path int true "ID клиента"
+// @Param body body models.ClientRequest true "Новое название клиента (не длиннее 100 символов)"
+// @Success 200 {string} string "Клиент переименован"
+// @Failure 400 {object} models.ErrorResponse "Ошибка декодирования тела запроса"
+// @Failure 404 {object} models.ErrorResponse "Клиент не найден"
+// @Failure 409 {object} models.ErrorResponse "Клиент с таким названием уже есть"
+// @Failure 422 {object} models.ErrorResponse "Ошибка ClientID или пустое/слишком длинное название"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /clients/{clientID} [put]
+func HandlerUpdateClient(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodPut {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	clientID, err := urlParamInt(r, "clientID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var client models.ClientRequest
+	if err := decodeJSON(r, &client); err != nil {
+		writeError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := validator.ValidateTaskName("name", client.Name); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := useCase.UseCaseUpdateClient(r.Context(), clientID, client.Name); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Удаление клиента
+// @Description Удаляет клиента без проектов. Проекты клиента нужно сначала удалить или перенести к другому клиенту.
+// @Tags Projects
+// @Produce json
+// @Security BearerAuth
+// @Param clientID path int true "ID клиента"
+// @Success 200 {string} string "Клиент удален"
+// @Failure 404 {object} models.ErrorResponse "Клиент не найден"
+// @Failure 409 {object} models.ErrorResponse "У клиента есть проекты"
+// @Failure 422 {object} models.ErrorResponse "Ошибка конвертирования ClientID"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /clients/{clientID} [delete]
+func HandlerDeleteClient(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodDelete {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	clientID, err := urlParamInt(r, "clientID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := useCase.UseCaseDeleteClient(r.Context(), clientID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Добавление проекта
+// @Description Создает проект клиента или внутренний проект (client_id не передан или null). Название уникально в пределах клиента.
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body models.ProjectRequest true "Название проекта (не длиннее 100 символов) и ID клиента"
+// @Success 200 {string} string "ProjectID: {projectID}"
+// @Failure 400 {object} models.ErrorResponse "Ошибка декодирования тела запроса"
+// @Failure 404 {object} models.ErrorResponse "Клиент не найден"
+// @Failure 409 {object} models.ErrorResponse "У клиента уже есть проект с таким названием"
+// @Failure 422 {object} models.ErrorResponse "Пустое или слишком длинное название"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /projects [post]
+func HandlerCreateProject(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodPost {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	var project models.ProjectRequest
+	if err := decodeJSON(r, &project); err != nil {
+		writeError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := validator.ValidateTaskName("name", project.Name); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	projectID, err := useCase.UseCaseCreateProject(r.Context(), project)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, map[string]int{"ProjectID": projectID})
+}
+
+// @Summary Список проектов
+// @Description Возвращает проекты по алфавиту, все или только проекты клиента.
+// @Tags Projects
+// @Produce json
+// @Security BearerAuth
+// @Param client_id query int false "ID клиента"
+// @Success 200 {array} models.Project "Проекты"
+// @Failure 404 {object} models.ErrorResponse "Клиент не найден"
+// @Failure 422 {object} models.ErrorResponse "Некорректный client_id"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /projects [get]
+func HandlerListProjects(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodGet {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	vErr := &domain.ValidationError{}
+	clientID := queryID(r, "client_id", vErr)
+	if vErr.HasErrors() {
+		writeError(w, vErr)
+		return
+	}
+
+	projects, err := useCase.UseCaseListProjects(r.Context(), clientID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, projects)
+}
+
+// @Summary Получение проекта
+// @Tags Projects
+// @Produce json
+// @Security BearerAuth
+// @Param projectID path int true "ID проекта"
+// @Success 200 {object} models.Project "Проект"
+// @Failure 404 {object} models.ErrorResponse "Проект не найден"
+// @Failure 422 {object} models.ErrorResponse "Ошибка конвертирования ProjectID"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /projects/{projectID} [get]
+func HandlerGetProject(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodGet {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	projectID, err := urlParamInt(r, "projectID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	project, err := useCase.UseCaseReadProject(r.Context(), projectID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, project)
+}
+
+// @Summary Изменение проекта
+// @Description Меняет название и клиента проекта. Если client_id не передан или null, проект становится внутренним.
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param projectID path int true "ID проекта"
+// @Param body body models.ProjectRequest true "Название проекта (не длиннее 100 символов) и ID клиента"
+// @Success 200 {string} string "Проект изменен"
+// @Failure 400 {object} models.ErrorResponse "Ошибка декодирования тела запроса"
+// @Failure 404 {object} models.ErrorResponse "Проект или клиент не найден"
+// @Failure 409 {object} models.ErrorResponse "У клиента уже есть проект с таким названием"
+// @Failure 422 {object} models.ErrorResponse "Ошибка ProjectID или пустое/слишком длинное название"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /projects/{projectID} [put]
+func HandlerUpdateProject(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodPut {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	projectID, err := urlParamInt(r, "projectID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var project models.ProjectRequest
+	if err := decodeJSON(r, &project); err != nil {
+		writeError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := validator.ValidateTaskName("name", project.Name); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := useCase.UseCaseUpdateProject(r.Context(), projectID, project); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Удаление проекта
+// @Description Удаляет проект. Задачи проекта не удаляются, а остаются без проекта.
+// @Tags Projects
+// @Produce json
+// @Security BearerAuth
+// @Param projectID path int true "ID проекта"
+// @Success 200 {string} string "Проект удален"
+// @Failure 404 {object} models.ErrorResponse "Проект не найден"
+// @Failure 422 {object} models.ErrorResponse "Ошибка конвертирования ProjectID"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /projects/{projectID} [delete]
+func HandlerDeleteProject(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodDelete {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	projectID, err := urlParamInt(r, "projectID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := useCase.UseCaseDeleteProject(r.Context(), projectID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Привязка задачи к проекту
+// @Description Привязывает задачу к проекту; project_id = null снимает задачу с проекта.
+// @Tags Tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param taskID path int true "ID задачи"
+// @Param body body models.TaskProject true "ID проекта или null"
+// @Success 200 {string} string "Проект задачи изменен"
+// @Failure 400 {object} models.ErrorResponse "Ошибка декодирования тела запроса"
+// @Failure 404 {object} models.ErrorResponse "Задача или проект не найдены"
+// @Failure 422 {object} models.ErrorResponse "Ошибка Task ID"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /task/project/{taskID} [put]
+func HandlerSetTaskProject(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodPut {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	taskID, err := urlParamInt(r, "taskID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var project models.TaskProject
+	if err := decodeJSON(r, &project); err != nil {
+		writeError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := useCase.UseCaseSetTaskProject(r.Context(), taskID, project.ProjectID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
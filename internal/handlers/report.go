@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/export"
+	"time-tracker/internal/logger"
+	"time-tracker/internal/models"
+	"time-tracker/internal/usecase"
+)
+
+// @Summary Отчет по времени пользователя
+// @Description Суммирует время работы пользователя за период в секундах с группировкой по дням, неделям (с понедельника), месяцам, задачам, проектам, клиентам или тегам.
+// @Description Отчет можно ограничить проектом (project_id) или клиентом (client_id). При группировке по проектам или клиентам время задач без проекта (без клиента) попадает в группу без project_id (client_id).
+// @Description Отчет можно ограничить тегами (tags, tag_match). При группировке по тегам задача с несколькими тегами учитывается в каждом из них, поэтому сумма групп может превышать total_seconds.
+// @Description Даты и границы периодов считаются в часовом поясе пользователя (time_zone в /users/{userID}/settings). Время запущенных задач учитывается до текущего момента.
+// @Description В ответ попадают только группы, по которым было время.
+// @Description Выгрузка в CSV или XLSX выбирается параметром format или заголовком Accept (text/csv, application/vnd.openxmlformats-officedocument.spreadsheetml.sheet):
+// @Description по строке на каждый интервал работы (пользователь, задача, проект, клиент, начало, окончание, длительность), в XLSX также лист итогов. group_by при выгрузке не учитывается.
+// @Tags Reports
+// @Produce json
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param userID path int true "ID пользователя"
+// @Param from query string true "Первый день периода (ГГГГ-ММ-ДД)"
+// @Param to query string true "Последний день периода включительно (ГГГГ-ММ-ДД)"
+// @Param group_by query string false "Группировка" Enums(day, week, month, task, project, client, tag) default(day)
+// @Param project_id query int false "ID проекта"
+// @Param client_id query int false "ID клиента"
+// @Param tags query string false "Теги через запятую"
+// @Param tag_match query string false "Задачи с любым из тегов или со всеми тегами" Enums(any, all) default(any)
+// @Param format query string false "Формат ответа (по умолчанию - по заголовку Accept, иначе json)" Enums(json, csv, xlsx)
+// @Success 200 {object} models.Report "Отчет"
+// @Failure 404 {object} models.ErrorResponse "Пользователь не найден"
+// @Failure 422 {object} models.ErrorResponse "Ошибка валидации параметров (в details - какие параметры неверны)"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /users/{userID}/report [get]
+func HandlerReport(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodGet {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	userID, err := urlParamInt(r, "userID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	filter, format, err := parseReportFilter(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if format != export.FormatJSON {
+		filename := fmt.Sprintf("report-%d-%s-%s.%s", userID,
+			filter.From.Format(time.DateOnly), filter.To.Format(time.DateOnly), format)
+		writeExport(w, r, format, filename, func(row func(models.ExportRow) error) error {
+			return useCase.UseCaseExportReport(r.Context(), userID, filter, row)
+		})
+		return
+	}
+
+	report, err := useCase.UseCaseReport(r.Context(), userID, filter)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, report)
+}
+
+// parseReportFilter собирает параметры отчета и формат ответа из query-параметров и заголовка Accept
+func parseReportFilter(r *http.Request) (models.ReportFilter, string, error) {
+	query := r.URL.Query()
+	vErr := &domain.ValidationError{}
+
+	filter := models.ReportFilter{GroupBy: models.ReportGroupDay}
+
+	for _, param := range []struct {
+		name  string
+		value *time.Time
+	}{{"from", &filter.From}, {"to", &filter.To}} {
+		value := query.Get(param.name)
+		if value == "" {
+			vErr.Add(param.name, "is required")
+			continue
+		}
+		t, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			vErr.Add(param.name, "expected format: YYYY-MM-DD")
+			continue
+		}
+		*param.value = t
+	}
+	if !vErr.HasErrors() && filter.To.Before(filter.From) {
+		vErr.Add("to", "must not be before from")
+	}
+
+	if groupBy := query.Get("group_by"); groupBy != "" {
+		switch groupBy {
+		case models.ReportGroupDay, models.ReportGroupWeek, models.ReportGroupMonth, models.ReportGroupTask,
+			models.ReportGroupProject, models.ReportGroupClient, models.ReportGroupTag:
+			filter.GroupBy = groupBy
+		default:
+			vErr.Add("group_by", "must be one of: day, week, month, task, project, client, tag")
+		}
+	}
+
+	filter.ProjectID = queryID(r, "project_id", vErr)
+	filter.ClientID = queryID(r, "client_id", vErr)
+	filter.Tags, filter.TagMatch = parseTagsQuery(r, vErr)
+
+	format := parseFormat(r, vErr)
+
+	if vErr.HasErrors() {
+		return models.ReportFilter{}, "", vErr
+	}
+	return filter, format, nil
+}
+
+// parseFormat выбирает формат ответа: параметр format, а без него - заголовок Accept
+func parseFormat(r *http.Request, vErr *domain.ValidationError) string {
+	format := r.URL.Query().Get("format")
+	switch format {
+	case "":
+		return export.FormatFromAccept(r.Header.Get("Accept"))
+	case export.FormatJSON, export.FormatCSV, export.FormatXLSX:
+	default:
+		vErr.Add("format", "must be one of: json, csv, xlsx")
+	}
+	return format
+}
+
+// writeExport отдает выгрузку файлом filename: run передает строки в row по мере чтения из БД.
+// Пока в ответ ничего не записано, ошибка возвращается обычным JSON-ответом;
+// после начала выгрузки ответ можно только оборвать.
+func writeExport(w http.ResponseWriter, r *http.Request, format, filename string, run func(row func(models.ExportRow) error) error) {
+	resp := &exportResponse{
+		w:           w,
+		contentType: export.ContentType(format),
+		filename:    filename,
+	}
+
+	out, err := export.New(format, resp)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	extendWriteDeadline(w, r)
+
+	err = run(out.WriteRow)
+	if err != nil {
+		out.Abort()
+	} else {
+		err = out.Close()
+	}
+	if err == nil {
+		return
+	}
+
+	if !resp.started {
+		writeError(w, err)
+		return
+	}
+	logger.SugaredLogger().Errorw("Выгрузка прервана", "path", r.URL.Path, "format", format, "error", err)
+}
+
+// exportResponse откладывает заголовки ответа до первой записи и сбрасывает
+// каждую порцию данных клиенту, не дожидаясь конца выгрузки
+type exportResponse struct {
+	w           http.ResponseWriter
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (e *exportResponse) Write(p []byte) (int, error) {
+	if !e.started {
+		e.started = true
+		e.w.Header().Set("Content-Type", e.contentType)
+		e.w.Header().Set("Content-Disposition", `attachment; filename="`+e.filename+`"`)
+		e.w.WriteHeader(http.StatusOK)
+	}
+
+	n, err := e.w.Write(p)
+	if err != nil {
+		return n, err
+	}
+	if err := http.NewResponseController(e.w).Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return n, err
+	}
+	return n, nil
+}
+
+// extendWriteDeadline продлевает SERVER_WRITE_TIMEOUT до дедлайна контекста запроса (EXPORT_TIMEOUT),
+// чтобы сервер не оборвал выгрузку, которую еще разрешено писать. Без дедлайна снимает ограничение.
+func extendWriteDeadline(w http.ResponseWriter, r *http.Request) {
+	deadline, _ := r.Context().Deadline()
+	err := http.NewResponseController(w).SetWriteDeadline(deadline)
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		logger.SugaredLogger().Warnw("Не удалось продлить таймаут записи ответа", "error", err)
+	}
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/models"
+	"time-tracker/internal/validator"
+)
+
+// decodeJSON декодирует тело запроса, запрещая неизвестные поля
+func decodeJSON(r *http.Request, v interface{}) error {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("%w: %v", errInvalidBody, err)
+	}
+	return nil
+}
+
+// writeJSON отправляет ответ 200 с телом в формате JSON
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	writeJSONStatus(w, http.StatusOK, v)
+}
+
+// writeJSONStatus отправляет ответ status с телом в формате JSON
+func writeJSONStatus(w http.ResponseWriter, status int, v interface{}) {
+	res, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(res)
+}
+
+// urlParamInt достает из пути целочисленный параметр
+func urlParamInt(r *http.Request, name string) (int, error) {
+	value, err := strconv.Atoi(chi.URLParam(r, name))
+	if err != nil {
+		return 0, domain.NewValidationError(name, "must be an integer")
+	}
+	return value, nil
+}
+
+// queryID разбирает необязательный положительный ID из query-параметра; 0 - параметр не передан
+func queryID(r *http.Request, name string, vErr *domain.ValidationError) int {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0
+	}
+	id, err := strconv.Atoi(value)
+	if err != nil || id < 1 {
+		vErr.Add(name, "must be a positive integer")
+		return 0
+	}
+	return id
+}
+
+// parseTagsQuery разбирает фильтр по тегам: tags - теги через запятую, tag_match - any (по умолчанию) или all
+func parseTagsQuery(r *http.Request, vErr *domain.ValidationError) ([]string, string) {
+	query := r.URL.Query()
+
+	tagMatch := query.Get("tag_match")
+	switch tagMatch {
+	case "":
+		tagMatch = models.TagMatchAny
+	case models.TagMatchAny, models.TagMatchAll:
+	default:
+		vErr.Add("tag_match", "must be any or all")
+	}
+
+	value := query.Get("tags")
+	if value == "" {
+		return nil, ""
+	}
+	tags, err := validator.NormalizeTags("tags", strings.Split(value, ","))
+	var tagsErr *domain.ValidationError
+	if errors.As(err, &tagsErr) {
+		for field, message := range tagsErr.Fields {
+			vErr.Add(field, message)
+		}
+		return nil, ""
+	}
+	return tags, tagMatch
+}
+
+// parseTimeQuery разбирает момент времени в формате RFC3339 или дату в формате ГГГГ-ММ-ДД
+// из query-параметра. dateOnly - передана дата без времени: ее границы зависят от часового пояса
+// пользователя и считаются в хранилище.
+func parseTimeQuery(value string) (t time.Time, dateOnly bool, err error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), false, nil
+	}
+	t, err = time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, false, errors.New("expected format: YYYY-MM-DD or RFC3339")
+	}
+	return t, true, nil
+}
+
+// parsePeriod разбирает границы периода в формате RFC3339 и проверяет, что конец позже начала
+func parsePeriod(startValue, endValue string) (time.Time, time.Time, error) {
+	vErr := &domain.ValidationError{}
+
+	start, err := time.Parse(time.RFC3339, startValue)
+	if err != nil {
+		vErr.Add("start", "expected format: RFC3339")
+	}
+	end, err := time.Parse(time.RFC3339, endValue)
+	if err != nil {
+		vErr.Add("end", "expected format: RFC3339")
+	}
+	if !vErr.HasErrors() && !end.After(start) {
+		vErr.Add("end", "must be after start")
+	}
+
+	if vErr.HasErrors() {
+		return time.Time{}, time.Time{}, vErr
+	}
+	return start, end, nil
+}
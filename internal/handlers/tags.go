@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"time-tracker/internal/models"
+	"time-tracker/internal/usecase"
+	"time-tracker/internal/validator"
+)
+
+// @Summary Список тегов
+// @Description Возвращает все теги по алфавиту.
+// @Tags Tags
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.Tag "Теги"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /tags [get]
+func HandlerListTags(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodGet {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	tags, err := useCase.UseCaseListTags(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, tags)
+}
+
+// @Summary Добавление тегов задаче
+// @Description Добавляет задаче теги; отсутствующие теги создаются. Теги приводятся к нижнему регистру, запятая в теге запрещена.
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param taskID path int true "ID задачи"
+// @Param body body models.TaskTags true "Теги (не длиннее 50 символов)"
+// @Success 200 {object} models.TaskTags "Все теги задачи"
+// @Failure 400 {object} models.ErrorResponse "Ошибка декодирования тела запроса"
+// @Failure 404 {object} models.ErrorResponse "Задача не найдена"
+// @Failure 422 {object} models.ErrorResponse "Ошибка Task ID или некорректный тег"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /task/tags/{taskID} [post]
+func HandlerAddTaskTags(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodPost {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	taskID, err := urlParamInt(r, "taskID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var body models.TaskTags
+	if err := decodeJSON(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	tags, err := validator.NormalizeTags("tags", body.Tags)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	tags, err = useCase.UseCaseAddTaskTags(r.Context(), taskID, tags)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, models.TaskTags{Tags: tags})
+}
+
+// @Summary Снятие тега с задачи
+// @Description Снимает тег с задачи. Если тега у задачи нет, ничего не меняется. Сам тег остается в списке тегов.
+// @Tags Tags
+// @Produce json
+// @Security BearerAuth
+// @Param taskID path int true "ID задачи"
+// @Param tag path string true "Тег"
+// @Success 200 {object} models.TaskTags "Оставшиеся теги задачи"
+// @Failure 404 {object} models.ErrorResponse "Задача не найдена"
+// @Failure 422 {object} models.ErrorResponse "Ошибка Task ID или некорректный тег"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /task/tags/{taskID}/{tag} [delete]
+func HandlerRemoveTaskTag(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodDelete {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	taskID, err := urlParamInt(r, "taskID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	tags, err := validator.NormalizeTags("tag", []string{chi.URLParam(r, "tag")})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	tags, err = useCase.UseCaseRemoveTaskTag(r.Context(), taskID, tags[0])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, models.TaskTags{Tags: tags})
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"net/http"
+	"time-tracker/internal/models"
+	"time-tracker/internal/usecase"
+)
+
+// @Summary Текущий таймер пользователя
+// @Description Возвращает запущенную задачу пользователя и сколько длится текущий интервал работы над ней.
+// @Description Если политика одного таймера выключена и запущено несколько задач, возвращается последняя запущенная.
+// @Tags Timer
+// @Produce json
+// @Security BearerAuth
+// @Param userID path int true "ID пользователя"
+// @Success 200 {object} models.Timer "Текущий таймер (running=false, если ни одна задача не запущена)"
+// @Failure 404 {object} models.ErrorResponse "Пользователь не найден"
+// @Failure 422 {object} models.ErrorResponse "Ошибка конвертирования UserID"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /users/{userID}/timer [get]
+func HandlerGetTimer(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodGet {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	userID, err := urlParamInt(r, "userID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	timer, err := useCase.UseCaseGetActiveTimer(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, timer)
+}
+
+// @Summary Настройки пользователя
+// @Description Возвращает настройки учета времени пользователя.
+// @Tags Timer
+// @Produce json
+// @Security BearerAuth
+// @Param userID path int true "ID пользователя"
+// @Success 200 {object} models.UserSettings "Настройки пользователя"
+// @Failure 404 {object} models.ErrorResponse "Пользователь не найден"
+// @Failure 422 {object} models.ErrorResponse "Ошибка конвертирования UserID"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /users/{userID}/settings [get]
+func HandlerGetUserSettings(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodGet {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	userID, err := urlParamInt(r, "userID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	settings, err := useCase.UseCaseReadUserSettings(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, settings)
+}
+
+// @Summary Изменение настроек пользователя
+// @Description Меняет переданные настройки, остальные остаются прежними.
+// @Description single_timer=true включает политику одного таймера: при старте или возобновлении задачи остальные запущенные задачи пользователя завершаются.
+// @Description time_zone - часовой пояс для отчетов в формате базы tz (например Europe/Moscow), по умолчанию UTC.
+// @Tags Timer
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userID path int true "ID пользователя"
+// @Param body body models.UserSettingsUpdate true "Новые значения настроек"
+// @Success 200 {object} models.UserSettings "Настройки после изменения"
+// @Failure 400 {object} models.ErrorResponse "Ошибка декодирования тела запроса"
+// @Failure 404 {object} models.ErrorResponse "Пользователь не найден"
+// @Failure 422 {object} models.ErrorResponse "Ошибка конвертирования UserID или неизвестный часовой пояс"
+// @Failure 500 {object} models.ErrorResponse "Ошибка сервера"
+// @Router /users/{userID}/settings [patch]
+func HandlerUpdateUserSettings(w http.ResponseWriter, r *http.Request, useCase usecase.UseCaseStorage) {
+	if r.Method != http.MethodPatch {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	userID, err := urlParamInt(r, "userID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var update models.UserSettingsUpdate
+	if err := decodeJSON(r, &update); err != nil {
+		writeError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	settings, err := useCase.UseCaseUpdateUserSettings(r.Context(), userID, update)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, settings)
+}
@@ -0,0 +1,95 @@
+// Package ical формирует календарь в формате iCalendar (RFC 5545)
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ContentType - тип ответа с календарем
+const ContentType = "text/calendar; charset=utf-8"
+
+// формат даты и времени в UTC
+const timeLayout = "20060102T150405Z"
+
+// длина строки в октетах, после которой строка переносится
+const maxLineLength = 75
+
+// Event - событие календаря (VEVENT)
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	End         time.Time
+	Stamp       time.Time
+}
+
+// Writer пишет календарь по одному событию. Данные буферизуются, поэтому
+// до первого сброса буфера в w ничего не попадает.
+type Writer struct {
+	w *bufio.Writer
+}
+
+// NewWriter начинает календарь с названием name
+func NewWriter(w io.Writer, name string) *Writer {
+	c := &Writer{w: bufio.NewWriter(w)}
+	c.line("BEGIN:VCALENDAR")
+	c.line("VERSION:2.0")
+	c.line("PRODID:-//time-tracker//RU")
+	c.line("CALSCALE:GREGORIAN")
+	c.line("METHOD:PUBLISH")
+	c.line("X-WR-CALNAME:" + escapeText(name))
+	return c
+}
+
+func (c *Writer) WriteEvent(e Event) error {
+	c.line("BEGIN:VEVENT")
+	c.line("UID:" + escapeText(e.UID))
+	c.line("DTSTAMP:" + e.Stamp.UTC().Format(timeLayout))
+	c.line("DTSTART:" + e.Start.UTC().Format(timeLayout))
+	c.line("DTEND:" + e.End.UTC().Format(timeLayout))
+	c.line("SUMMARY:" + escapeText(e.Summary))
+	if e.Description != "" {
+		c.line("DESCRIPTION:" + escapeText(e.Description))
+	}
+	c.line("TRANSP:TRANSPARENT")
+	return c.line("END:VEVENT")
+}
+
+// Close завершает календарь и сбрасывает буфер в w
+func (c *Writer) Close() error {
+	if err := c.line("END:VCALENDAR"); err != nil {
+		return err
+	}
+	return c.w.Flush()
+}
+
+// line пишет строку содержимого, перенося её по 75 октетов без разрыва символов UTF-8.
+// Продолжение строки начинается с пробела. Ошибка записи запоминается в bufio.Writer,
+// поэтому достаточно вернуть её из последней строки.
+func (c *Writer) line(s string) error {
+	limit := maxLineLength
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		c.w.WriteString(s[:cut])
+		c.w.WriteString("\r\n ")
+		s = s[cut:]
+		// пробел в начале продолжения тоже занимает октет
+		limit = maxLineLength - 1
+	}
+	c.w.WriteString(s)
+	_, err := c.w.WriteString("\r\n")
+	return err
+}
+
+// escapeText экранирует значение типа TEXT
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
@@ -0,0 +1,70 @@
+package ical
+
+import (
+	"bufio"
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	c := NewWriter(buf, "Учет времени")
+
+	moscow := time.FixedZone("MSK", 3*60*60)
+	require.NoError(t, c.WriteEvent(Event{
+		UID:         "task-7@time-tracker",
+		Summary:     "Отчет; квартал, итоги",
+		Description: "строка 1\nстрока 2",
+		Start:       time.Date(2024, 1, 10, 12, 0, 0, 0, moscow),
+		End:         time.Date(2024, 1, 10, 13, 30, 0, 0, moscow),
+		Stamp:       time.Date(2024, 1, 10, 10, 30, 0, 0, time.UTC),
+	}))
+	require.NoError(t, c.Close())
+
+	want := "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:-//time-tracker//RU\r\n" +
+		"CALSCALE:GREGORIAN\r\n" +
+		"METHOD:PUBLISH\r\n" +
+		"X-WR-CALNAME:Учет времени\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:task-7@time-tracker\r\n" +
+		"DTSTAMP:20240110T103000Z\r\n" +
+		"DTSTART:20240110T090000Z\r\n" +
+		"DTEND:20240110T103000Z\r\n" +
+		"SUMMARY:Отчет\\; квартал\\, итоги\r\n" +
+		"DESCRIPTION:строка 1\\nстрока 2\r\n" +
+		"TRANSP:TRANSPARENT\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+	assert.Equal(t, want, buf.String())
+}
+
+func TestLineFolding(t *testing.T) {
+	buf := &bytes.Buffer{}
+	c := &Writer{w: bufio.NewWriter(buf)}
+
+	summary := "SUMMARY:" + strings.Repeat("задача ", 30)
+	require.NoError(t, c.line(summary))
+	require.NoError(t, c.w.Flush())
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	require.Greater(t, len(lines), 1)
+
+	var unfolded strings.Builder
+	for i, line := range lines {
+		assert.LessOrEqual(t, len(line), maxLineLength)
+		assert.True(t, utf8.ValidString(line), "строка %d разорвана посреди символа", i)
+		if i > 0 {
+			require.True(t, strings.HasPrefix(line, " "))
+			line = line[1:]
+		}
+		unfolded.WriteString(line)
+	}
+	assert.Equal(t, summary, unfolded.String())
+}
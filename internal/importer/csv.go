@@ -0,0 +1,141 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/models"
+)
+
+// названия колонок CSV (без учета регистра), в том числе из выгрузки самого трекера
+var csvColumns = map[string][]string{
+	"name":  {"name_task", "name", "task", "description", "задача", "название"},
+	"start": {"start", "start_time", "начало"},
+	"end":   {"end", "end_time", "окончание"},
+	"id":    {"external_id", "uid", "id"},
+}
+
+// форматы времени в CSV; время без часового пояса считается временем пользователя
+var csvTimeLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+}
+
+// parseCSV разбирает CSV с заголовком. Нужны колонки названия, начала и окончания,
+// колонка идентификатора необязательна. Разделитель - запятая или точка с запятой.
+func (p *parser) parseCSV(r io.Reader, loc *time.Location) {
+	br := bufio.NewReader(r)
+	first, err := br.Peek(br.Size())
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		p.err = domain.NewValidationError("file", err.Error())
+		return
+	}
+	firstLine, _, _ := strings.Cut(string(first), "\n")
+
+	reader := csv.NewReader(br)
+	reader.FieldsPerRecord = -1
+	if strings.Count(firstLine, ";") > strings.Count(firstLine, ",") {
+		reader.Comma = ';'
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = errors.New("file is empty")
+		}
+		p.err = domain.NewValidationError("file", err.Error())
+		return
+	}
+
+	columns, err := csvHeader(header)
+	if err != nil {
+		p.err = domain.NewValidationError("file", err.Error())
+		return
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			p.err = domain.NewValidationError("file", err.Error())
+			return
+		}
+		line, _ := reader.FieldPos(0)
+
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		entry := models.ImportEntry{
+			Line:       line,
+			ExternalID: field("id"),
+			NameTask:   field("name"),
+		}
+		if entry.Start, err = parseCSVTime(field("start"), loc); err != nil {
+			p.invalid(entry, "начало: "+err.Error())
+			continue
+		}
+		if entry.End, err = parseCSVTime(field("end"), loc); err != nil {
+			p.invalid(entry, "окончание: "+err.Error())
+			continue
+		}
+		p.add(entry)
+	}
+}
+
+// csvHeader находит номера нужных колонок по заголовку
+func csvHeader(header []string) (map[string]int, error) {
+	columns := map[string]int{}
+	for i, title := range header {
+		title = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(title, "\ufeff")))
+		for column, names := range csvColumns {
+			if _, ok := columns[column]; ok {
+				continue
+			}
+			for _, name := range names {
+				if title == name {
+					columns[column] = i
+				}
+			}
+		}
+	}
+
+	var missing []string
+	for _, column := range []string{"name", "start", "end"} {
+		if _, ok := columns[column]; !ok {
+			missing = append(missing, csvColumns[column][0])
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing columns: %s", strings.Join(missing, ", "))
+	}
+	return columns, nil
+}
+
+func parseCSVTime(value string, loc *time.Location) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, errors.New("не указано")
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range csvTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("неверный формат времени %q (ожидается RFC3339 или ГГГГ-ММ-ДД ЧЧ:ММ:СС)", value)
+}
@@ -0,0 +1,207 @@
+package importer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/models"
+)
+
+// максимальная длина строки iCalendar после склейки переносов
+const maxICSLineLength = 1 << 20
+
+// icsLine - строка содержимого iCalendar: NAME;PARAM=VALUE:VALUE
+type icsLine struct {
+	num    int
+	name   string
+	params map[string]string
+	value  string
+}
+
+// icsEvent - свойства одного VEVENT
+type icsEvent struct {
+	line  int
+	props map[string]icsLine
+}
+
+func (p *parser) parseICS(r io.Reader, loc *time.Location) {
+	lines, err := readICSLines(r)
+	if err != nil {
+		p.err = domain.NewValidationError("file", err.Error())
+		return
+	}
+	if len(lines) == 0 || lines[0].name != "BEGIN" || !strings.EqualFold(lines[0].value, "VCALENDAR") {
+		p.err = domain.NewValidationError("file", "not an iCalendar file")
+		return
+	}
+
+	// вложенные компоненты события (VALARM) пропускаются, чтобы их свойства не перетирали свойства события
+	var event *icsEvent
+	depth := 0
+	for _, line := range lines {
+		switch {
+		case line.name == "BEGIN" && strings.EqualFold(line.value, "VEVENT") && event == nil:
+			event = &icsEvent{line: line.num, props: map[string]icsLine{}}
+		case event == nil:
+		case line.name == "BEGIN":
+			depth++
+		case line.name == "END" && depth > 0:
+			depth--
+		case line.name == "END" && strings.EqualFold(line.value, "VEVENT"):
+			p.addICSEvent(event, loc)
+			event = nil
+		case depth == 0:
+			if _, ok := event.props[line.name]; !ok {
+				event.props[line.name] = line
+			}
+		}
+	}
+}
+
+func (p *parser) addICSEvent(event *icsEvent, loc *time.Location) {
+	entry := models.ImportEntry{
+		Line:       event.line,
+		ExternalID: event.props["UID"].value,
+		NameTask:   unescapeText(event.props["SUMMARY"].value),
+	}
+	// экземпляр повторяющегося события имеет тот же UID, что и само событие
+	if recurrence, ok := event.props["RECURRENCE-ID"]; ok && entry.ExternalID != "" {
+		entry.ExternalID += "/" + recurrence.value
+	}
+
+	if strings.EqualFold(event.props["STATUS"].value, "CANCELLED") {
+		return
+	}
+	if _, ok := event.props["RRULE"]; ok {
+		p.invalid(entry, "повторяющиеся события не поддерживаются")
+		return
+	}
+
+	start, ok := event.props["DTSTART"]
+	if !ok {
+		p.invalid(entry, "нет времени начала (DTSTART)")
+		return
+	}
+	end, ok := event.props["DTEND"]
+	if !ok {
+		p.invalid(entry, "нет времени окончания (DTEND)")
+		return
+	}
+
+	var err error
+	if entry.Start, err = parseICSTime(start, loc); err != nil {
+		p.invalid(entry, "DTSTART: "+err.Error())
+		return
+	}
+	if entry.End, err = parseICSTime(end, loc); err != nil {
+		p.invalid(entry, "DTEND: "+err.Error())
+		return
+	}
+	p.add(entry)
+}
+
+// parseICSTime разбирает DATE-TIME в UTC, с TZID или "плавающее" (в часовом поясе loc).
+// События на весь день (VALUE=DATE) не импортируются: у них нет времени работы.
+func parseICSTime(line icsLine, loc *time.Location) (time.Time, error) {
+	if strings.EqualFold(line.params["VALUE"], "DATE") || len(line.value) == len("20060102") {
+		return time.Time{}, errors.New("события на весь день не поддерживаются")
+	}
+
+	if strings.HasSuffix(line.value, "Z") {
+		t, err := time.Parse("20060102T150405Z", line.value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("неверный формат времени %q", line.value)
+		}
+		return t, nil
+	}
+
+	if tzid := line.params["TZID"]; tzid != "" {
+		// неизвестные базе часовых поясов идентификаторы (например, имена Windows) считаем поясом пользователя
+		if tz, err := time.LoadLocation(strings.TrimPrefix(tzid, "/")); err == nil {
+			loc = tz
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", line.value, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("неверный формат времени %q", line.value)
+	}
+	return t, nil
+}
+
+// readICSLines читает строки содержимого, склеивая перенесенные строки (RFC 5545, 3.1)
+func readICSLines(r io.Reader) ([]icsLine, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxICSLineLength)
+
+	var raw []string
+	var nums []int
+	for num := 1; scanner.Scan(); num++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if num == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		if (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) && len(raw) > 0 {
+			raw[len(raw)-1] += text[1:]
+			continue
+		}
+		if text == "" {
+			continue
+		}
+		raw = append(raw, text)
+		nums = append(nums, num)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	lines := make([]icsLine, 0, len(raw))
+	for i, text := range raw {
+		line, ok := parseICSLine(text)
+		if !ok {
+			continue
+		}
+		line.num = nums[i]
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// parseICSLine разбирает строку на имя, параметры и значение. Двоеточие внутри кавычек
+// относится к значению параметра.
+func parseICSLine(text string) (icsLine, bool) {
+	colon := -1
+	quoted := false
+	for i, c := range text {
+		if c == '"' {
+			quoted = !quoted
+		}
+		if c == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon <= 0 {
+		return icsLine{}, false
+	}
+
+	parts := strings.Split(text[:colon], ";")
+	line := icsLine{
+		name:   strings.ToUpper(parts[0]),
+		params: map[string]string{},
+		value:  text[colon+1:],
+	}
+	for _, param := range parts[1:] {
+		name, value, _ := strings.Cut(param, "=")
+		line.params[strings.ToUpper(name)] = strings.Trim(value, `"`)
+	}
+	return line, true
+}
+
+// unescapeText снимает экранирование значения типа TEXT
+func unescapeText(s string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(s)
+}
@@ -0,0 +1,95 @@
+// Package importer разбирает выгрузки других трекеров (iCalendar и CSV) в записи с явным
+// временем начала и окончания
+package importer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"mime"
+	"path/filepath"
+	"strings"
+	"time"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/models"
+	"time-tracker/internal/validator"
+)
+
+// форматы импорта
+const (
+	FormatICS = "ics"
+	FormatCSV = "csv"
+)
+
+// Parse разбирает файл формата format. Время без часового пояса считается временем в loc.
+// Записи, которые не удалось разобрать, возвращаются описаниями проблем, а не ошибкой;
+// ошибка валидации означает, что файл целиком не читается.
+func Parse(format string, r io.Reader, loc *time.Location) ([]models.ImportEntry, []models.ImportIssue, error) {
+	var p parser
+	switch format {
+	case FormatICS:
+		p.parseICS(r, loc)
+	case FormatCSV:
+		p.parseCSV(r, loc)
+	default:
+		return nil, nil, domain.NewValidationError("format", "must be one of: ics, csv")
+	}
+	return p.entries, p.issues, p.err
+}
+
+// FormatFromContentType выбирает формат по Content-Type или расширению файла.
+// Пустая строка - формат не распознан.
+func FormatFromContentType(contentType, filename string) string {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		switch mediaType {
+		case "text/calendar":
+			return FormatICS
+		case "text/csv":
+			return FormatCSV
+		}
+	}
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".ics", ".ical":
+		return FormatICS
+	case ".csv":
+		return FormatCSV
+	}
+	return ""
+}
+
+type parser struct {
+	entries []models.ImportEntry
+	issues  []models.ImportIssue
+	err     error
+}
+
+// add проверяет запись и добавляет ее в результат. Если во внешнем трекере у записи нет
+// идентификатора, он строится из названия и времени, чтобы повторный импорт того же файла
+// находил уже загруженные записи.
+func (p *parser) add(entry models.ImportEntry) {
+	if entry.ExternalID == "" {
+		sum := sha256.Sum256([]byte(entry.NameTask + "\x00" +
+			entry.Start.UTC().Format(time.RFC3339) + "\x00" + entry.End.UTC().Format(time.RFC3339)))
+		entry.ExternalID = "sha256:" + hex.EncodeToString(sum[:])
+	}
+
+	if err := validator.ValidateTaskName("name_task", entry.NameTask); err != nil {
+		p.invalid(entry, err.Error())
+		return
+	}
+	if !entry.End.After(entry.Start) {
+		p.invalid(entry, "окончание должно быть позже начала")
+		return
+	}
+	p.entries = append(p.entries, entry)
+}
+
+func (p *parser) invalid(entry models.ImportEntry, message string) {
+	p.issues = append(p.issues, models.ImportIssue{
+		Line:       entry.Line,
+		ExternalID: entry.ExternalID,
+		NameTask:   entry.NameTask,
+		Reason:     models.ImportIssueInvalid,
+		Message:    message,
+	})
+}
@@ -0,0 +1,187 @@
+package importer
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/models"
+)
+
+var moscow = time.FixedZone("MSK", 3*60*60)
+
+const testICS = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:task-7@time-tracker\r\n" +
+	"DTSTART:20240110T090000Z\r\n" +
+	"DTEND:20240110T103000Z\r\n" +
+	"SUMMARY:Отчет\\; квартал\\, ито\r\n" +
+	" ги\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"DESCRIPTION:напоминание\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:meeting\r\n" +
+	"DTSTART;TZID=Europe/Moscow:20240111T120000\r\n" +
+	"DTEND;TZID=Europe/Moscow:20240111T130000\r\n" +
+	"SUMMARY:Созвон\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART:20240112T090000\r\n" +
+	"DTEND:20240112T100000\r\n" +
+	"SUMMARY:Без UID\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:holiday\r\n" +
+	"DTSTART;VALUE=DATE:20240113\r\n" +
+	"DTEND;VALUE=DATE:20240114\r\n" +
+	"SUMMARY:Выходной\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:cancelled\r\n" +
+	"STATUS:CANCELLED\r\n" +
+	"DTSTART:20240114T090000Z\r\n" +
+	"DTEND:20240114T100000Z\r\n" +
+	"SUMMARY:Отменено\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:open\r\n" +
+	"DTSTART:20240115T090000Z\r\n" +
+	"SUMMARY:Без окончания\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseICS(t *testing.T) {
+	entries, issues, err := Parse(FormatICS, strings.NewReader(testICS), moscow)
+	require.NoError(t, err)
+
+	require.Len(t, entries, 3)
+	assert.Equal(t, models.ImportEntry{
+		Line:       3,
+		ExternalID: "task-7@time-tracker",
+		NameTask:   "Отчет; квартал, итоги",
+		Start:      time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC),
+		End:        time.Date(2024, 1, 10, 10, 30, 0, 0, time.UTC),
+	}, entries[0])
+
+	assert.Equal(t, "meeting", entries[1].ExternalID)
+	assert.True(t, entries[1].Start.Equal(time.Date(2024, 1, 11, 9, 0, 0, 0, time.UTC)))
+
+	// время без часового пояса - время пользователя, идентификатор строится из содержимого
+	assert.True(t, entries[2].Start.Equal(time.Date(2024, 1, 12, 6, 0, 0, 0, time.UTC)))
+	assert.True(t, strings.HasPrefix(entries[2].ExternalID, "sha256:"))
+
+	require.Len(t, issues, 2)
+	assert.Equal(t, "holiday", issues[0].ExternalID)
+	assert.Equal(t, models.ImportIssueInvalid, issues[0].Reason)
+	assert.Equal(t, "open", issues[1].ExternalID)
+	assert.Contains(t, issues[1].Message, "DTEND")
+
+	// повторный разбор дает те же идентификаторы, по ним импорт находит дубликаты
+	again, _, err := Parse(FormatICS, strings.NewReader(testICS), moscow)
+	require.NoError(t, err)
+	assert.Equal(t, entries, again)
+}
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string
+		wantEntries []models.ImportEntry
+		wantIssues  []string
+		wantErr     bool
+	}{
+		{
+			name: "#1 Запятая, RFC3339 и external_id",
+			file: "external_id,name_task,start,end\n" +
+				"a1,Отчет,2024-01-10T09:00:00Z,2024-01-10T10:30:00Z\n",
+			wantEntries: []models.ImportEntry{{
+				Line:       2,
+				ExternalID: "a1",
+				NameTask:   "Отчет",
+				Start:      time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC),
+				End:        time.Date(2024, 1, 10, 10, 30, 0, 0, time.UTC),
+			}},
+		},
+		{
+			name: "#2 Точка с запятой и время пользователя",
+			file: "Задача;Начало;Окончание\n" +
+				"Созвон;2024-01-10 12:00;2024-01-10 13:00\n",
+			wantEntries: []models.ImportEntry{{
+				Line:     2,
+				NameTask: "Созвон",
+				Start:    time.Date(2024, 1, 10, 12, 0, 0, 0, moscow),
+				End:      time.Date(2024, 1, 10, 13, 0, 0, 0, moscow),
+			}},
+		},
+		{
+			name: "#3 Выгрузка самого трекера",
+			file: "Пользователь,ID задачи,Задача,Проект,Клиент,Начало,Окончание,\"Длительность, сек\"\n" +
+				"Иванов Иван Иванович,1,Отчет,Сайт,ООО Ромашка,2024-01-10 09:00:00,2024-01-10 10:30:00,5400\n" +
+				"Иванов Иван Иванович,2,Созвон,,,2024-01-10 11:00:00,,600\n" +
+				"Иванов Иван Иванович,3,Ревью,,,2024-01-10 12:00:00,2024-01-10 11:00:00,0\n",
+			wantEntries: []models.ImportEntry{{
+				Line:     2,
+				NameTask: "Отчет",
+				Start:    time.Date(2024, 1, 10, 9, 0, 0, 0, moscow),
+				End:      time.Date(2024, 1, 10, 10, 30, 0, 0, moscow),
+			}},
+			wantIssues: []string{"окончание: не указано", "окончание должно быть позже начала"},
+		},
+		{
+			name:    "#4 Нет обязательных колонок",
+			file:    "name,start\nОтчет,2024-01-10T09:00:00Z\n",
+			wantErr: true,
+		},
+		{
+			name:    "#5 Пустой файл",
+			file:    "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, issues, err := Parse(FormatCSV, strings.NewReader(tt.file), moscow)
+			if tt.wantErr {
+				var vErr *domain.ValidationError
+				assert.True(t, errors.As(err, &vErr))
+				return
+			}
+			require.NoError(t, err)
+
+			require.Len(t, entries, len(tt.wantEntries))
+			for i, want := range tt.wantEntries {
+				got := entries[i]
+				if want.ExternalID == "" {
+					assert.True(t, strings.HasPrefix(got.ExternalID, "sha256:"))
+					want.ExternalID = got.ExternalID
+				}
+				assert.Equal(t, want.Line, got.Line)
+				assert.Equal(t, want.ExternalID, got.ExternalID)
+				assert.Equal(t, want.NameTask, got.NameTask)
+				assert.True(t, want.Start.Equal(got.Start), "start %s", got.Start)
+				assert.True(t, want.End.Equal(got.End), "end %s", got.End)
+			}
+
+			require.Len(t, issues, len(tt.wantIssues))
+			for i, message := range tt.wantIssues {
+				assert.Equal(t, models.ImportIssueInvalid, issues[i].Reason)
+				assert.Equal(t, message, issues[i].Message)
+			}
+		})
+	}
+}
+
+func TestFormatFromContentType(t *testing.T) {
+	assert.Equal(t, FormatICS, FormatFromContentType("text/calendar; charset=utf-8", ""))
+	assert.Equal(t, FormatCSV, FormatFromContentType("text/csv", ""))
+	assert.Equal(t, FormatICS, FormatFromContentType("", "export.ICS"))
+	assert.Equal(t, FormatCSV, FormatFromContentType("application/octet-stream", "export.csv"))
+	assert.Equal(t, "", FormatFromContentType("application/json", "export.json"))
+}
@@ -30,12 +30,18 @@ func (r *loggingResponseWriter) WriteHeader(statusCode int) {
 	r.responseData.status = statusCode
 }
 
+// Unwrap отдает исходный http.ResponseWriter для http.ResponseController (Flush при потоковой выдаче)
+func (r *loggingResponseWriter) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
 var sugar *zap.SugaredLogger
 
+// InitLogger настраивает логгер, пишущий в stdout и, если задан logFile, дополнительно в этот файл
 func InitLogger(logFile string) error {
 	// Настройка конфигурации логгера
 	cfg := zap.NewDevelopmentConfig()
-	if logFile == "" {
+	if logFile == "" || logFile == "stdout" {
 		cfg.OutputPaths = []string{"stdout"}
 	} else {
 		cfg.OutputPaths = []string{logFile, "stdout"}
@@ -61,6 +67,16 @@ func SugaredLogger() *zap.SugaredLogger {
 	return sugar
 }
 
+// redactURI скрывает секретный токен из query-параметров, чтобы он не попадал в логи
+func redactURI(r *http.Request) string {
+	query := r.URL.Query()
+	if !query.Has("token") {
+		return r.RequestURI
+	}
+	query.Set("token", "***")
+	return r.URL.Path + "?" + query.Encode()
+}
+
 func WithLogging(h http.Handler) http.Handler {
 	logFn := func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
@@ -79,7 +95,7 @@ func WithLogging(h http.Handler) http.Handler {
 		duration := time.Since(start)
 
 		sugar.Infoln(
-			"uri", r.RequestURI,
+			"uri", redactURI(r),
 			"method", r.Method,
 			"status", responseData.status,
 			"duration", duration,
@@ -0,0 +1,201 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const namespace = "time_tracker"
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Количество HTTP запросов по шаблону маршрута, методу и статусу.",
+	}, []string{"route", "method", "status"})
+
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Время обработки HTTP запросов по шаблону маршрута, методу и статусу.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	enrichmentDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "enrichment_api_request_duration_seconds",
+		Help:      "Время запросов к стороннему API обогащения данных пользователя.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"result"})
+
+	enrichmentErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "enrichment_api_errors_total",
+		Help:      "Количество ошибок запросов к стороннему API обогащения данных по причине.",
+	}, []string{"reason"})
+
+	enrichmentRetries = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "enrichment_api_retries_total",
+		Help:      "Количество повторных запросов к стороннему API обогащения данных.",
+	})
+
+	enrichmentJobs = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "enrichment_jobs_total",
+		Help:      "Количество обработанных задач очереди обогащения по результату: done, retry, failed.",
+	}, []string{"result"})
+
+	enrichmentCircuitState = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "enrichment_api_circuit_state",
+		Help:      "Состояние circuit breaker стороннего API: 0 - закрыт, 1 - полуоткрыт, 2 - открыт.",
+	})
+)
+
+// причины ошибок стороннего API для enrichment_api_errors_total
+const (
+	EnrichmentErrorRequest = "request"
+	EnrichmentErrorStatus  = "status"
+	EnrichmentErrorDecode  = "decode"
+	// запрос не отправлялся, так как circuit breaker открыт
+	EnrichmentErrorCircuitOpen = "circuit_open"
+)
+
+// EnrichmentRetry учитывает повторный запрос к стороннему API
+func EnrichmentRetry() {
+	enrichmentRetries.Inc()
+}
+
+// EnrichmentRejected учитывает запрос, отклоненный открытым circuit breaker без обращения к API
+func EnrichmentRejected() {
+	enrichmentErrors.WithLabelValues(EnrichmentErrorCircuitOpen).Inc()
+}
+
+// результаты задач очереди обогащения для enrichment_jobs_total
+const (
+	EnrichmentJobDone   = "done"
+	EnrichmentJobRetry  = "retry"
+	EnrichmentJobFailed = "failed"
+)
+
+// EnrichmentJob учитывает обработанную задачу очереди обогащения
+func EnrichmentJob(result string) {
+	enrichmentJobs.WithLabelValues(result).Inc()
+}
+
+// SetEnrichmentCircuitState публикует состояние circuit breaker стороннего API
+func SetEnrichmentCircuitState(state int) {
+	enrichmentCircuitState.Set(float64(state))
+}
+
+// ObserveEnrichment учитывает запрос к стороннему API. reason пустой для успешного запроса.
+func ObserveEnrichment(start time.Time, reason string) {
+	result := "success"
+	if reason != "" {
+		result = "error"
+		enrichmentErrors.WithLabelValues(reason).Inc()
+	}
+	enrichmentDuration.WithLabelValues(result).Observe(time.Since(start).Seconds())
+}
+
+// Обёртка для http.ResponseWriter, чтобы узнать статус ответа
+type statusResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusResponseWriter) WriteHeader(statusCode int) {
+	w.status = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Unwrap отдает исходный http.ResponseWriter для http.ResponseController (Flush при потоковой выдаче)
+func (w *statusResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// WithMetrics считает запросы и время их обработки.
+// Маршрут берется из шаблона chi (например /user/{userID}), чтобы не плодить метки по каждому ID.
+func WithMetrics(h http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		sw := &statusResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		h.ServeHTTP(sw, r)
+
+		route := "unmatched"
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			route = rctx.RoutePattern()
+		}
+		status := strconv.Itoa(sw.status)
+
+		httpRequests.WithLabelValues(route, r.Method, status).Inc()
+		httpDuration.WithLabelValues(route, r.Method, status).Observe(time.Since(start).Seconds())
+	}
+	return http.HandlerFunc(fn)
+}
+
+// RegisterDB публикует статистику пула соединений sql.DB
+func RegisterDB(db *sql.DB) {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db, namespace))
+}
+
+// TaskCounter отдает данные для бизнес-метрик по задачам
+type TaskCounter interface {
+	CountRunningTasks(ctx context.Context) (int, error)
+}
+
+// RegisterTaskCounter публикует количество запущенных задач. Значение считается в БД при каждом сборе метрик
+// не дольше timeout; нулевой timeout - без ограничения.
+func RegisterTaskCounter(counter TaskCounter, timeout time.Duration) {
+	prometheus.MustRegister(&tasksCollector{counter: counter, timeout: timeout})
+}
+
+type tasksCollector struct {
+	counter TaskCounter
+	timeout time.Duration
+}
+
+var (
+	tasksRunningDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "tasks_running"),
+		"Количество задач с заполненным start_time и пустым end_time.",
+		nil, nil,
+	)
+	tasksScrapeErrorDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "tasks_scrape_error"),
+		"1, если не удалось получить количество задач из БД при последнем сборе метрик.",
+		nil, nil,
+	)
+)
+
+func (c *tasksCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- tasksRunningDesc
+	ch <- tasksScrapeErrorDesc
+}
+
+func (c *tasksCollector) Collect(ch chan<- prometheus.Metric) {
+	// как и для запросов, нулевое значение отключает ограничение
+	ctx := context.Background()
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	running, err := c.counter.CountRunningTasks(ctx)
+	if err != nil {
+		ch <- prometheus.MustNewConstMetric(tasksScrapeErrorDesc, prometheus.GaugeValue, 1)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(tasksScrapeErrorDesc, prometheus.GaugeValue, 0)
+	ch <- prometheus.MustNewConstMetric(tasksRunningDesc, prometheus.GaugeValue, float64(running))
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"database/sql"
+	"time"
 )
 
 type UserData struct {
@@ -22,12 +23,22 @@ type TaskName struct {
 }
 
 type TaskData struct {
-	TaskID    string        `json:"id"`
-	UserID    string        `json:"user_id"`
-	NameTask  string        `json:"name_task"`
-	StartTime sql.NullTime  `json:"start_time"`
-	EndTime   sql.NullTime  `json:"end_time"`
-	AllTime   sql.NullInt64 `json:"all_time"`
+	TaskID    string         `json:"id"`
+	UserID    string         `json:"user_id"`
+	NameTask  string         `json:"name_task"`
+	StartTime sql.NullTime   `json:"start_time"`
+	EndTime   sql.NullTime   `json:"end_time"`
+	AllTime   sql.NullInt64  `json:"all_time"`
+	IsManual  bool           `json:"is_manual"`
+	ProjectID *int           `json:"project_id"`
+	Intervals []TaskInterval `json:"intervals"`
+}
+
+// TaskInterval - отрезок работы над задачей между стартом/возобновлением и паузой/завершением
+type TaskInterval struct {
+	IntervalID string       `json:"id"`
+	StartTime  time.Time    `json:"start_time"`
+	EndTime    sql.NullTime `json:"end_time"`
 }
 
 type TaskTime struct {
@@ -35,7 +46,400 @@ type TaskTime struct {
 	End   string `json:"end"`
 }
 
+// ManualTask - задача с явно указанными временем начала и окончания (в формате RFC3339)
+type ManualTask struct {
+	Name  string `json:"task_name"`
+	Start string `json:"start" example:"2024-07-01T09:00:00+03:00"`
+	End   string `json:"end" example:"2024-07-01T12:30:00+03:00"`
+}
+
+// TaskPeriod - новые время начала и окончания задачи (в формате RFC3339)
+type TaskPeriod struct {
+	Start string `json:"start" example:"2024-07-01T09:00:00+03:00"`
+	End   string `json:"end" example:"2024-07-01T12:30:00+03:00"`
+}
+
+// Client - клиент, для которого ведутся проекты
+type Client struct {
+	ClientID int    `json:"id"`
+	Name     string `json:"name"`
+}
+
+// ClientRequest - название нового клиента или новое название существующего
+type ClientRequest struct {
+	Name string `json:"name" example:"ООО Ромашка"`
+}
+
+// Project - проект; ClientID пустой у внутренних проектов без клиента
+type Project struct {
+	ProjectID int    `json:"id"`
+	ClientID  *int   `json:"client_id"`
+	Name      string `json:"name"`
+}
+
+// ProjectRequest - данные нового проекта или новые данные существующего
+type ProjectRequest struct {
+	Name     string `json:"name" example:"Сайт"`
+	ClientID *int   `json:"client_id"`
+}
+
+// TaskProject - проект задачи; null снимает задачу с проекта
+type TaskProject struct {
+	ProjectID *int `json:"project_id"`
+}
+
+// Credentials - логин и пароль пользователя
+type Credentials struct {
+	Login    string `json:"login" example:"ivanov"`
+	Password string `json:"password" example:"correct horse battery"`
+}
+
+// RefreshRequest - refresh-токен для обновления пары токенов или выхода
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// TokenResponse - пара токенов: токен доступа передается в заголовке Authorization: Bearer,
+// refresh-токен - в /auth/refresh, когда токен доступа истечет
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type" example:"Bearer"`
+	ExpiresIn    int    `json:"expires_in" example:"900"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RoleRequest - новая роль пользователя
+type RoleRequest struct {
+	Role string `json:"role" example:"manager"`
+}
+
+// Role - роль пользователя с описанием прав
+type Role struct {
+	Name        string `json:"name" example:"manager"`
+	Description string `json:"description"`
+}
+
+// ManagerRequest - менеджер, в команду которого входит пользователь; null - исключить из команды
+type ManagerRequest struct {
+	ManagerID *int `json:"manager_id" example:"1"`
+}
+
+// APIKeyRequest - параметры нового ключа API
+type APIKeyRequest struct {
+	Name      string     `json:"name" example:"ci-bot"`
+	Scopes    []string   `json:"scopes" example:"tasks:write,reports:read"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" example:"2025-01-01T00:00:00Z"`
+}
+
+// APIKey - ключ API без секрета; prefix - начало ключа, чтобы узнать его в списке
+type APIKey struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix" example:"tt_k3Xz9Q"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+// APIKeyCreated - новый ключ API; сам ключ показывается только в ответе на создание
+type APIKeyCreated struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// APIKeyOwner - владелец действующего ключа API и права ключа
+type APIKeyOwner struct {
+	UserID int
+	Role   string
+	Scopes []string
+}
+
+// состояния обогащения данных пользователя через сторонний API
+const (
+	EnrichmentPending = "pending"
+	EnrichmentDone    = "done"
+	EnrichmentFailed  = "failed"
+)
+
+// UserCreated - ответ на добавление пользователя; данные из стороннего API заполняются в фоне
+type UserCreated struct {
+	UserID           int    `json:"UserID" example:"1"`
+	EnrichmentStatus string `json:"enrichment_status" example:"pending"`
+}
+
+// EnrichmentStatus - состояние обогащения данных пользователя. attempts, last_error и
+// next_attempt_at пусты, если обогащение не запускалось (пользователь добавлен через /test или до очереди).
+type EnrichmentStatus struct {
+	UserID        int        `json:"user_id" example:"1"`
+	Status        string     `json:"status" example:"pending"`
+	Attempts      int        `json:"attempts" example:"2"`
+	LastError     string     `json:"last_error,omitempty" example:"ошибка запроса к стороннему API: неправильный статус код API: 503"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+}
+
+// EnrichmentJob - задача очереди обогащения, взятая обработчиком; Attempts включает текущую попытку
+type EnrichmentJob struct {
+	UserID         int
+	PassportNumber string
+	Attempts       int
+}
+
+// UserCredentials - учетные данные пользователя из хранилища
+type UserCredentials struct {
+	UserID       int
+	Role         string
+	PasswordHash string
+}
+
+// Tag - метка задачи (meeting, review, bugfix и т.п.)
+type Tag struct {
+	TagID int    `json:"id"`
+	Name  string `json:"name"`
+}
+
+// TaskTags - теги задачи
+type TaskTags struct {
+	Tags []string `json:"tags" example:"meeting,review"`
+}
+
+// режимы фильтра по тегам: задача с любым из тегов или со всеми тегами
+const (
+	TagMatchAny = "any"
+	TagMatchAll = "all"
+)
+
 type Tasks struct {
 	Name    string `json:"task_name"`
 	AllTime string `json:"all_time"`
 }
+
+// UserSettings - настройки учета времени пользователя
+type UserSettings struct {
+	// SingleTimer - при старте или возобновлении задачи остальные запущенные задачи пользователя завершаются
+	SingleTimer bool `json:"single_timer"`
+	// TimeZone - часовой пояс, в котором строятся отчеты (например Europe/Moscow)
+	TimeZone string `json:"time_zone" example:"Europe/Moscow"`
+}
+
+// UserSettingsUpdate - изменение настроек; поля, которые не переданы, не меняются
+type UserSettingsUpdate struct {
+	SingleTimer *bool   `json:"single_timer,omitempty"`
+	TimeZone    *string `json:"time_zone,omitempty" example:"Europe/Moscow"`
+}
+
+// Timer - текущий таймер пользователя; Task пустой, если ни одна задача не запущена
+type Timer struct {
+	Running bool       `json:"running"`
+	Task    *TimerTask `json:"task,omitempty"`
+}
+
+// TimerTask - запущенная задача: когда открыт текущий интервал и сколько он длится
+type TimerTask struct {
+	TaskID          int       `json:"id"`
+	NameTask        string    `json:"name_task"`
+	RunningSince    time.Time `json:"running_since"`
+	RunningSeconds  int64     `json:"running_seconds"`
+	DurationSeconds int64     `json:"duration_seconds"`
+}
+
+// группировки отчета
+const (
+	ReportGroupDay     = "day"
+	ReportGroupWeek    = "week"
+	ReportGroupMonth   = "month"
+	ReportGroupTask    = "task"
+	ReportGroupProject = "project"
+	ReportGroupClient  = "client"
+	ReportGroupTag     = "tag"
+)
+
+// ReportFilter - период отчета (даты в часовом поясе пользователя, To включительно), группировка
+// и необязательные фильтры по проекту и клиенту (0 - без фильтра) и по тегам (TagMatch - any или all)
+type ReportFilter struct {
+	From      time.Time
+	To        time.Time
+	GroupBy   string
+	ProjectID int
+	ClientID  int
+	Tags      []string
+	TagMatch  string
+}
+
+// Report - время пользователя за период в секундах по группам и в сумме
+type Report struct {
+	UserID       int            `json:"user_id"`
+	From         string         `json:"from" example:"2024-01-01"`
+	To           string         `json:"to" example:"2024-01-31"`
+	TimeZone     string         `json:"time_zone" example:"Europe/Moscow"`
+	GroupBy      string         `json:"group_by" example:"day"`
+	ProjectID    int            `json:"project_id,omitempty"`
+	ClientID     int            `json:"client_id,omitempty"`
+	Tags         []string       `json:"tags,omitempty"`
+	TagMatch     string         `json:"tag_match,omitempty"`
+	Buckets      []ReportBucket `json:"buckets"`
+	TotalSeconds int64          `json:"total_seconds"`
+}
+
+// ReportBucket - группа отчета: начало дня/недели/месяца (Period), задача (TaskID, NameTask),
+// проект (ProjectID, ProjectName и клиент проекта), клиент (ClientID, ClientName) или тег (Tag).
+// Время задач без проекта попадает в группу с пустым ProjectID, без клиента - с пустым ClientID,
+// без тегов - с пустым Tag.
+type ReportBucket struct {
+	Period       string `json:"period,omitempty" example:"2024-01-01"`
+	TaskID       int    `json:"task_id,omitempty"`
+	NameTask     string `json:"name_task,omitempty"`
+	ProjectID    *int   `json:"project_id,omitempty"`
+	ProjectName  string `json:"project_name,omitempty"`
+	ClientID     *int   `json:"client_id,omitempty"`
+	ClientName   string `json:"client_name,omitempty"`
+	Tag          string `json:"tag,omitempty"`
+	TotalSeconds int64  `json:"total_seconds"`
+}
+
+// ExportRow - строка выгрузки в часовом поясе пользователя: интервал работы над задачей в отчете
+// или задача целиком в списке задач. Для запущенной задачи End пустой, а длительность считается
+// до текущего момента; у задачи, которую еще не начинали, пустой и Start.
+type ExportRow struct {
+	UserName        string
+	TaskID          int
+	NameTask        string
+	ProjectName     string
+	ClientName      string
+	Start           sql.NullTime
+	End             sql.NullTime
+	DurationSeconds int64
+}
+
+// CalendarEvent - завершенная задача для календаря; время в UTC
+type CalendarEvent struct {
+	TaskID   int
+	NameTask string
+	Start    time.Time
+	End      time.Time
+	AllTime  int64
+}
+
+// CalendarToken - новый токен подписки на календарь. Токен показывается только один раз,
+// в БД хранится его хеш.
+type CalendarToken struct {
+	Token string `json:"token" example:"hG3k...Q"`
+	URL   string `json:"url" example:"http://localhost:8080/users/1/calendar.ics?token=hG3k...Q"`
+}
+
+// причины, по которым запись импорта пропущена
+const (
+	ImportIssueInvalid   = "invalid"
+	ImportIssueDuplicate = "duplicate"
+	ImportIssueOverlap   = "overlap"
+)
+
+// ImportEntry - запись из импортируемого файла; Line - номер строки в файле
+type ImportEntry struct {
+	Line       int
+	ExternalID string
+	NameTask   string
+	Start      time.Time
+	End        time.Time
+}
+
+// ImportIssue - запись файла, которая не импортирована (или не будет импортирована при dry_run)
+type ImportIssue struct {
+	Line       int    `json:"line" example:"12"`
+	ExternalID string `json:"external_id,omitempty"`
+	NameTask   string `json:"name_task,omitempty"`
+	Reason     string `json:"reason" example:"overlap"`
+	Message    string `json:"message"`
+}
+
+// ImportResult - итог импорта. При dry_run ничего не сохраняется, а Imported показывает,
+// сколько записей было бы загружено.
+type ImportResult struct {
+	DryRun     bool          `json:"dry_run"`
+	Total      int           `json:"total"`
+	Imported   int           `json:"imported"`
+	Duplicates int           `json:"duplicates"`
+	Skipped    int           `json:"skipped"`
+	TaskIDs    []int         `json:"task_ids,omitempty"`
+	Issues     []ImportIssue `json:"issues"`
+}
+
+// ErrorResponse - единый формат ответа с ошибкой
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+type ErrorBody struct {
+	Code    string            `json:"code" example:"validation_failed"`
+	Message string            `json:"message" example:"ошибка валидации: passport_number.series: input character is not a digit"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// HealthReport - ответ проверки готовности сервиса с разбивкой по зависимостям
+type HealthReport struct {
+	Status string                 `json:"status" example:"ok"`
+	Checks map[string]HealthCheck `json:"checks,omitempty"`
+}
+
+type HealthCheck struct {
+	Status  string                 `json:"status" example:"ok"`
+	Error   string                 `json:"error,omitempty"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// статусы задачи
+const (
+	TaskStatusNotStarted = "not_started"
+	TaskStatusRunning    = "running"
+	TaskStatusPaused     = "paused"
+	TaskStatusFinished   = "finished"
+)
+
+// поля сортировки списка задач
+const (
+	TaskSortID        = "id"
+	TaskSortName      = "name"
+	TaskSortStartTime = "start_time"
+	TaskSortEndTime   = "end_time"
+	TaskSortDuration  = "duration"
+)
+
+// TaskFilter - параметры выборки задач пользователя
+type TaskFilter struct {
+	Status    string
+	Name      string
+	ProjectID int       // 0 - без фильтра по проекту
+	ClientID  int       // 0 - без фильтра по клиенту
+	Tags      []string  // пусто - без фильтра по тегам
+	TagMatch  string    // any или all
+	From      time.Time // задачи, начатые не раньше From (если задано)
+	To        time.Time // задачи, начатые раньше To (если задано)
+	FromDay   time.Time // задачи, начатые не раньше полуночи дня FromDay в часовом поясе пользователя (если задано)
+	ToDay     time.Time // задачи, начатые не позже дня ToDay включительно в часовом поясе пользователя (если задано)
+	SortBy    string
+	SortDesc  bool
+	Cursor    string
+	Limit     int
+}
+
+// TaskItem - задача в списке со всеми полями
+type TaskItem struct {
+	TaskID          int        `json:"id"`
+	UserID          int        `json:"user_id"`
+	NameTask        string     `json:"name_task"`
+	Status          string     `json:"status" example:"finished"`
+	StartTime       *time.Time `json:"start_time"`
+	EndTime         *time.Time `json:"end_time"`
+	DurationSeconds int64      `json:"duration_seconds"`
+	IsManual        bool       `json:"is_manual"`
+	ProjectID       *int       `json:"project_id"`
+	Tags            []string   `json:"tags"`
+}
+
+// TaskPage - страница списка задач; NextCursor пустой на последней странице
+type TaskPage struct {
+	Tasks      []TaskItem `json:"tasks"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+}
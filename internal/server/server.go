@@ -1,32 +1,35 @@
 package server
 
 import (
-	"github.com/joho/godotenv"
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"os/signal"
+	"syscall"
+	"time-tracker/internal/API/apiDataUser"
+	"time-tracker/internal/auth"
 	"time-tracker/internal/config"
+	"time-tracker/internal/enrichment"
 	"time-tracker/internal/handlers"
 	"time-tracker/internal/logger"
+	"time-tracker/internal/metrics"
 	"time-tracker/internal/storage/postgres"
 	"time-tracker/internal/usecase"
 )
 
-func StartServer() error {
+// StartServer запускает HTTP-сервер с конфигурацией conf и блокируется до SIGINT/SIGTERM
+func StartServer(conf *config.Config) error {
 
 	// Инициализация логгера
-	if err := logger.InitLogger("log.txt"); err != nil {
-		panic("cannot initialize zap")
+	if err := logger.InitLogger(conf.LOG_FILE); err != nil {
+		return fmt.Errorf("инициализация логгера: %w", err)
 	}
 	defer logger.SugaredLogger().Sync()
 
-	//считываем файл .env
-	err := godotenv.Load(".env")
+	tokens, err := auth.NewTokens(conf.JWT_SECRET, conf.ACCESS_TOKEN_TTL, conf.REFRESH_TOKEN_TTL)
 	if err != nil {
-		logger.SugaredLogger().Errorw("Ошибка загрузки файла .env", "error", err)
-	}
-	//парсим переменные окружения
-	conf, err := config.ParseConfigServer()
-	if err != nil {
-		logger.SugaredLogger().Errorw("Ошибка считывания переменных окружения", "error", err)
+		logger.SugaredLogger().Errorw("Некорректные настройки токенов доступа (JWT_SECRET)", "error", err)
 		return err
 	}
 
@@ -38,15 +41,78 @@ func StartServer() error {
 		logger.SugaredLogger().Fatalw("Ошибка при подключении к БД", "error", err)
 		return err
 	}
-	useCase := usecase.NewUseCaseStorage(db)
+	defer func() {
+		if err := db.Close(); err != nil {
+			logger.SugaredLogger().Errorw("Ошибка закрытия соединения с БД", "error", err)
+		}
+	}()
+	useCase := usecase.NewUseCaseStorage(db, tokens)
 	logger.SugaredLogger().Infow("Успешное подключение к БД")
 
-	r := handlers.InitRoutes(useCase, conf)
+	metrics.RegisterDB(db.DB())
+	metrics.RegisterTaskCounter(db, conf.REQUEST_TIMEOUT)
+
+	enricher := apiDataUser.NewClient(conf)
+	r := handlers.InitRoutes(useCase, conf, tokens, enricher)
 
 	//создние сервера
-	err = http.ListenAndServe(conf.SERVER_HOST+":"+conf.SERVER_PORT, r)
+	srv := &http.Server{
+		Addr:         conf.SERVER_HOST + ":" + conf.SERVER_PORT,
+		Handler:      r,
+		ReadTimeout:  conf.SERVER_READ_TIMEOUT,
+		WriteTimeout: conf.SERVER_WRITE_TIMEOUT,
+		IdleTimeout:  conf.SERVER_IDLE_TIMEOUT,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// очередь обогащения обрабатывается в фоне и останавливается вместе с сервером по сигналу
+	workerDone := make(chan struct{})
+	go func() {
+		defer close(workerDone)
+		enrichment.NewWorker(db, enricher, conf).Run(ctx)
+	}()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err = <-serverErr:
+		if errors.Is(err, http.ErrServerClosed) {
+			err = nil
+		}
+	case <-ctx.Done():
+	}
+	stop()
+
+	// сервер и обработчики очереди останавливаются одновременно, обоих ждем не дольше SHUTDOWN_TIMEOUT
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), conf.SHUTDOWN_TIMEOUT)
+	defer cancel()
+
+	// соединение с БД закрывается после остановки обработчиков или по истечении SHUTDOWN_TIMEOUT
+	defer func() {
+		select {
+		case <-workerDone:
+		case <-shutdownCtx.Done():
+			logger.SugaredLogger().Warnw("Обработчики очереди обогащения не остановились за SHUTDOWN_TIMEOUT, "+
+				"взятые задачи вернутся в очередь по истечении срока, на который взяты", "timeout", conf.SHUTDOWN_TIMEOUT)
+		}
+	}()
+
 	if err != nil {
 		return err
 	}
+
+	// дожидаемся завершения активных запросов, новые соединения не принимаются
+	logger.SugaredLogger().Infow("Остановка сервера", "timeout", conf.SHUTDOWN_TIMEOUT)
+	if err = srv.Shutdown(shutdownCtx); err != nil {
+		logger.SugaredLogger().Errorw("Не все запросы завершились до остановки сервера", "error", err)
+		return err
+	}
+
+	logger.SugaredLogger().Infow("Сервер остановлен")
 	return nil
 }
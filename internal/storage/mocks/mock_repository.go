@@ -0,0 +1,985 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/storage/repo.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	models "time-tracker/internal/models"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepositoryDB is a mock of RepositoryDB interface.
+type MockRepositoryDB struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryDBMockRecorder
+}
+
+// MockRepositoryDBMockRecorder is the mock recorder for MockRepositoryDB.
+type MockRepositoryDBMockRecorder struct {
+	mock *MockRepositoryDB
+}
+
+// NewMockRepositoryDB creates a new mock instance.
+func NewMockRepositoryDB(ctrl *gomock.Controller) *MockRepositoryDB {
+	mock := &MockRepositoryDB{ctrl: ctrl}
+	mock.recorder = &MockRepositoryDBMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepositoryDB) EXPECT() *MockRepositoryDBMockRecorder {
+	return m.recorder
+}
+
+// AddEndTime mocks base method.
+func (m *MockRepositoryDB) AddEndTime(ctx context.Context, taskID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEndTime", ctx, taskID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddEndTime indicates an expected call of AddEndTime.
+func (mr *MockRepositoryDBMockRecorder) AddEndTime(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEndTime", reflect.TypeOf((*MockRepositoryDB)(nil).AddEndTime), ctx, taskID)
+}
+
+// AddStartTime mocks base method.
+func (m *MockRepositoryDB) AddStartTime(ctx context.Context, taskID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddStartTime", ctx, taskID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddStartTime indicates an expected call of AddStartTime.
+func (mr *MockRepositoryDBMockRecorder) AddStartTime(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStartTime", reflect.TypeOf((*MockRepositoryDB)(nil).AddStartTime), ctx, taskID)
+}
+
+// AddTaskTags mocks base method.
+func (m *MockRepositoryDB) AddTaskTags(ctx context.Context, taskID int, tags []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTaskTags", ctx, taskID, tags)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTaskTags indicates an expected call of AddTaskTags.
+func (mr *MockRepositoryDBMockRecorder) AddTaskTags(ctx, taskID, tags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTaskTags", reflect.TypeOf((*MockRepositoryDB)(nil).AddTaskTags), ctx, taskID, tags)
+}
+
+// AuthenticateAPIKey mocks base method.
+func (m *MockRepositoryDB) AuthenticateAPIKey(ctx context.Context, keyHash string) (models.APIKeyOwner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateAPIKey", ctx, keyHash)
+	ret0, _ := ret[0].(models.APIKeyOwner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateAPIKey indicates an expected call of AuthenticateAPIKey.
+func (mr *MockRepositoryDBMockRecorder) AuthenticateAPIKey(ctx, keyHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateAPIKey", reflect.TypeOf((*MockRepositoryDB)(nil).AuthenticateAPIKey), ctx, keyHash)
+}
+
+// CalendarEvents mocks base method.
+func (m *MockRepositoryDB) CalendarEvents(ctx context.Context, userID int, event func(models.CalendarEvent) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalendarEvents", ctx, userID, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CalendarEvents indicates an expected call of CalendarEvents.
+func (mr *MockRepositoryDBMockRecorder) CalendarEvents(ctx, userID, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalendarEvents", reflect.TypeOf((*MockRepositoryDB)(nil).CalendarEvents), ctx, userID, event)
+}
+
+// ClaimEnrichmentJob mocks base method.
+func (m *MockRepositoryDB) ClaimEnrichmentJob(ctx context.Context, lease time.Duration) (models.EnrichmentJob, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimEnrichmentJob", ctx, lease)
+	ret0, _ := ret[0].(models.EnrichmentJob)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ClaimEnrichmentJob indicates an expected call of ClaimEnrichmentJob.
+func (mr *MockRepositoryDBMockRecorder) ClaimEnrichmentJob(ctx, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimEnrichmentJob", reflect.TypeOf((*MockRepositoryDB)(nil).ClaimEnrichmentJob), ctx, lease)
+}
+
+// CompleteEnrichmentJob mocks base method.
+func (m *MockRepositoryDB) CompleteEnrichmentJob(ctx context.Context, userID int, userData models.UserData) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteEnrichmentJob", ctx, userID, userData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteEnrichmentJob indicates an expected call of CompleteEnrichmentJob.
+func (mr *MockRepositoryDBMockRecorder) CompleteEnrichmentJob(ctx, userID, userData interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteEnrichmentJob", reflect.TypeOf((*MockRepositoryDB)(nil).CompleteEnrichmentJob), ctx, userID, userData)
+}
+
+// Create mocks base method.
+func (m *MockRepositoryDB) Create(ctx context.Context, userData models.UserData) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userData)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryDBMockRecorder) Create(ctx, userData interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepositoryDB)(nil).Create), ctx, userData)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockRepositoryDB) CreateAPIKey(ctx context.Context, userID int, key models.APIKey, keyHash string) (models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, userID, key, keyHash)
+	ret0, _ := ret[0].(models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockRepositoryDBMockRecorder) CreateAPIKey(ctx, userID, key, keyHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockRepositoryDB)(nil).CreateAPIKey), ctx, userID, key, keyHash)
+}
+
+// CreateClient mocks base method.
+func (m *MockRepositoryDB) CreateClient(ctx context.Context, name string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateClient", ctx, name)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateClient indicates an expected call of CreateClient.
+func (mr *MockRepositoryDBMockRecorder) CreateClient(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClient", reflect.TypeOf((*MockRepositoryDB)(nil).CreateClient), ctx, name)
+}
+
+// CreateManualTask mocks base method.
+func (m *MockRepositoryDB) CreateManualTask(ctx context.Context, userID int, nameTask string, start, end time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateManualTask", ctx, userID, nameTask, start, end)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateManualTask indicates an expected call of CreateManualTask.
+func (mr *MockRepositoryDBMockRecorder) CreateManualTask(ctx, userID, nameTask, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateManualTask", reflect.TypeOf((*MockRepositoryDB)(nil).CreateManualTask), ctx, userID, nameTask, start, end)
+}
+
+// CreatePendingUser mocks base method.
+func (m *MockRepositoryDB) CreatePendingUser(ctx context.Context, passportNumber string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePendingUser", ctx, passportNumber)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePendingUser indicates an expected call of CreatePendingUser.
+func (mr *MockRepositoryDBMockRecorder) CreatePendingUser(ctx, passportNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePendingUser", reflect.TypeOf((*MockRepositoryDB)(nil).CreatePendingUser), ctx, passportNumber)
+}
+
+// CreateProject mocks base method.
+func (m *MockRepositoryDB) CreateProject(ctx context.Context, project models.ProjectRequest) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProject", ctx, project)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProject indicates an expected call of CreateProject.
+func (mr *MockRepositoryDBMockRecorder) CreateProject(ctx, project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockRepositoryDB)(nil).CreateProject), ctx, project)
+}
+
+// CreateRefreshToken mocks base method.
+func (m *MockRepositoryDB) CreateRefreshToken(ctx context.Context, userID int, tokenHash string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRefreshToken", ctx, userID, tokenHash, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRefreshToken indicates an expected call of CreateRefreshToken.
+func (mr *MockRepositoryDBMockRecorder) CreateRefreshToken(ctx, userID, tokenHash, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefreshToken", reflect.TypeOf((*MockRepositoryDB)(nil).CreateRefreshToken), ctx, userID, tokenHash, expiresAt)
+}
+
+// CreateTask mocks base method.
+func (m *MockRepositoryDB) CreateTask(ctx context.Context, userID int, nameTask string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTask", ctx, userID, nameTask)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTask indicates an expected call of CreateTask.
+func (mr *MockRepositoryDBMockRecorder) CreateTask(ctx, userID, nameTask interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockRepositoryDB)(nil).CreateTask), ctx, userID, nameTask)
+}
+
+// Delete mocks base method.
+func (m *MockRepositoryDB) Delete(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryDBMockRecorder) Delete(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepositoryDB)(nil).Delete), ctx, userID)
+}
+
+// DeleteClient mocks base method.
+func (m *MockRepositoryDB) DeleteClient(ctx context.Context, clientID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClient", ctx, clientID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteClient indicates an expected call of DeleteClient.
+func (mr *MockRepositoryDBMockRecorder) DeleteClient(ctx, clientID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClient", reflect.TypeOf((*MockRepositoryDB)(nil).DeleteClient), ctx, clientID)
+}
+
+// DeleteProject mocks base method.
+func (m *MockRepositoryDB) DeleteProject(ctx context.Context, projectID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProject", ctx, projectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProject indicates an expected call of DeleteProject.
+func (mr *MockRepositoryDBMockRecorder) DeleteProject(ctx, projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockRepositoryDB)(nil).DeleteProject), ctx, projectID)
+}
+
+// DeleteTask mocks base method.
+func (m *MockRepositoryDB) DeleteTask(ctx context.Context, taskID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTask", ctx, taskID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTask indicates an expected call of DeleteTask.
+func (mr *MockRepositoryDBMockRecorder) DeleteTask(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockRepositoryDB)(nil).DeleteTask), ctx, taskID)
+}
+
+// ExportReport mocks base method.
+func (m *MockRepositoryDB) ExportReport(ctx context.Context, userID int, filter models.ReportFilter, row func(models.ExportRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportReport", ctx, userID, filter, row)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportReport indicates an expected call of ExportReport.
+func (mr *MockRepositoryDBMockRecorder) ExportReport(ctx, userID, filter, row interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportReport", reflect.TypeOf((*MockRepositoryDB)(nil).ExportReport), ctx, userID, filter, row)
+}
+
+// ExportTasks mocks base method.
+func (m *MockRepositoryDB) ExportTasks(ctx context.Context, userID int, filter models.TaskFilter, row func(models.ExportRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportTasks", ctx, userID, filter, row)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportTasks indicates an expected call of ExportTasks.
+func (mr *MockRepositoryDBMockRecorder) ExportTasks(ctx, userID, filter, row interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTasks", reflect.TypeOf((*MockRepositoryDB)(nil).ExportTasks), ctx, userID, filter, row)
+}
+
+// FailEnrichmentJob mocks base method.
+func (m *MockRepositoryDB) FailEnrichmentJob(ctx context.Context, userID int, lastError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailEnrichmentJob", ctx, userID, lastError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailEnrichmentJob indicates an expected call of FailEnrichmentJob.
+func (mr *MockRepositoryDBMockRecorder) FailEnrichmentJob(ctx, userID, lastError interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailEnrichmentJob", reflect.TypeOf((*MockRepositoryDB)(nil).FailEnrichmentJob), ctx, userID, lastError)
+}
+
+// GetActiveTimer mocks base method.
+func (m *MockRepositoryDB) GetActiveTimer(ctx context.Context, userID int) (models.Timer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveTimer", ctx, userID)
+	ret0, _ := ret[0].(models.Timer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveTimer indicates an expected call of GetActiveTimer.
+func (mr *MockRepositoryDBMockRecorder) GetActiveTimer(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveTimer", reflect.TypeOf((*MockRepositoryDB)(nil).GetActiveTimer), ctx, userID)
+}
+
+// GetTasksUser mocks base method.
+func (m *MockRepositoryDB) GetTasksUser(ctx context.Context, userID int, timeTask models.TaskTime) ([]models.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasksUser", ctx, userID, timeTask)
+	ret0, _ := ret[0].([]models.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasksUser indicates an expected call of GetTasksUser.
+func (mr *MockRepositoryDBMockRecorder) GetTasksUser(ctx, userID, timeTask interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksUser", reflect.TypeOf((*MockRepositoryDB)(nil).GetTasksUser), ctx, userID, timeTask)
+}
+
+// GetUsers mocks base method.
+func (m *MockRepositoryDB) GetUsers(ctx context.Context, dataFilter models.UserData, page, limit int) ([]models.UserData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", ctx, dataFilter, page, limit)
+	ret0, _ := ret[0].([]models.UserData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockRepositoryDBMockRecorder) GetUsers(ctx, dataFilter, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockRepositoryDB)(nil).GetUsers), ctx, dataFilter, page, limit)
+}
+
+// ImportTasks mocks base method.
+func (m *MockRepositoryDB) ImportTasks(ctx context.Context, userID int, entries []models.ImportEntry, dryRun bool) (models.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTasks", ctx, userID, entries, dryRun)
+	ret0, _ := ret[0].(models.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportTasks indicates an expected call of ImportTasks.
+func (mr *MockRepositoryDBMockRecorder) ImportTasks(ctx, userID, entries, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTasks", reflect.TypeOf((*MockRepositoryDB)(nil).ImportTasks), ctx, userID, entries, dryRun)
+}
+
+// IsTeamMember mocks base method.
+func (m *MockRepositoryDB) IsTeamMember(ctx context.Context, managerID, userID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTeamMember", ctx, managerID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsTeamMember indicates an expected call of IsTeamMember.
+func (mr *MockRepositoryDBMockRecorder) IsTeamMember(ctx, managerID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTeamMember", reflect.TypeOf((*MockRepositoryDB)(nil).IsTeamMember), ctx, managerID, userID)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockRepositoryDB) ListAPIKeys(ctx context.Context, userID int) ([]models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", ctx, userID)
+	ret0, _ := ret[0].([]models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockRepositoryDBMockRecorder) ListAPIKeys(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockRepositoryDB)(nil).ListAPIKeys), ctx, userID)
+}
+
+// ListClients mocks base method.
+func (m *MockRepositoryDB) ListClients(ctx context.Context) ([]models.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListClients", ctx)
+	ret0, _ := ret[0].([]models.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListClients indicates an expected call of ListClients.
+func (mr *MockRepositoryDBMockRecorder) ListClients(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClients", reflect.TypeOf((*MockRepositoryDB)(nil).ListClients), ctx)
+}
+
+// ListProjects mocks base method.
+func (m *MockRepositoryDB) ListProjects(ctx context.Context, clientID int) ([]models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProjects", ctx, clientID)
+	ret0, _ := ret[0].([]models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProjects indicates an expected call of ListProjects.
+func (mr *MockRepositoryDBMockRecorder) ListProjects(ctx, clientID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProjects", reflect.TypeOf((*MockRepositoryDB)(nil).ListProjects), ctx, clientID)
+}
+
+// ListRoles mocks base method.
+func (m *MockRepositoryDB) ListRoles(ctx context.Context) ([]models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoles", ctx)
+	ret0, _ := ret[0].([]models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoles indicates an expected call of ListRoles.
+func (mr *MockRepositoryDBMockRecorder) ListRoles(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoles", reflect.TypeOf((*MockRepositoryDB)(nil).ListRoles), ctx)
+}
+
+// ListTags mocks base method.
+func (m *MockRepositoryDB) ListTags(ctx context.Context) ([]models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTags", ctx)
+	ret0, _ := ret[0].([]models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTags indicates an expected call of ListTags.
+func (mr *MockRepositoryDBMockRecorder) ListTags(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockRepositoryDB)(nil).ListTags), ctx)
+}
+
+// ListTasks mocks base method.
+func (m *MockRepositoryDB) ListTasks(ctx context.Context, userID int, filter models.TaskFilter) (models.TaskPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTasks", ctx, userID, filter)
+	ret0, _ := ret[0].(models.TaskPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTasks indicates an expected call of ListTasks.
+func (mr *MockRepositoryDBMockRecorder) ListTasks(ctx, userID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockRepositoryDB)(nil).ListTasks), ctx, userID, filter)
+}
+
+// ListTeam mocks base method.
+func (m *MockRepositoryDB) ListTeam(ctx context.Context, managerID int) ([]models.UserData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTeam", ctx, managerID)
+	ret0, _ := ret[0].([]models.UserData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTeam indicates an expected call of ListTeam.
+func (mr *MockRepositoryDBMockRecorder) ListTeam(ctx, managerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTeam", reflect.TypeOf((*MockRepositoryDB)(nil).ListTeam), ctx, managerID)
+}
+
+// MigrationVersion mocks base method.
+func (m *MockRepositoryDB) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MigrationVersion", ctx)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// MigrationVersion indicates an expected call of MigrationVersion.
+func (mr *MockRepositoryDBMockRecorder) MigrationVersion(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrationVersion", reflect.TypeOf((*MockRepositoryDB)(nil).MigrationVersion), ctx)
+}
+
+// PauseTask mocks base method.
+func (m *MockRepositoryDB) PauseTask(ctx context.Context, taskID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseTask", ctx, taskID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PauseTask indicates an expected call of PauseTask.
+func (mr *MockRepositoryDBMockRecorder) PauseTask(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseTask", reflect.TypeOf((*MockRepositoryDB)(nil).PauseTask), ctx, taskID)
+}
+
+// Ping mocks base method.
+func (m *MockRepositoryDB) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockRepositoryDBMockRecorder) Ping(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockRepositoryDB)(nil).Ping), ctx)
+}
+
+// Read mocks base method.
+func (m *MockRepositoryDB) Read(ctx context.Context, userID int) (models.UserData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", ctx, userID)
+	ret0, _ := ret[0].(models.UserData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockRepositoryDBMockRecorder) Read(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockRepositoryDB)(nil).Read), ctx, userID)
+}
+
+// ReadCalendarTokenHash mocks base method.
+func (m *MockRepositoryDB) ReadCalendarTokenHash(ctx context.Context, userID int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadCalendarTokenHash", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadCalendarTokenHash indicates an expected call of ReadCalendarTokenHash.
+func (mr *MockRepositoryDBMockRecorder) ReadCalendarTokenHash(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadCalendarTokenHash", reflect.TypeOf((*MockRepositoryDB)(nil).ReadCalendarTokenHash), ctx, userID)
+}
+
+// ReadClient mocks base method.
+func (m *MockRepositoryDB) ReadClient(ctx context.Context, clientID int) (models.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadClient", ctx, clientID)
+	ret0, _ := ret[0].(models.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadClient indicates an expected call of ReadClient.
+func (mr *MockRepositoryDBMockRecorder) ReadClient(ctx, clientID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadClient", reflect.TypeOf((*MockRepositoryDB)(nil).ReadClient), ctx, clientID)
+}
+
+// ReadCredentials mocks base method.
+func (m *MockRepositoryDB) ReadCredentials(ctx context.Context, login string) (models.UserCredentials, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadCredentials", ctx, login)
+	ret0, _ := ret[0].(models.UserCredentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadCredentials indicates an expected call of ReadCredentials.
+func (mr *MockRepositoryDBMockRecorder) ReadCredentials(ctx, login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadCredentials", reflect.TypeOf((*MockRepositoryDB)(nil).ReadCredentials), ctx, login)
+}
+
+// ReadEnrichmentStatus mocks base method.
+func (m *MockRepositoryDB) ReadEnrichmentStatus(ctx context.Context, userID int) (models.EnrichmentStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadEnrichmentStatus", ctx, userID)
+	ret0, _ := ret[0].(models.EnrichmentStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadEnrichmentStatus indicates an expected call of ReadEnrichmentStatus.
+func (mr *MockRepositoryDBMockRecorder) ReadEnrichmentStatus(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadEnrichmentStatus", reflect.TypeOf((*MockRepositoryDB)(nil).ReadEnrichmentStatus), ctx, userID)
+}
+
+// ReadProject mocks base method.
+func (m *MockRepositoryDB) ReadProject(ctx context.Context, projectID int) (models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadProject", ctx, projectID)
+	ret0, _ := ret[0].(models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadProject indicates an expected call of ReadProject.
+func (mr *MockRepositoryDBMockRecorder) ReadProject(ctx, projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadProject", reflect.TypeOf((*MockRepositoryDB)(nil).ReadProject), ctx, projectID)
+}
+
+// ReadTask mocks base method.
+func (m *MockRepositoryDB) ReadTask(ctx context.Context, taskID int) (models.TaskData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadTask", ctx, taskID)
+	ret0, _ := ret[0].(models.TaskData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadTask indicates an expected call of ReadTask.
+func (mr *MockRepositoryDBMockRecorder) ReadTask(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadTask", reflect.TypeOf((*MockRepositoryDB)(nil).ReadTask), ctx, taskID)
+}
+
+// ReadUserSettings mocks base method.
+func (m *MockRepositoryDB) ReadUserSettings(ctx context.Context, userID int) (models.UserSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadUserSettings", ctx, userID)
+	ret0, _ := ret[0].(models.UserSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadUserSettings indicates an expected call of ReadUserSettings.
+func (mr *MockRepositoryDBMockRecorder) ReadUserSettings(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserSettings", reflect.TypeOf((*MockRepositoryDB)(nil).ReadUserSettings), ctx, userID)
+}
+
+// RemoveTaskTag mocks base method.
+func (m *MockRepositoryDB) RemoveTaskTag(ctx context.Context, taskID int, tag string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTaskTag", ctx, taskID, tag)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveTaskTag indicates an expected call of RemoveTaskTag.
+func (mr *MockRepositoryDBMockRecorder) RemoveTaskTag(ctx, taskID, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTaskTag", reflect.TypeOf((*MockRepositoryDB)(nil).RemoveTaskTag), ctx, taskID, tag)
+}
+
+// RenameTask mocks base method.
+func (m *MockRepositoryDB) RenameTask(ctx context.Context, taskID int, nameTask string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameTask", ctx, taskID, nameTask)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameTask indicates an expected call of RenameTask.
+func (mr *MockRepositoryDBMockRecorder) RenameTask(ctx, taskID, nameTask interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTask", reflect.TypeOf((*MockRepositoryDB)(nil).RenameTask), ctx, taskID, nameTask)
+}
+
+// Report mocks base method.
+func (m *MockRepositoryDB) Report(ctx context.Context, userID int, filter models.ReportFilter) (models.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Report", ctx, userID, filter)
+	ret0, _ := ret[0].(models.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Report indicates an expected call of Report.
+func (mr *MockRepositoryDBMockRecorder) Report(ctx, userID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Report", reflect.TypeOf((*MockRepositoryDB)(nil).Report), ctx, userID, filter)
+}
+
+// RescheduleEnrichmentJob mocks base method.
+func (m *MockRepositoryDB) RescheduleEnrichmentJob(ctx context.Context, userID int, runAt time.Time, lastError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RescheduleEnrichmentJob", ctx, userID, runAt, lastError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RescheduleEnrichmentJob indicates an expected call of RescheduleEnrichmentJob.
+func (mr *MockRepositoryDBMockRecorder) RescheduleEnrichmentJob(ctx, userID, runAt, lastError interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescheduleEnrichmentJob", reflect.TypeOf((*MockRepositoryDB)(nil).RescheduleEnrichmentJob), ctx, userID, runAt, lastError)
+}
+
+// ResumeTask mocks base method.
+func (m *MockRepositoryDB) ResumeTask(ctx context.Context, taskID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeTask", ctx, taskID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResumeTask indicates an expected call of ResumeTask.
+func (mr *MockRepositoryDBMockRecorder) ResumeTask(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeTask", reflect.TypeOf((*MockRepositoryDB)(nil).ResumeTask), ctx, taskID)
+}
+
+// RetryEnrichment mocks base method.
+func (m *MockRepositoryDB) RetryEnrichment(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryEnrichment", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetryEnrichment indicates an expected call of RetryEnrichment.
+func (mr *MockRepositoryDBMockRecorder) RetryEnrichment(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryEnrichment", reflect.TypeOf((*MockRepositoryDB)(nil).RetryEnrichment), ctx, userID)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockRepositoryDB) RevokeAPIKey(ctx context.Context, userID, keyID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, userID, keyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockRepositoryDBMockRecorder) RevokeAPIKey(ctx, userID, keyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockRepositoryDB)(nil).RevokeAPIKey), ctx, userID, keyID)
+}
+
+// RevokeRefreshToken mocks base method.
+func (m *MockRepositoryDB) RevokeRefreshToken(ctx context.Context, tokenHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshToken", ctx, tokenHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRefreshToken indicates an expected call of RevokeRefreshToken.
+func (mr *MockRepositoryDBMockRecorder) RevokeRefreshToken(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshToken", reflect.TypeOf((*MockRepositoryDB)(nil).RevokeRefreshToken), ctx, tokenHash)
+}
+
+// RotateRefreshToken mocks base method.
+func (m *MockRepositoryDB) RotateRefreshToken(ctx context.Context, tokenHash, newHash string, expiresAt time.Time) (models.UserCredentials, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateRefreshToken", ctx, tokenHash, newHash, expiresAt)
+	ret0, _ := ret[0].(models.UserCredentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateRefreshToken indicates an expected call of RotateRefreshToken.
+func (mr *MockRepositoryDBMockRecorder) RotateRefreshToken(ctx, tokenHash, newHash, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateRefreshToken", reflect.TypeOf((*MockRepositoryDB)(nil).RotateRefreshToken), ctx, tokenHash, newHash, expiresAt)
+}
+
+// SetCalendarToken mocks base method.
+func (m *MockRepositoryDB) SetCalendarToken(ctx context.Context, userID int, tokenHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCalendarToken", ctx, userID, tokenHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCalendarToken indicates an expected call of SetCalendarToken.
+func (mr *MockRepositoryDBMockRecorder) SetCalendarToken(ctx, userID, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCalendarToken", reflect.TypeOf((*MockRepositoryDB)(nil).SetCalendarToken), ctx, userID, tokenHash)
+}
+
+// SetCredentials mocks base method.
+func (m *MockRepositoryDB) SetCredentials(ctx context.Context, userID int, login, passwordHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCredentials", ctx, userID, login, passwordHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCredentials indicates an expected call of SetCredentials.
+func (mr *MockRepositoryDBMockRecorder) SetCredentials(ctx, userID, login, passwordHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCredentials", reflect.TypeOf((*MockRepositoryDB)(nil).SetCredentials), ctx, userID, login, passwordHash)
+}
+
+// SetTaskProject mocks base method.
+func (m *MockRepositoryDB) SetTaskProject(ctx context.Context, taskID int, projectID *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTaskProject", ctx, taskID, projectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTaskProject indicates an expected call of SetTaskProject.
+func (mr *MockRepositoryDBMockRecorder) SetTaskProject(ctx, taskID, projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTaskProject", reflect.TypeOf((*MockRepositoryDB)(nil).SetTaskProject), ctx, taskID, projectID)
+}
+
+// SetUserManager mocks base method.
+func (m *MockRepositoryDB) SetUserManager(ctx context.Context, userID int, managerID *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserManager", ctx, userID, managerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserManager indicates an expected call of SetUserManager.
+func (mr *MockRepositoryDBMockRecorder) SetUserManager(ctx, userID, managerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserManager", reflect.TypeOf((*MockRepositoryDB)(nil).SetUserManager), ctx, userID, managerID)
+}
+
+// SetUserRole mocks base method.
+func (m *MockRepositoryDB) SetUserRole(ctx context.Context, userID int, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserRole", ctx, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserRole indicates an expected call of SetUserRole.
+func (mr *MockRepositoryDBMockRecorder) SetUserRole(ctx, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserRole", reflect.TypeOf((*MockRepositoryDB)(nil).SetUserRole), ctx, userID, role)
+}
+
+// TaskOwner mocks base method.
+func (m *MockRepositoryDB) TaskOwner(ctx context.Context, taskID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TaskOwner", ctx, taskID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TaskOwner indicates an expected call of TaskOwner.
+func (mr *MockRepositoryDBMockRecorder) TaskOwner(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TaskOwner", reflect.TypeOf((*MockRepositoryDB)(nil).TaskOwner), ctx, taskID)
+}
+
+// Update mocks base method.
+func (m *MockRepositoryDB) Update(ctx context.Context, userID int, userData models.UserData) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, userID, userData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRepositoryDBMockRecorder) Update(ctx, userID, userData interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepositoryDB)(nil).Update), ctx, userID, userData)
+}
+
+// UpdateClient mocks base method.
+func (m *MockRepositoryDB) UpdateClient(ctx context.Context, clientID int, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateClient", ctx, clientID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateClient indicates an expected call of UpdateClient.
+func (mr *MockRepositoryDBMockRecorder) UpdateClient(ctx, clientID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClient", reflect.TypeOf((*MockRepositoryDB)(nil).UpdateClient), ctx, clientID, name)
+}
+
+// UpdateProject mocks base method.
+func (m *MockRepositoryDB) UpdateProject(ctx context.Context, projectID int, project models.ProjectRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProject", ctx, projectID, project)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProject indicates an expected call of UpdateProject.
+func (mr *MockRepositoryDBMockRecorder) UpdateProject(ctx, projectID, project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockRepositoryDB)(nil).UpdateProject), ctx, projectID, project)
+}
+
+// UpdateTaskTime mocks base method.
+func (m *MockRepositoryDB) UpdateTaskTime(ctx context.Context, taskID int, start, end time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaskTime", ctx, taskID, start, end)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTaskTime indicates an expected call of UpdateTaskTime.
+func (mr *MockRepositoryDBMockRecorder) UpdateTaskTime(ctx, taskID, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskTime", reflect.TypeOf((*MockRepositoryDB)(nil).UpdateTaskTime), ctx, taskID, start, end)
+}
+
+// UpdateUserSettings mocks base method.
+func (m *MockRepositoryDB) UpdateUserSettings(ctx context.Context, userID int, update models.UserSettingsUpdate) (models.UserSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserSettings", ctx, userID, update)
+	ret0, _ := ret[0].(models.UserSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserSettings indicates an expected call of UpdateUserSettings.
+func (mr *MockRepositoryDBMockRecorder) UpdateUserSettings(ctx, userID, update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserSettings", reflect.TypeOf((*MockRepositoryDB)(nil).UpdateUserSettings), ctx, userID, update)
+}
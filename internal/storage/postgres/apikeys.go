@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/models"
+)
+
+// CreateAPIKey сохраняет ключ API пользователя по хешу и возвращает его с ID и временем создания
+func (p *PostgresStorage) CreateAPIKey(ctx context.Context, userID int, key models.APIKey, keyHash string) (models.APIKey, error) {
+	query := `
+		INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at;
+		`
+	err := p.db.QueryRowContext(ctx, query, userID, key.Name, key.Prefix, keyHash, key.Scopes, key.ExpiresAt).
+		Scan(&key.ID, &key.CreatedAt)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return models.APIKey{}, fmt.Errorf("%w: id %d", domain.ErrUserNotFound, userID)
+		}
+		return models.APIKey{}, err
+	}
+	return key, nil
+}
+
+// ListAPIKeys возвращает ключи пользователя, включая отозванные и истекшие
+func (p *PostgresStorage) ListAPIKeys(ctx context.Context, userID int) ([]models.APIKey, error) {
+	query := `
+		SELECT id, name, prefix, scopes, created_at, expires_at, last_used_at, revoked_at
+		FROM api_keys WHERE user_id = $1
+		ORDER BY id ASC;
+		`
+	rows, err := p.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	typeMap := pgtype.NewMap()
+	keys := []models.APIKey{}
+	for rows.Next() {
+		var key models.APIKey
+		if err := rows.Scan(&key.ID, &key.Name, &key.Prefix, typeMap.SQLScanner(&key.Scopes),
+			&key.CreatedAt, &key.ExpiresAt, &key.LastUsedAt, &key.RevokedAt); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// RevokeAPIKey отзывает ключ пользователя; повторный отзыв не меняет время первого
+func (p *PostgresStorage) RevokeAPIKey(ctx context.Context, userID, keyID int) error {
+	query := `UPDATE api_keys SET revoked_at = COALESCE(revoked_at, NOW()) WHERE id = $2 AND user_id = $1;`
+	return execOne(ctx, p.db, fmt.Errorf("%w: id %d", domain.ErrAPIKeyNotFound, keyID), query, userID, keyID)
+}
+
+// AuthenticateAPIKey находит действующий (не отозванный и не истекший) ключ по хешу,
+// отмечает время его использования и возвращает владельца с текущей ролью и права ключа.
+// Неизвестный или недействующий ключ - ErrUnauthorized.
+func (p *PostgresStorage) AuthenticateAPIKey(ctx context.Context, keyHash string) (models.APIKeyOwner, error) {
+	query := `
+		UPDATE api_keys k SET last_used_at = NOW()
+		FROM users u
+		WHERE k.key_hash = $1 AND u.id = k.user_id
+			AND k.revoked_at IS NULL AND (k.expires_at IS NULL OR k.expires_at > NOW())
+		RETURNING k.user_id, u.role, k.scopes;
+		`
+	var owner models.APIKeyOwner
+	err := p.db.QueryRowContext(ctx, query, keyHash).Scan(&owner.UserID, &owner.Role, pgtype.NewMap().SQLScanner(&owner.Scopes))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.APIKeyOwner{}, fmt.Errorf("%w: неверный, истекший или отозванный ключ API", domain.ErrUnauthorized)
+		}
+		return models.APIKeyOwner{}, err
+	}
+	if owner.Scopes == nil {
+		owner.Scopes = []string{}
+	}
+	return owner, nil
+}
@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/models"
+)
+
+// SetCredentials задает пользователю логин и хеш пароля и отзывает его refresh-токены,
+// чтобы после смены пароля пришлось войти заново на всех устройствах
+func (p *PostgresStorage) SetCredentials(ctx context.Context, userID int, login, passwordHash string) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE users SET login = $2, password_hash = $3 WHERE id = $1;`
+	err = execOne(ctx, tx, fmt.Errorf("%w: id %d", domain.ErrUserNotFound, userID), query, userID, login, passwordHash)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%w: %s", domain.ErrDuplicateLogin, login)
+		}
+		return err
+	}
+
+	query = `UPDATE refresh_tokens SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL;`
+	if _, err = tx.ExecContext(ctx, query, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ReadCredentials ищет пользователя по логину без учета регистра
+func (p *PostgresStorage) ReadCredentials(ctx context.Context, login string) (models.UserCredentials, error) {
+	query := `SELECT id, role, COALESCE(password_hash, '') FROM users WHERE LOWER(login) = LOWER($1);`
+
+	var creds models.UserCredentials
+	err := p.db.QueryRowContext(ctx, query, login).Scan(&creds.UserID, &creds.Role, &creds.PasswordHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.UserCredentials{}, fmt.Errorf("%w: логин %s", domain.ErrUserNotFound, login)
+		}
+		return models.UserCredentials{}, err
+	}
+	return creds, nil
+}
+
+func (p *PostgresStorage) SetUserRole(ctx context.Context, userID int, role string) error {
+	query := `UPDATE users SET role = $2 WHERE id = $1;`
+	return execOne(ctx, p.db, fmt.Errorf("%w: id %d", domain.ErrUserNotFound, userID), query, userID, role)
+}
+
+// TaskOwner возвращает ID пользователя, которому принадлежит задача
+func (p *PostgresStorage) TaskOwner(ctx context.Context, taskID int) (int, error) {
+	query := `SELECT user_id FROM tasks WHERE id = $1;`
+
+	var userID int
+	if err := p.db.QueryRowContext(ctx, query, taskID).Scan(&userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("%w: id %d", domain.ErrTaskNotFound, taskID)
+		}
+		return 0, err
+	}
+	return userID, nil
+}
+
+// CreateRefreshToken сохраняет хеш refresh-токена, выданного при входе, как начало новой цепочки.
+// Заодно удаляются истекшие токены пользователя.
+func (p *PostgresStorage) CreateRefreshToken(ctx context.Context, userID int, tokenHash string, expiresAt time.Time) error {
+	query := `
+		WITH expired AS (
+			DELETE FROM refresh_tokens WHERE user_id = $1 AND expires_at <= NOW()
+		)
+		INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at)
+		VALUES ($1, gen_random_uuid(), $2, $3);
+		`
+	_, err := p.db.ExecContext(ctx, query, userID, tokenHash, expiresAt)
+	return err
+}
+
+// RotateRefreshToken отзывает refresh-токен tokenHash и сохраняет вместо него newHash в той же цепочке.
+// Повторное использование уже отозванного токена означает, что токен украден,
+// поэтому отзывается вся цепочка. Неизвестный, истекший или отозванный токен - ErrInvalidToken.
+func (p *PostgresStorage) RotateRefreshToken(ctx context.Context, tokenHash, newHash string, expiresAt time.Time) (models.UserCredentials, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.UserCredentials{}, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT r.id, r.family_id, r.expires_at <= NOW(), r.revoked_at IS NOT NULL, u.id, u.role
+		FROM refresh_tokens r
+		JOIN users u ON u.id = r.user_id
+		WHERE r.token_hash = $1
+		FOR UPDATE OF r;
+		`
+	var tokenID int
+	var familyID string
+	var expired, revoked bool
+	var creds models.UserCredentials
+	err = tx.QueryRowContext(ctx, query, tokenHash).Scan(&tokenID, &familyID, &expired, &revoked, &creds.UserID, &creds.Role)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.UserCredentials{}, domain.ErrInvalidToken
+		}
+		return models.UserCredentials{}, err
+	}
+
+	if revoked {
+		query = `UPDATE refresh_tokens SET revoked_at = NOW() WHERE family_id = $1 AND revoked_at IS NULL;`
+		if _, err = tx.ExecContext(ctx, query, familyID); err != nil {
+			return models.UserCredentials{}, err
+		}
+		if err = tx.Commit(); err != nil {
+			return models.UserCredentials{}, err
+		}
+		return models.UserCredentials{}, fmt.Errorf("%w: повторное использование refresh-токена", domain.ErrInvalidToken)
+	}
+	if expired {
+		return models.UserCredentials{}, domain.ErrInvalidToken
+	}
+
+	query = `UPDATE refresh_tokens SET revoked_at = NOW() WHERE id = $1;`
+	if _, err = tx.ExecContext(ctx, query, tokenID); err != nil {
+		return models.UserCredentials{}, err
+	}
+	query = `INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at) VALUES ($1, $2, $3, $4);`
+	if _, err = tx.ExecContext(ctx, query, creds.UserID, familyID, newHash, expiresAt); err != nil {
+		return models.UserCredentials{}, err
+	}
+
+	return creds, tx.Commit()
+}
+
+// RevokeRefreshToken отзывает цепочку, которой принадлежит refresh-токен; неизвестный токен не ошибка
+func (p *PostgresStorage) RevokeRefreshToken(ctx context.Context, tokenHash string) error {
+	query := `
+		UPDATE refresh_tokens SET revoked_at = NOW()
+		WHERE family_id = (SELECT family_id FROM refresh_tokens WHERE token_hash = $1)
+			AND revoked_at IS NULL;
+		`
+	_, err := p.db.ExecContext(ctx, query, tokenHash)
+	return err
+}
@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/models"
+)
+
+// SetCalendarToken сохраняет хеш токена подписки на календарь, заменяя прежний
+func (p *PostgresStorage) SetCalendarToken(ctx context.Context, userID int, tokenHash string) error {
+	query := `UPDATE users SET calendar_token_hash = $2 WHERE id = $1;`
+	return execOne(ctx, p.db, fmt.Errorf("%w: id %d", domain.ErrUserNotFound, userID), query, userID, tokenHash)
+}
+
+// ReadCalendarTokenHash возвращает хеш токена подписки; пустая строка - токен не выпускался
+func (p *PostgresStorage) ReadCalendarTokenHash(ctx context.Context, userID int) (string, error) {
+	query := `SELECT COALESCE(calendar_token_hash, '') FROM users WHERE id = $1;`
+
+	var tokenHash string
+	err := p.db.QueryRowContext(ctx, query, userID).Scan(&tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("%w: id %d", domain.ErrUserNotFound, userID)
+		}
+		return "", err
+	}
+	return tokenHash, nil
+}
+
+// CalendarEvents передает в event завершенные задачи пользователя по мере чтения из БД
+func (p *PostgresStorage) CalendarEvents(ctx context.Context, userID int, event func(models.CalendarEvent) error) error {
+	query := `
+		SELECT id, name_task, start_time::TIMESTAMPTZ, end_time::TIMESTAMPTZ, COALESCE(all_time, 0)
+		FROM tasks
+		WHERE user_id = $1 AND start_time IS NOT NULL AND end_time IS NOT NULL
+		ORDER BY start_time, id;
+		`
+	rows, err := p.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e models.CalendarEvent
+		if err := rows.Scan(&e.TaskID, &e.NameTask, &e.Start, &e.End, &e.AllTime); err != nil {
+			return err
+		}
+		if err := event(e); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -1,92 +1,166 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
 	_ "github.com/jackc/pgconn"
 	_ "github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"strconv"
-	"sync"
 	"time-tracker/internal/config"
+	"time-tracker/internal/domain"
 	"time-tracker/internal/models"
 	"time-tracker/internal/validator"
 )
 
+// закрытие открытого интервала задачи
+const closeIntervalQuery = `
+		UPDATE task_intervals
+		SET end_time = NOW()
+		WHERE task_id = $1 AND end_time IS NULL;
+		`
+
+// сумма закрытых интервалов задачи в секундах
+const sumIntervalsQuery = `
+		SELECT COALESCE(SUM(EXTRACT(EPOCH FROM end_time - start_time)), 0)
+		FROM task_intervals
+		WHERE task_id = $1 AND end_time IS NOT NULL
+		`
+
 type PostgresStorage struct {
 	db *sql.DB
-	mu sync.RWMutex
 }
 
+// NewPostgresStorage подключается к БД и, если включен AUTO_MIGRATE, применяет новые миграции
 func NewPostgresStorage(conf *config.Config) (*PostgresStorage, error) {
+	db, err := openDB(conf)
+	if err != nil {
+		return nil, err
+	}
 
-	login := conf.DB_LOGIN
-	password := conf.DB_PASS
-	host := conf.DB_HOST
-	port := conf.DB_PORT
-	dbname := conf.DB_NAME
+	if conf.AUTO_MIGRATE {
+		m, err := NewMigrate(db)
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+		if err = m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			db.Close()
+			return nil, fmt.Errorf("применение миграций: %w", err)
+		}
+	}
+
+	return &PostgresStorage{db: db}, nil
+}
+
+// Close закрывает пул соединений с БД
+func (p *PostgresStorage) Close() error {
+	return p.db.Close()
+}
+
+// Ping проверяет доступность БД
+func (p *PostgresStorage) Ping(ctx context.Context) error {
+	return p.db.PingContext(ctx)
+}
 
-	dsn := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable", login, password, host, port, dbname)
+// MigrationVersion возвращает текущую версию схемы из таблицы golang-migrate
+func (p *PostgresStorage) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	query := `SELECT version, dirty FROM ` + postgres.DefaultMigrationsTable + ` LIMIT 1;`
 
-	db, err := sql.Open("pgx", dsn)
+	var version int64
+	var dirty bool
+	err := p.db.QueryRowContext(ctx, query).Scan(&version, &dirty)
 	if err != nil {
-		return nil, err
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, migrate.ErrNilVersion
+		}
+		return 0, false, err
 	}
 
-	if err = db.Ping(); err != nil {
-		return nil, err
+	return uint(version), dirty, nil
+}
+
+// DB отдает пул соединений для сбора статистики
+func (p *PostgresStorage) DB() *sql.DB {
+	return p.db
+}
+
+// CountRunningTasks возвращает количество запущенных и не завершенных задач
+func (p *PostgresStorage) CountRunningTasks(ctx context.Context) (int, error) {
+	query := `SELECT COUNT(*) FROM tasks WHERE start_time IS NOT NULL AND end_time IS NULL;`
+
+	var count int
+	if err := p.db.QueryRowContext(ctx, query).Scan(&count); err != nil {
+		return 0, err
 	}
+	return count, nil
+}
+
+// isUniqueViolation проверяет, что запрос нарушил ограничение уникальности
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
 
-	driver, err := postgres.WithInstance(db, &postgres.Config{})
+// isForeignKeyViolation проверяет, что запрос сослался на несуществующую запись
+// или удаляет запись, на которую есть ссылки
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503"
+}
+
+// execer - *sql.DB или *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// execOne выполняет запрос, изменяющий одну запись, и возвращает notFound, если запрос не изменил
+// ни одной записи: записи нет или не выполнено условие запроса
+func execOne(ctx context.Context, db execer, notFound error, query string, args ...interface{}) error {
+	result, err := db.ExecContext(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	m, err := migrate.NewWithDatabaseInstance(
-		"file://migrations",
-		"postgres", driver)
+
+	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		//sugar.Errorw("Не удалось создать объект миграции", "error", err)
-		return nil, err
+		return err
 	}
-
-	//применение миграций
-	err = m.Up()
-	if err != nil && err != migrate.ErrNoChange {
-		//sugar.Errorw("Не удалось применить миграции", "error", err)
-		return nil, err
+	if rowsAffected == 0 {
+		return notFound
 	}
-
-	return &PostgresStorage{db: db}, nil
+	return nil
 }
 
-func (p *PostgresStorage) Create(userData models.UserData) (int, error) {
+func (p *PostgresStorage) Create(ctx context.Context, userData models.UserData) (int, error) {
 	query := `
 INSERT INTO users (passport_number, surname, name, patronymic, address)
 VALUES ($1, $2, $3, $4, $5)
 RETURNING id;
 `
 	var userID int
-	err := p.db.QueryRow(query, userData.PassportNumber, userData.Surname, userData.Name, userData.Patronymic, userData.Address).Scan(&userID)
+	err := p.db.QueryRowContext(ctx, query, userData.PassportNumber, userData.Surname, userData.Name, userData.Patronymic, userData.Address).Scan(&userID)
 	if err != nil {
-		var pgErr *pgconn.PgError
-		errors.As(err, &pgErr)
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("%w: %s", domain.ErrDuplicatePassport, userData.PassportNumber)
+		}
 		return 0, err
 	}
 	return userID, nil
 }
 
-func (p *PostgresStorage) Read(userID int) (models.UserData, error) {
+func (p *PostgresStorage) Read(ctx context.Context, userID int) (models.UserData, error) {
 	query := `
 		SELECT id, passport_number, surname, name, patronymic, address FROM users WHERE id = $1;
 	`
 
 	data := models.UserData{}
-	err := p.db.QueryRow(query, userID).Scan(
+	err := p.db.QueryRowContext(ctx, query, userID).Scan(
 		&data.UserID,
 		&data.PassportNumber,
 		&data.Surname,
@@ -96,7 +170,7 @@ func (p *PostgresStorage) Read(userID int) (models.UserData, error) {
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return models.UserData{}, fmt.Errorf("пользователь с id %d не найден", userID)
+			return models.UserData{}, fmt.Errorf("%w: id %d", domain.ErrUserNotFound, userID)
 		}
 		return models.UserData{}, err
 	}
@@ -104,8 +178,8 @@ func (p *PostgresStorage) Read(userID int) (models.UserData, error) {
 	return data, nil
 }
 
-func (p *PostgresStorage) Update(userID int, userData models.UserData) error {
-	data, err := p.Read(userID)
+func (p *PostgresStorage) Update(ctx context.Context, userID int, userData models.UserData) error {
+	data, err := p.Read(ctx, userID)
 	if err != nil {
 		return err
 	}
@@ -130,7 +204,7 @@ func (p *PostgresStorage) Update(userID int, userData models.UserData) error {
 		data.Address = userData.Address
 	}
 
-	_, err = p.db.Exec(query,
+	_, err = p.db.ExecContext(ctx, query,
 		userID,
 		data.PassportNumber,
 		data.Surname,
@@ -139,31 +213,21 @@ func (p *PostgresStorage) Update(userID int, userData models.UserData) error {
 		data.Address,
 	)
 	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%w: %s", domain.ErrDuplicatePassport, data.PassportNumber)
+		}
 		return err
 	}
 
 	return nil
 }
 
-func (p *PostgresStorage) Delete(userID int) error {
+func (p *PostgresStorage) Delete(ctx context.Context, userID int) error {
 	query := `DELETE FROM users WHERE id = $1;`
-	result, err := p.db.Exec(query, userID)
-	if err != nil {
-		return fmt.Errorf("ошибка удаления записи: %v", err)
-	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
-
-	if rowsAffected == 0 {
-		return fmt.Errorf("пользователь с id %d не найден", userID)
-	}
-	return nil
+	return execOne(ctx, p.db, fmt.Errorf("%w: id %d", domain.ErrUserNotFound, userID), query, userID)
 }
-func (p *PostgresStorage) GetUsers(dataFilter models.UserData, page, limit int) ([]models.UserData, error) {
-	query := `SELECT * FROM users WHERE 1=1`
+func (p *PostgresStorage) GetUsers(ctx context.Context, dataFilter models.UserData, page, limit int) ([]models.UserData, error) {
+	query := `SELECT id, passport_number, surname, name, patronymic, address FROM users WHERE 1=1`
 	args := []interface{}{}
 	argCounter := 1
 
@@ -203,7 +267,7 @@ func (p *PostgresStorage) GetUsers(dataFilter models.UserData, page, limit int)
 	query += " LIMIT $" + strconv.Itoa(argCounter) + " OFFSET $" + strconv.Itoa(argCounter+1)
 	args = append(args, limit, offset)
 
-	rows, err := p.db.Query(query, args...)
+	rows, err := p.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -225,8 +289,8 @@ func (p *PostgresStorage) GetUsers(dataFilter models.UserData, page, limit int)
 	return users, nil
 }
 
-func (p *PostgresStorage) CreateTask(userID int, nameTask string) (int, error) {
-	_, err := p.Read(userID)
+func (p *PostgresStorage) CreateTask(ctx context.Context, userID int, nameTask string) (int, error) {
+	_, err := p.Read(ctx, userID)
 	if err != nil {
 		return 0, err
 	}
@@ -237,95 +301,257 @@ VALUES ($1, $2)
 RETURNING id;
 `
 	var taskID int
-	err = p.db.QueryRow(query, userID, nameTask).Scan(&taskID)
+	err = p.db.QueryRowContext(ctx, query, userID, nameTask).Scan(&taskID)
 	if err != nil {
-		var pgErr *pgconn.PgError
-		errors.As(err, &pgErr)
 		return 0, err
 	}
 	return taskID, nil
 }
 
-func (p *PostgresStorage) ReadTask(taskID int) (models.TaskData, error) {
+func (p *PostgresStorage) ReadTask(ctx context.Context, taskID int) (models.TaskData, error) {
 	query := `
-		SELECT id, user_id, name_task, start_time, end_time, all_time FROM tasks WHERE id = $1;
+		SELECT id, user_id, name_task, start_time, end_time, all_time, is_manual, project_id FROM tasks WHERE id = $1;
 	`
 
 	data := models.TaskData{}
-	err := p.db.QueryRow(query, taskID).Scan(
+	err := p.db.QueryRowContext(ctx, query, taskID).Scan(
 		&data.TaskID,
 		&data.UserID,
 		&data.NameTask,
 		&data.StartTime,
 		&data.EndTime,
 		&data.AllTime,
+		&data.IsManual,
+		&data.ProjectID,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return models.TaskData{}, fmt.Errorf("задача с id %d не найдена", taskID)
+			return models.TaskData{}, fmt.Errorf("%w: id %d", domain.ErrTaskNotFound, taskID)
 		}
 		return models.TaskData{}, err
 	}
 
+	data.Intervals, err = p.readTaskIntervals(ctx, taskID)
+	if err != nil {
+		return models.TaskData{}, err
+	}
+
 	return data, nil
 }
 
-func (p *PostgresStorage) AddStartTime(taskID int) error {
-	// Проверяем, что задача существует и получаем её данные
-	task, err := p.ReadTask(taskID)
+func (p *PostgresStorage) readTaskIntervals(ctx context.Context, taskID int) ([]models.TaskInterval, error) {
+	query := `
+		SELECT id, start_time, end_time FROM task_intervals WHERE task_id = $1 ORDER BY start_time, id;
+	`
+
+	rows, err := p.db.QueryContext(ctx, query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	intervals := []models.TaskInterval{}
+	for rows.Next() {
+		var interval models.TaskInterval
+		if err := rows.Scan(&interval.IntervalID, &interval.StartTime, &interval.EndTime); err != nil {
+			return nil, err
+		}
+		intervals = append(intervals, interval)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return intervals, nil
+}
+
+func (p *PostgresStorage) AddStartTime(ctx context.Context, taskID int) error {
+	tx, err := p.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	// Проверяем, что поле start_time равно NULL
-	if !task.StartTime.Valid { // Проверяем, что start_time является NULL
-		query := `
+	// состояние задачи проверяем под блокировкой, чтобы параллельный запрос не изменил его
+	state, err := lockTask(ctx, tx, taskID)
+	if err != nil {
+		return err
+	}
+	if state.started {
+		return fmt.Errorf("%w: id %d", domain.ErrAlreadyStarted, taskID)
+	}
+
+	// при политике одного таймера сначала завершаем текущую задачу пользователя
+	if state.singleTimer {
+		if err = finishOtherTasks(ctx, tx, state.userID, taskID); err != nil {
+			return err
+		}
+	}
+
+	query := `
 		UPDATE tasks
 		SET start_time = NOW()
 		WHERE id = $1 AND start_time IS NULL; -- Обновляем только если start_time равно NULL
 		`
-		_, err = p.db.Exec(query, taskID)
+	if err = execOne(ctx, tx, fmt.Errorf("%w: id %d", errNotChanged, taskID), query, taskID); err != nil {
+		return err
+	}
 
-		if err != nil {
-			return err
-		}
-	} else {
-		return fmt.Errorf("поле start_time уже заполнено")
+	// открываем первый интервал работы над задачей
+	query = `
+		INSERT INTO task_intervals (task_id, start_time)
+		VALUES ($1, NOW());
+		`
+	if _, err = tx.ExecContext(ctx, query, taskID); err != nil {
+		return err
 	}
 
-	return nil
+	return tx.Commit()
+}
+
+func (p *PostgresStorage) AddEndTime(ctx context.Context, taskID int) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	state, err := lockTask(ctx, tx, taskID)
+	if err != nil {
+		return err
+	}
+	if !state.started {
+		return fmt.Errorf("%w: id %d", domain.ErrNotStarted, taskID)
+	}
+	if state.finished {
+		return fmt.Errorf("%w: id %d", domain.ErrAlreadyFinished, taskID)
+	}
+
+	// закрываем открытый интервал, если задача не стоит на паузе
+	if _, err = tx.ExecContext(ctx, closeIntervalQuery, taskID); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE tasks
+		SET end_time = NOW(),
+			all_time = (` + sumIntervalsQuery + `)
+		WHERE id = $1 AND end_time IS NULL;
+		`
+	if err = execOne(ctx, tx, fmt.Errorf("%w: id %d", errNotChanged, taskID), query, taskID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (p *PostgresStorage) PauseTask(ctx context.Context, taskID int) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	state, err := lockTask(ctx, tx, taskID)
+	if err != nil {
+		return err
+	}
+	if !state.started {
+		return fmt.Errorf("%w: id %d", domain.ErrNotStarted, taskID)
+	}
+	if state.finished {
+		return fmt.Errorf("%w: id %d", domain.ErrAlreadyFinished, taskID)
+	}
+	if !state.running {
+		return fmt.Errorf("%w: id %d", domain.ErrAlreadyPaused, taskID)
+	}
+
+	if err = execOne(ctx, tx, fmt.Errorf("%w: id %d", errNotChanged, taskID), closeIntervalQuery, taskID); err != nil {
+		return err
+	}
+
+	// all_time хранит сумму закрытых интервалов
+	query := `
+		UPDATE tasks
+		SET all_time = (` + sumIntervalsQuery + `)
+		WHERE id = $1;
+		`
+	if _, err = tx.ExecContext(ctx, query, taskID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
-func (p *PostgresStorage) AddEndTime(taskID int) error {
-	task, err := p.ReadTask(taskID)
+func (p *PostgresStorage) ResumeTask(ctx context.Context, taskID int) error {
+	tx, err := p.db.BeginTx(ctx, nil)
 	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
 
+	state, err := lockTask(ctx, tx, taskID)
+	if err != nil {
 		return err
 	}
-	if task.StartTime.Valid {
-		if !task.EndTime.Valid {
-			query := `
-				UPDATE tasks
-				SET end_time = NOW(),
-    				all_time = EXTRACT(EPOCH FROM NOW() - start_time)
-				WHERE id = $1;
-				`
-			_, err = p.db.Exec(query, taskID)
-
-			if err != nil {
-				return err
-			}
-		} else {
-			return fmt.Errorf("поле end_time уже заполнено")
+	if !state.started {
+		return fmt.Errorf("%w: id %d", domain.ErrNotStarted, taskID)
+	}
+	if state.finished {
+		return fmt.Errorf("%w: id %d", domain.ErrAlreadyFinished, taskID)
+	}
+	if state.running {
+		return fmt.Errorf("%w: id %d", domain.ErrAlreadyRunning, taskID)
+	}
+
+	// при политике одного таймера завершаем остальные запущенные задачи пользователя
+	if state.singleTimer {
+		if err = finishOtherTasks(ctx, tx, state.userID, taskID); err != nil {
+			return err
 		}
-	} else {
-		return fmt.Errorf("поле start_time не заполнено")
 	}
 
-	return nil
+	// второй открытый интервал не даст создать уникальный индекс task_intervals_open_idx
+	query := `
+		INSERT INTO task_intervals (task_id, start_time)
+		VALUES ($1, NOW());
+		`
+	if _, err = tx.ExecContext(ctx, query, taskID); err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%w: id %d", domain.ErrAlreadyRunning, taskID)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (p *PostgresStorage) RenameTask(ctx context.Context, taskID int, nameTask string) error {
+	query := `UPDATE tasks SET name_task = $2 WHERE id = $1;`
+	return execOne(ctx, p.db, fmt.Errorf("%w: id %d", domain.ErrTaskNotFound, taskID), query, taskID, nameTask)
+}
+
+func (p *PostgresStorage) DeleteTask(ctx context.Context, taskID int) error {
+	// запущенную задачу не удаляем, чтобы не потерять текущий интервал;
+	// интервалы удаляются каскадно
+	query := `
+		DELETE FROM tasks
+		WHERE id = $1 AND NOT EXISTS (
+			SELECT 1 FROM task_intervals WHERE task_id = $1 AND end_time IS NULL
+		);
+		`
+	err := execOne(ctx, p.db, fmt.Errorf("%w: id %d", domain.ErrTaskRunning, taskID), query, taskID)
+	if errors.Is(err, domain.ErrTaskRunning) {
+		// задачи нет или она выполняется - уточняем причину
+		if _, readErr := p.ReadTask(ctx, taskID); readErr != nil {
+			return readErr
+		}
+	}
+	return err
 }
 
-func (p *PostgresStorage) GetTasksUser(userID int, timeTask models.TaskTime) ([]models.Tasks, error) {
+func (p *PostgresStorage) GetTasksUser(ctx context.Context, userID int, timeTask models.TaskTime) ([]models.Tasks, error) {
 
 	query := `
         SELECT name_task, all_time
@@ -334,7 +560,7 @@ func (p *PostgresStorage) GetTasksUser(userID int, timeTask models.TaskTime) ([]
         ORDER BY all_time DESC;
     `
 
-	rows, err := p.db.Query(query, userID, timeTask.Start, timeTask.End)
+	rows, err := p.db.QueryContext(ctx, query, userID, timeTask.Start, timeTask.End)
 	if err != nil {
 		return nil, err
 	}
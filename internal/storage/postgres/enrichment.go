@@ -0,0 +1,181 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/models"
+)
+
+// CreatePendingUser добавляет пользователя только с номером паспорта и ставит в очередь
+// задачу обогащения его данных
+func (p *PostgresStorage) CreatePendingUser(ctx context.Context, passportNumber string) (int, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO users (passport_number, surname, name, patronymic, address, enrichment_status)
+		VALUES ($1, '', '', '', '', 'pending')
+		RETURNING id;
+		`
+	var userID int
+	if err = tx.QueryRowContext(ctx, query, passportNumber).Scan(&userID); err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("%w: %s", domain.ErrDuplicatePassport, passportNumber)
+		}
+		return 0, err
+	}
+
+	if _, err = tx.ExecContext(ctx, `INSERT INTO enrichment_jobs (user_id) VALUES ($1);`, userID); err != nil {
+		return 0, err
+	}
+	return userID, tx.Commit()
+}
+
+// ReadEnrichmentStatus возвращает состояние обогащения данных пользователя
+func (p *PostgresStorage) ReadEnrichmentStatus(ctx context.Context, userID int) (models.EnrichmentStatus, error) {
+	query := `
+		SELECT u.enrichment_status, COALESCE(j.attempts, 0), COALESCE(j.last_error, ''), j.run_at, j.updated_at
+		FROM users u
+		LEFT JOIN enrichment_jobs j ON j.user_id = u.id
+		WHERE u.id = $1;
+		`
+	status := models.EnrichmentStatus{UserID: userID}
+	var runAt, updatedAt sql.NullTime
+	err := p.db.QueryRowContext(ctx, query, userID).Scan(&status.Status, &status.Attempts, &status.LastError, &runAt, &updatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.EnrichmentStatus{}, fmt.Errorf("%w: id %d", domain.ErrUserNotFound, userID)
+		}
+		return models.EnrichmentStatus{}, err
+	}
+
+	// срок следующей попытки имеет смысл только для задачи в очереди
+	if runAt.Valid && status.Status == models.EnrichmentPending {
+		status.NextAttemptAt = &runAt.Time
+	}
+	if updatedAt.Valid {
+		status.UpdatedAt = &updatedAt.Time
+	}
+	return status, nil
+}
+
+// RetryEnrichment заново ставит в очередь обогащение данных пользователя: задача выполняется
+// сразу, счетчик попыток и последняя ошибка сбрасываются
+func (p *PostgresStorage) RetryEnrichment(ctx context.Context, userID int) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = execOne(ctx, tx, fmt.Errorf("%w: id %d", domain.ErrUserNotFound, userID),
+		`UPDATE users SET enrichment_status = 'pending' WHERE id = $1;`, userID)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO enrichment_jobs (user_id) VALUES ($1)
+		ON CONFLICT (user_id) DO UPDATE
+		SET attempts = 0, run_at = NOW(), last_error = NULL, updated_at = NOW();
+		`
+	if _, err = tx.ExecContext(ctx, query, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ClaimEnrichmentJob забирает задачу обогащения, срок которой наступил, и откладывает ее на lease:
+// если обработчик не завершит задачу за это время, ее возьмет другой. ok - false, если задач нет.
+func (p *PostgresStorage) ClaimEnrichmentJob(ctx context.Context, lease time.Duration) (models.EnrichmentJob, bool, error) {
+	query := `
+		UPDATE enrichment_jobs j
+		SET attempts = j.attempts + 1, run_at = NOW() + make_interval(secs => $1), updated_at = NOW()
+		FROM users u
+		WHERE u.id = j.user_id AND j.user_id = (
+			SELECT q.user_id
+			FROM enrichment_jobs q
+			JOIN users qu ON qu.id = q.user_id
+			WHERE qu.enrichment_status = 'pending' AND q.run_at <= NOW()
+			ORDER BY q.run_at
+			LIMIT 1
+			FOR UPDATE OF q SKIP LOCKED
+		)
+		RETURNING j.user_id, u.passport_number, j.attempts;
+		`
+	var job models.EnrichmentJob
+	err := p.db.QueryRowContext(ctx, query, lease.Seconds()).Scan(&job.UserID, &job.PassportNumber, &job.Attempts)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.EnrichmentJob{}, false, nil
+		}
+		return models.EnrichmentJob{}, false, err
+	}
+	return job, true, nil
+}
+
+// CompleteEnrichmentJob записывает данные из стороннего API и отмечает обогащение выполненным.
+// Заполняются только пустые поля: правки, сделанные, пока задача ждала в очереди, не затираются.
+func (p *PostgresStorage) CompleteEnrichmentJob(ctx context.Context, userID int, userData models.UserData) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE users
+		SET surname = COALESCE(NULLIF(surname, ''), $2),
+			name = COALESCE(NULLIF(name, ''), $3),
+			patronymic = COALESCE(NULLIF(patronymic, ''), $4),
+			address = COALESCE(NULLIF(address, ''), $5),
+			enrichment_status = 'done'
+		WHERE id = $1;
+		`
+	err = execOne(ctx, tx, fmt.Errorf("%w: id %d", domain.ErrUserNotFound, userID),
+		query, userID, userData.Surname, userData.Name, userData.Patronymic, userData.Address)
+	if err != nil {
+		return err
+	}
+
+	query = `UPDATE enrichment_jobs SET last_error = NULL, updated_at = NOW() WHERE user_id = $1;`
+	if _, err = tx.ExecContext(ctx, query, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RescheduleEnrichmentJob откладывает задачу обогащения до runAt после неудачной попытки
+func (p *PostgresStorage) RescheduleEnrichmentJob(ctx context.Context, userID int, runAt time.Time, lastError string) error {
+	query := `UPDATE enrichment_jobs SET run_at = $2, last_error = $3, updated_at = NOW() WHERE user_id = $1;`
+	return execOne(ctx, p.db, fmt.Errorf("%w: id %d", domain.ErrUserNotFound, userID), query, userID, runAt, lastError)
+}
+
+// FailEnrichmentJob отмечает обогащение неудавшимся; задача остается в таблице для просмотра
+// состояния и повторного запуска
+func (p *PostgresStorage) FailEnrichmentJob(ctx context.Context, userID int, lastError string) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = execOne(ctx, tx, fmt.Errorf("%w: id %d", domain.ErrUserNotFound, userID),
+		`UPDATE users SET enrichment_status = 'failed' WHERE id = $1;`, userID)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE enrichment_jobs SET last_error = $2, updated_at = NOW() WHERE user_id = $1;`
+	if _, err = tx.ExecContext(ctx, query, userID, lastError); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
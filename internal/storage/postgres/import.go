@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/models"
+)
+
+// ImportTasks добавляет записи импорта как ручные задачи. Записи, уже загруженные ранее
+// (тот же external_id), и записи, пересекающиеся с задачами пользователя или с предыдущими
+// записями файла, пропускаются и попадают в Issues.
+// При dryRun все проверки выполняются в той же транзакции, но она откатывается, поэтому
+// отчет совпадает с тем, что даст настоящий импорт.
+func (p *PostgresStorage) ImportTasks(ctx context.Context, userID int, entries []models.ImportEntry, dryRun bool) (models.ImportResult, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.ImportResult{}, err
+	}
+	defer tx.Rollback()
+
+	// блокируем пользователя, как и при создании ручной задачи
+	query := `SELECT id FROM users WHERE id = $1 FOR UPDATE;`
+	if err = tx.QueryRowContext(ctx, query, userID).Scan(&userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.ImportResult{}, fmt.Errorf("%w: id %d", domain.ErrUserNotFound, userID)
+		}
+		return models.ImportResult{}, err
+	}
+
+	result := models.ImportResult{DryRun: dryRun, Total: len(entries), Issues: []models.ImportIssue{}}
+	duplicateQuery := `SELECT EXISTS (SELECT 1 FROM tasks WHERE user_id = $1 AND external_id = $2);`
+
+	for _, entry := range entries {
+		issue := models.ImportIssue{Line: entry.Line, ExternalID: entry.ExternalID, NameTask: entry.NameTask}
+
+		var duplicate bool
+		if err = tx.QueryRowContext(ctx, duplicateQuery, userID, entry.ExternalID).Scan(&duplicate); err != nil {
+			return models.ImportResult{}, err
+		}
+		if duplicate {
+			issue.Reason = models.ImportIssueDuplicate
+			issue.Message = "запись уже импортирована"
+			result.Duplicates++
+			result.Issues = append(result.Issues, issue)
+			continue
+		}
+
+		err = checkOverlap(ctx, tx, userID, 0, entry.Start, entry.End)
+		if errors.Is(err, domain.ErrTimeOverlap) {
+			issue.Reason = models.ImportIssueOverlap
+			issue.Message = err.Error()
+			result.Skipped++
+			result.Issues = append(result.Issues, issue)
+			continue
+		}
+		if err != nil {
+			return models.ImportResult{}, err
+		}
+
+		externalID := sql.NullString{String: entry.ExternalID, Valid: entry.ExternalID != ""}
+		taskID, err := insertManualTask(ctx, tx, userID, entry.NameTask, externalID, entry.Start, entry.End)
+		if err != nil {
+			return models.ImportResult{}, err
+		}
+		result.Imported++
+		if !dryRun {
+			result.TaskIDs = append(result.TaskIDs, taskID)
+		}
+	}
+
+	if dryRun {
+		return result, nil
+	}
+	return result, tx.Commit()
+}
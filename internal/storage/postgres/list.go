@@ -0,0 +1,293 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/jackc/pgx/v5/pgtype"
+	"strconv"
+	"strings"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/models"
+)
+
+// taskListQuery - задачи пользователя с вычисленными статусом и длительностью.
+// Для запущенной задачи к сумме закрытых интервалов добавляется время открытого.
+// Теги задачи собираются в массив по алфавиту, для выгрузки добавляются названия проекта и клиента.
+const taskListQuery = `
+	SELECT t.id, t.user_id, t.name_task, t.start_time, t.end_time,
+		CASE
+			WHEN t.start_time IS NULL THEN 'not_started'
+			WHEN t.end_time IS NOT NULL THEN 'finished'
+			WHEN oi.start_time IS NOT NULL THEN 'running'
+			ELSE 'paused'
+		END AS status,
+		COALESCE(t.all_time, 0) + COALESCE(EXTRACT(EPOCH FROM NOW() - oi.start_time), 0)::BIGINT AS duration,
+		COALESCE(t.all_time, 0) AS stored_duration,
+		t.is_manual, t.project_id, p.client_id,
+		COALESCE(p.name, '') AS project_name, COALESCE(c.name, '') AS client_name,
+		ARRAY(SELECT g.name FROM task_tags tt JOIN tags g ON g.id = tt.tag_id WHERE tt.task_id = t.id ORDER BY g.name) AS tags
+	FROM tasks t
+	LEFT JOIN task_intervals oi ON oi.task_id = t.id AND oi.end_time IS NULL
+	LEFT JOIN projects p ON p.id = t.project_id
+	LEFT JOIN clients c ON c.id = p.client_id
+	WHERE t.user_id = $1
+`
+
+// userTimeZoneQuery - часовой пояс пользователя $1 из его настроек
+const userTimeZoneQuery = `(SELECT time_zone FROM users WHERE id = $1)`
+
+// sortColumn - выражение для сортировки и тип, к которому приводится текстовое значение из курсора
+type sortColumn struct {
+	expr    string
+	sqlType string
+}
+
+// пустые даты сортируются как самые ранние, чтобы keyset-пагинация не спотыкалась о NULL.
+// Длительность сортируется по сохраненному all_time без текущего интервала запущенной задачи:
+// duration растет с каждым запросом, и ключ из курсора к следующей странице уже устарел бы.
+var taskSortColumns = map[string]sortColumn{
+	models.TaskSortID:        {"id", "INT"},
+	models.TaskSortName:      {"name_task", "TEXT"},
+	models.TaskSortStartTime: {"COALESCE(start_time, '-infinity'::TIMESTAMP)", "TIMESTAMP"},
+	models.TaskSortEndTime:   {"COALESCE(end_time, '-infinity'::TIMESTAMP)", "TIMESTAMP"},
+	models.TaskSortDuration:  {"stored_duration", "BIGINT"},
+}
+
+// taskCursor - позиция последней выданной задачи. Сортировка сохраняется в курсоре,
+// чтобы курсор нельзя было применить к выборке с другим порядком.
+type taskCursor struct {
+	SortBy   string `json:"s"`
+	SortDesc bool   `json:"d"`
+	Key      string `json:"k"`
+	TaskID   int    `json:"i"`
+}
+
+func encodeTaskCursor(c taskCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeTaskCursor(s string, filter models.TaskFilter) (taskCursor, error) {
+	invalid := domain.NewValidationError("cursor", "invalid cursor")
+
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return taskCursor{}, invalid
+	}
+	var c taskCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return taskCursor{}, invalid
+	}
+	if c.SortBy != filter.SortBy || c.SortDesc != filter.SortDesc {
+		return taskCursor{}, domain.NewValidationError("cursor", "cursor was issued for a different sort order")
+	}
+	return c, nil
+}
+
+// escapeLike экранирует спецсимволы шаблона LIKE
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+func (p *PostgresStorage) ListTasks(ctx context.Context, userID int, filter models.TaskFilter) (models.TaskPage, error) {
+	if _, err := p.Read(ctx, userID); err != nil {
+		return models.TaskPage{}, err
+	}
+
+	column, ok := taskSortColumns[filter.SortBy]
+	if !ok {
+		return models.TaskPage{}, domain.NewValidationError("sort", "unknown sort field")
+	}
+
+	query := `WITH list AS (` + taskListQuery + `)
+		SELECT id, user_id, name_task, start_time, end_time, status, duration, is_manual, project_id, tags, (` + column.expr + `)::TEXT
+		FROM list WHERE 1=1`
+	conditions, args := taskListConditions(filter, []interface{}{userID})
+	query += conditions
+	argCounter := len(args) + 1
+
+	direction, comparison := "ASC", ">"
+	if filter.SortDesc {
+		direction, comparison = "DESC", "<"
+	}
+
+	if filter.Cursor != "" {
+		cursor, err := decodeTaskCursor(filter.Cursor, filter)
+		if err != nil {
+			return models.TaskPage{}, err
+		}
+		query += " AND (" + column.expr + ", id) " + comparison +
+			" ($" + strconv.Itoa(argCounter) + "::TEXT::" + column.sqlType + ", $" + strconv.Itoa(argCounter+1) + ")"
+		args = append(args, cursor.Key, cursor.TaskID)
+		argCounter += 2
+	}
+
+	// берем на одну запись больше, чтобы понять, есть ли следующая страница
+	query += " ORDER BY " + column.expr + " " + direction + ", id " + direction
+	query += " LIMIT $" + strconv.Itoa(argCounter)
+	args = append(args, filter.Limit+1)
+
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return models.TaskPage{}, err
+	}
+	defer rows.Close()
+
+	typeMap := pgtype.NewMap()
+	page := models.TaskPage{Tasks: []models.TaskItem{}}
+	var lastKey string
+	for rows.Next() {
+		var task models.TaskItem
+		var startTime, endTime sql.NullTime
+		var sortKey string
+		if err := rows.Scan(&task.TaskID, &task.UserID, &task.NameTask, &startTime, &endTime,
+			&task.Status, &task.DurationSeconds, &task.IsManual, &task.ProjectID, typeMap.SQLScanner(&task.Tags), &sortKey); err != nil {
+			return models.TaskPage{}, err
+		}
+		if task.Tags == nil {
+			task.Tags = []string{}
+		}
+		if startTime.Valid {
+			task.StartTime = &startTime.Time
+		}
+		if endTime.Valid {
+			task.EndTime = &endTime.Time
+		}
+
+		if len(page.Tasks) == filter.Limit {
+			page.NextCursor = encodeTaskCursor(taskCursor{
+				SortBy:   filter.SortBy,
+				SortDesc: filter.SortDesc,
+				Key:      lastKey,
+				TaskID:   page.Tasks[len(page.Tasks)-1].TaskID,
+			})
+			break
+		}
+		page.Tasks = append(page.Tasks, task)
+		lastKey = sortKey
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.TaskPage{}, err
+	}
+
+	return page, nil
+}
+
+// ExportTasks передает в row все задачи пользователя по фильтру в порядке сортировки списка, читая их
+// прямо из sql.Rows. Курсор и лимит страницы не учитываются. Время - в часовом поясе пользователя.
+func (p *PostgresStorage) ExportTasks(ctx context.Context, userID int, filter models.TaskFilter, row func(models.ExportRow) error) error {
+	user, err := p.Read(ctx, userID)
+	if err != nil {
+		return err
+	}
+	settings, err := p.ReadUserSettings(ctx, userID)
+	if err != nil {
+		return err
+	}
+	userName := strings.Join(strings.Fields(user.Surname+" "+user.Name+" "+user.Patronymic), " ")
+
+	column, ok := taskSortColumns[filter.SortBy]
+	if !ok {
+		return domain.NewValidationError("sort", "unknown sort field")
+	}
+
+	conditions, args := taskListConditions(filter, []interface{}{userID})
+	args = append(args, settings.TimeZone)
+	timeZone := "$" + strconv.Itoa(len(args))
+
+	direction := "ASC"
+	if filter.SortDesc {
+		direction = "DESC"
+	}
+
+	query := `WITH list AS (` + taskListQuery + `)
+		SELECT id, name_task, project_name, client_name,
+			start_time::TIMESTAMPTZ AT TIME ZONE ` + timeZone + `, end_time::TIMESTAMPTZ AT TIME ZONE ` + timeZone + `, duration
+		FROM list WHERE 1=1` + conditions + `
+		ORDER BY ` + column.expr + " " + direction + ", id " + direction
+
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		data := models.ExportRow{UserName: userName}
+		if err := rows.Scan(&data.TaskID, &data.NameTask, &data.ProjectName, &data.ClientName,
+			&data.Start, &data.End, &data.DurationSeconds); err != nil {
+			return err
+		}
+
+		if err := row(data); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// taskListConditions - условия фильтра для выборки из taskListQuery. Параметры условий
+// нумеруются после уже переданных args.
+func taskListConditions(filter models.TaskFilter, args []interface{}) (string, []interface{}) {
+	query := ""
+	argCounter := len(args) + 1
+
+	if filter.Status != "" {
+		query += " AND status = $" + strconv.Itoa(argCounter)
+		args = append(args, filter.Status)
+		argCounter++
+	}
+	if filter.Name != "" {
+		query += " AND name_task ILIKE $" + strconv.Itoa(argCounter)
+		args = append(args, "%"+escapeLike(filter.Name)+"%")
+		argCounter++
+	}
+	if filter.ProjectID != 0 {
+		query += " AND project_id = $" + strconv.Itoa(argCounter)
+		args = append(args, filter.ProjectID)
+		argCounter++
+	}
+	if filter.ClientID != 0 {
+		query += " AND client_id = $" + strconv.Itoa(argCounter)
+		args = append(args, filter.ClientID)
+		argCounter++
+	}
+	if len(filter.Tags) > 0 {
+		// && - есть хотя бы один из тегов, @> - есть все теги
+		operator := " && "
+		if filter.TagMatch == models.TagMatchAll {
+			operator = " @> "
+		}
+		query += " AND tags" + operator + "$" + strconv.Itoa(argCounter) + "::TEXT[]"
+		args = append(args, filter.Tags)
+		argCounter++
+	}
+	// start_time хранится по часовому поясу сессии, поэтому сравниваем моменты времени, а не показания часов
+	if !filter.From.IsZero() {
+		query += " AND start_time::TIMESTAMPTZ >= $" + strconv.Itoa(argCounter) + "::TIMESTAMPTZ"
+		args = append(args, filter.From)
+		argCounter++
+	}
+	if !filter.To.IsZero() {
+		query += " AND start_time::TIMESTAMPTZ < $" + strconv.Itoa(argCounter) + "::TIMESTAMPTZ"
+		args = append(args, filter.To)
+		argCounter++
+	}
+	// границы-даты - полночь FromDay и ToDay+1 в часовом поясе пользователя, как в отчете
+	if !filter.FromDay.IsZero() {
+		query += " AND start_time::TIMESTAMPTZ >= ($" + strconv.Itoa(argCounter) + "::DATE::TIMESTAMP AT TIME ZONE " + userTimeZoneQuery + ")"
+		args = append(args, filter.FromDay)
+		argCounter++
+	}
+	if !filter.ToDay.IsZero() {
+		query += " AND start_time::TIMESTAMPTZ < (($" + strconv.Itoa(argCounter) + "::DATE + 1)::TIMESTAMP AT TIME ZONE " + userTimeZoneQuery + ")"
+		args = append(args, filter.ToDay)
+		argCounter++
+	}
+
+	return query, args
+}
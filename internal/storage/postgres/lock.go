@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time-tracker/internal/domain"
+)
+
+// Блокировки берутся в одном порядке: сначала строка пользователя, затем строка задачи.
+// Так переходы по задачам одного пользователя выполняются последовательно и не ведут к взаимоблокировкам.
+
+// errNotChanged - условный запрос не изменил строку, хотя состояние проверено под блокировкой
+var errNotChanged = errors.New("состояние задачи не изменилось")
+
+// lockTaskOwner блокирует строку владельца задачи до конца транзакции и возвращает его ID
+// и политику одного таймера. Все изменения интервалов пользователя, которые зависят от его
+// других задач, проходят через эту блокировку и поэтому выполняются последовательно.
+func lockTaskOwner(ctx context.Context, tx *sql.Tx, taskID int) (int, bool, error) {
+	query := `
+		SELECT u.id, u.single_timer
+		FROM users u
+		JOIN tasks t ON t.user_id = u.id
+		WHERE t.id = $1
+		FOR UPDATE OF u;
+		`
+	var userID int
+	var singleTimer bool
+	if err := tx.QueryRowContext(ctx, query, taskID).Scan(&userID, &singleTimer); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, fmt.Errorf("%w: id %d", domain.ErrTaskNotFound, taskID)
+		}
+		return 0, false, err
+	}
+	return userID, singleTimer, nil
+}
+
+// taskState - состояние задачи, прочитанное под блокировкой
+type taskState struct {
+	userID      int
+	singleTimer bool
+	started     bool // start_time заполнено
+	finished    bool // end_time заполнено
+	running     bool // есть открытый интервал
+}
+
+// lockTask блокирует владельца и саму задачу до конца транзакции и возвращает её состояние
+func lockTask(ctx context.Context, tx *sql.Tx, taskID int) (taskState, error) {
+	userID, singleTimer, err := lockTaskOwner(ctx, tx, taskID)
+	if err != nil {
+		return taskState{}, err
+	}
+
+	query := `
+		SELECT start_time IS NOT NULL, end_time IS NOT NULL,
+			EXISTS (SELECT 1 FROM task_intervals WHERE task_id = $1 AND end_time IS NULL)
+		FROM tasks
+		WHERE id = $1
+		FOR UPDATE;
+		`
+	state := taskState{userID: userID, singleTimer: singleTimer}
+	err = tx.QueryRowContext(ctx, query, taskID).Scan(&state.started, &state.finished, &state.running)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return taskState{}, fmt.Errorf("%w: id %d", domain.ErrTaskNotFound, taskID)
+		}
+		return taskState{}, err
+	}
+	return state, nil
+}
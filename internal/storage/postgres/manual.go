@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+	"time-tracker/internal/domain"
+)
+
+// Время из запроса приводится к TIMESTAMPTZ, а затем к TIMESTAMP в часовом поясе сессии,
+// чтобы ручные интервалы хранились так же, как интервалы, записанные через NOW().
+
+// overlapQuery проверяет пересечение периода с интервалами других задач пользователя.
+// Открытый интервал считается продолжающимся до текущего момента.
+const overlapQuery = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM task_intervals i
+			JOIN tasks t ON t.id = i.task_id
+			WHERE t.user_id = $1 AND t.id <> $2
+				AND i.start_time < $4::TIMESTAMPTZ::TIMESTAMP
+				AND COALESCE(i.end_time, LOCALTIMESTAMP) > $3::TIMESTAMPTZ::TIMESTAMP
+		);
+		`
+
+// checkOverlap возвращает ErrTimeOverlap, если период пересекается с другими задачами пользователя.
+// taskID - задача, интервалы которой не учитываются (0 для новой задачи).
+func checkOverlap(ctx context.Context, tx *sql.Tx, userID, taskID int, start, end time.Time) error {
+	var overlap bool
+	if err := tx.QueryRowContext(ctx, overlapQuery, userID, taskID, start, end).Scan(&overlap); err != nil {
+		return err
+	}
+	if overlap {
+		return fmt.Errorf("%w: %s - %s", domain.ErrTimeOverlap, start.Format(time.RFC3339), end.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// CreateManualTask создает завершенную задачу с одним интервалом [start, end).
+// Строка пользователя блокируется до конца транзакции, чтобы параллельные ручные
+// записи не прошли проверку пересечения одновременно.
+func (p *PostgresStorage) CreateManualTask(ctx context.Context, userID int, nameTask string, start, end time.Time) (int, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	query := `SELECT id FROM users WHERE id = $1 FOR UPDATE;`
+	if err = tx.QueryRowContext(ctx, query, userID).Scan(&userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("%w: id %d", domain.ErrUserNotFound, userID)
+		}
+		return 0, err
+	}
+
+	if err = checkOverlap(ctx, tx, userID, 0, start, end); err != nil {
+		return 0, err
+	}
+
+	taskID, err := insertManualTask(ctx, tx, userID, nameTask, sql.NullString{}, start, end)
+	if err != nil {
+		return 0, err
+	}
+
+	return taskID, tx.Commit()
+}
+
+// insertManualTask добавляет завершенную задачу с одним интервалом [start, end)
+func insertManualTask(ctx context.Context, tx *sql.Tx, userID int, nameTask string, externalID sql.NullString, start, end time.Time) (int, error) {
+	query := `
+		INSERT INTO tasks (user_id, name_task, start_time, end_time, all_time, is_manual, external_id)
+		VALUES ($1, $2, $3::TIMESTAMPTZ::TIMESTAMP, $4::TIMESTAMPTZ::TIMESTAMP, $5, TRUE, $6)
+		RETURNING id;
+		`
+	var taskID int
+	err := tx.QueryRowContext(ctx, query, userID, nameTask, start, end, int64(end.Sub(start).Seconds()), externalID).Scan(&taskID)
+	if err != nil {
+		return 0, err
+	}
+
+	query = `
+		INSERT INTO task_intervals (task_id, start_time, end_time)
+		VALUES ($1, $2::TIMESTAMPTZ::TIMESTAMP, $3::TIMESTAMPTZ::TIMESTAMP);
+		`
+	if _, err = tx.ExecContext(ctx, query, taskID, start, end); err != nil {
+		return 0, err
+	}
+	return taskID, nil
+}
+
+// UpdateTaskTime задает задаче время начала и окончания вручную. Все интервалы задачи
+// заменяются одним интервалом [start, end), поэтому запущенная задача становится завершенной.
+func (p *PostgresStorage) UpdateTaskTime(ctx context.Context, taskID int, start, end time.Time) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// блокируем владельца задачи, как и при создании ручной задачи
+	userID, _, err := lockTaskOwner(ctx, tx, taskID)
+	if err != nil {
+		return err
+	}
+
+	if err = checkOverlap(ctx, tx, userID, taskID, start, end); err != nil {
+		return err
+	}
+
+	query := `DELETE FROM task_intervals WHERE task_id = $1;`
+	if _, err = tx.ExecContext(ctx, query, taskID); err != nil {
+		return err
+	}
+
+	query = `
+		INSERT INTO task_intervals (task_id, start_time, end_time)
+		VALUES ($1, $2::TIMESTAMPTZ::TIMESTAMP, $3::TIMESTAMPTZ::TIMESTAMP);
+		`
+	if _, err = tx.ExecContext(ctx, query, taskID, start, end); err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE tasks
+		SET start_time = $2::TIMESTAMPTZ::TIMESTAMP,
+			end_time = $3::TIMESTAMPTZ::TIMESTAMP,
+			all_time = $4,
+			is_manual = TRUE
+		WHERE id = $1;
+		`
+	err = execOne(ctx, tx, fmt.Errorf("%w: id %d", domain.ErrTaskNotFound, taskID),
+		query, taskID, start, end, int64(end.Sub(start).Seconds()))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
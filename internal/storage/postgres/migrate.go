@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"time-tracker/internal/config"
+	"time-tracker/migrations"
+)
+
+// openDB открывает пул соединений с БД из конфигурации и проверяет подключение
+func openDB(conf *config.Config) (*sql.DB, error) {
+	dsn := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable",
+		conf.DB_LOGIN, conf.DB_PASS, conf.DB_HOST, conf.DB_PORT, conf.DB_NAME)
+
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// NewMigrate создает golang-migrate для встроенных в бинарник миграций.
+// Close у результата закрывает и db.
+func NewMigrate(db *sql.DB) (*migrate.Migrate, error) {
+	source, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	driver, err := postgres.WithInstance(db, &postgres.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	return migrate.NewWithInstance("iofs", source, "postgres", driver)
+}
+
+// OpenMigrate подключается к БД из конфигурации для ручного управления миграциями
+func OpenMigrate(conf *config.Config) (*migrate.Migrate, error) {
+	db, err := openDB(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := NewMigrate(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/models"
+)
+
+func (p *PostgresStorage) CreateClient(ctx context.Context, name string) (int, error) {
+	query := `INSERT INTO clients (name) VALUES ($1) RETURNING id;`
+
+	var clientID int
+	if err := p.db.QueryRowContext(ctx, query, name).Scan(&clientID); err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("%w: клиент %q", domain.ErrDuplicateName, name)
+		}
+		return 0, err
+	}
+	return clientID, nil
+}
+
+func (p *PostgresStorage) ReadClient(ctx context.Context, clientID int) (models.Client, error) {
+	query := `SELECT id, name FROM clients WHERE id = $1;`
+
+	client := models.Client{}
+	if err := p.db.QueryRowContext(ctx, query, clientID).Scan(&client.ClientID, &client.Name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Client{}, fmt.Errorf("%w: id %d", domain.ErrClientNotFound, clientID)
+		}
+		return models.Client{}, err
+	}
+	return client, nil
+}
+
+func (p *PostgresStorage) ListClients(ctx context.Context) ([]models.Client, error) {
+	query := `SELECT id, name FROM clients ORDER BY name, id;`
+
+	rows, err := p.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	clients := []models.Client{}
+	for rows.Next() {
+		var client models.Client
+		if err := rows.Scan(&client.ClientID, &client.Name); err != nil {
+			return nil, err
+		}
+		clients = append(clients, client)
+	}
+	return clients, rows.Err()
+}
+
+func (p *PostgresStorage) UpdateClient(ctx context.Context, clientID int, name string) error {
+	query := `UPDATE clients SET name = $2 WHERE id = $1;`
+
+	err := execOne(ctx, p.db, fmt.Errorf("%w: id %d", domain.ErrClientNotFound, clientID), query, clientID, name)
+	if isUniqueViolation(err) {
+		return fmt.Errorf("%w: клиент %q", domain.ErrDuplicateName, name)
+	}
+	return err
+}
+
+// DeleteClient удаляет клиента без проектов
+func (p *PostgresStorage) DeleteClient(ctx context.Context, clientID int) error {
+	query := `DELETE FROM clients WHERE id = $1;`
+
+	err := execOne(ctx, p.db, fmt.Errorf("%w: id %d", domain.ErrClientNotFound, clientID), query, clientID)
+	if isForeignKeyViolation(err) {
+		return fmt.Errorf("%w: id %d", domain.ErrClientHasProjects, clientID)
+	}
+	return err
+}
+
+func (p *PostgresStorage) CreateProject(ctx context.Context, project models.ProjectRequest) (int, error) {
+	query := `INSERT INTO projects (name, client_id) VALUES ($1, $2) RETURNING id;`
+
+	var projectID int
+	if err := p.db.QueryRowContext(ctx, query, project.Name, project.ClientID).Scan(&projectID); err != nil {
+		return 0, projectError(err, project)
+	}
+	return projectID, nil
+}
+
+func (p *PostgresStorage) ReadProject(ctx context.Context, projectID int) (models.Project, error) {
+	query := `SELECT id, client_id, name FROM projects WHERE id = $1;`
+
+	project := models.Project{}
+	err := p.db.QueryRowContext(ctx, query, projectID).Scan(&project.ProjectID, &project.ClientID, &project.Name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Project{}, fmt.Errorf("%w: id %d", domain.ErrProjectNotFound, projectID)
+		}
+		return models.Project{}, err
+	}
+	return project, nil
+}
+
+// ListProjects возвращает проекты клиента clientID или все проекты, если clientID = 0
+func (p *PostgresStorage) ListProjects(ctx context.Context, clientID int) ([]models.Project, error) {
+	if clientID != 0 {
+		if _, err := p.ReadClient(ctx, clientID); err != nil {
+			return nil, err
+		}
+	}
+
+	query := `
+		SELECT id, client_id, name
+		FROM projects
+		WHERE $1 = 0 OR client_id = $1
+		ORDER BY name, id;
+		`
+	rows, err := p.db.QueryContext(ctx, query, clientID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	projects := []models.Project{}
+	for rows.Next() {
+		var project models.Project
+		if err := rows.Scan(&project.ProjectID, &project.ClientID, &project.Name); err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+	return projects, rows.Err()
+}
+
+// UpdateProject меняет название и клиента проекта; ClientID = nil делает проект внутренним
+func (p *PostgresStorage) UpdateProject(ctx context.Context, projectID int, project models.ProjectRequest) error {
+	query := `UPDATE projects SET name = $2, client_id = $3 WHERE id = $1;`
+
+	err := execOne(ctx, p.db, fmt.Errorf("%w: id %d", domain.ErrProjectNotFound, projectID), query, projectID, project.Name, project.ClientID)
+	if err != nil {
+		return projectError(err, project)
+	}
+	return nil
+}
+
+// DeleteProject удаляет проект; его задачи остаются без проекта
+func (p *PostgresStorage) DeleteProject(ctx context.Context, projectID int) error {
+	query := `DELETE FROM projects WHERE id = $1;`
+	return execOne(ctx, p.db, fmt.Errorf("%w: id %d", domain.ErrProjectNotFound, projectID), query, projectID)
+}
+
+// SetTaskProject привязывает задачу к проекту; projectID = nil снимает задачу с проекта
+func (p *PostgresStorage) SetTaskProject(ctx context.Context, taskID int, projectID *int) error {
+	query := `UPDATE tasks SET project_id = $2 WHERE id = $1;`
+
+	err := execOne(ctx, p.db, fmt.Errorf("%w: id %d", domain.ErrTaskNotFound, taskID), query, taskID, projectID)
+	if isForeignKeyViolation(err) {
+		return fmt.Errorf("%w: id %d", domain.ErrProjectNotFound, *projectID)
+	}
+	return err
+}
+
+// projectError переводит нарушения ограничений таблицы projects в ошибки предметной области
+func projectError(err error, project models.ProjectRequest) error {
+	switch {
+	case isUniqueViolation(err):
+		return fmt.Errorf("%w: проект %q", domain.ErrDuplicateName, project.Name)
+	case isForeignKeyViolation(err):
+		return fmt.Errorf("%w: id %d", domain.ErrClientNotFound, *project.ClientID)
+	}
+	return err
+}
@@ -0,0 +1,236 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+	"time-tracker/internal/models"
+)
+
+// Интервалы хранятся в TIMESTAMP по часовому поясу сессии, поэтому сначала приводятся к TIMESTAMPTZ.
+// Границы отчета - полночь дат From и To+1 в часовом поясе пользователя ($4).
+// Открытый интервал запущенной задачи считается до текущего момента.
+// $5 и $6 - необязательные фильтры по проекту и клиенту (NULL - без фильтра),
+// $7 - теги (NULL - без фильтра), $8 - нужны ли задаче все теги из $7 или хотя бы один.
+const reportIntervalsQuery = `
+	WITH bounds AS (
+		SELECT $2::DATE::TIMESTAMP AT TIME ZONE $4 AS from_ts,
+			($3::DATE + 1)::TIMESTAMP AT TIME ZONE $4 AS to_ts
+	)
+	SELECT t.id AS task_id, t.name_task,
+		t.project_id, COALESCE(p.name, '') AS project_name,
+		p.client_id, COALESCE(c.name, '') AS client_name,
+		GREATEST(i.start_time::TIMESTAMPTZ, b.from_ts) AS s,
+		LEAST(COALESCE(i.end_time::TIMESTAMPTZ, NOW()), b.to_ts) AS e,
+		i.end_time IS NULL AND NOW() <= b.to_ts AS running
+	FROM task_intervals i
+	JOIN tasks t ON t.id = i.task_id
+	LEFT JOIN projects p ON p.id = t.project_id
+	LEFT JOIN clients c ON c.id = p.client_id
+	CROSS JOIN bounds b
+	WHERE t.user_id = $1
+		AND ($5::INT IS NULL OR t.project_id = $5)
+		AND ($6::INT IS NULL OR p.client_id = $6)
+		AND ($7::TEXT[] IS NULL OR EXISTS (
+			SELECT 1 FROM task_tags tt JOIN tags g ON g.id = tt.tag_id
+			WHERE tt.task_id = t.id AND g.name = ANY($7)
+			HAVING COUNT(*) >= CASE WHEN $8::BOOLEAN THEN cardinality($7) ELSE 1 END
+		))
+		AND i.start_time::TIMESTAMPTZ < b.to_ts
+		AND COALESCE(i.end_time::TIMESTAMPTZ, NOW()) > b.from_ts
+`
+
+// reportByPeriodQuery разбивает интервалы по дням/неделям/месяцам ($9) в часовом поясе пользователя.
+// Интервал, переходящий через границу, делится между соседними периодами.
+const reportByPeriodQuery = `
+	WITH intervals AS (` + reportIntervalsQuery + `),
+	pieces AS (
+		SELECT p.period,
+			GREATEST(iv.s, p.period AT TIME ZONE $4) AS s,
+			LEAST(iv.e, (p.period + ('1 ' || $9::TEXT)::INTERVAL) AT TIME ZONE $4) AS e
+		FROM intervals iv
+		CROSS JOIN LATERAL generate_series(
+			date_trunc($9::TEXT, iv.s AT TIME ZONE $4),
+			date_trunc($9::TEXT, iv.e AT TIME ZONE $4),
+			('1 ' || $9::TEXT)::INTERVAL
+		) AS p(period)
+	)
+	SELECT period, SUM(EXTRACT(EPOCH FROM e - s))::BIGINT
+	FROM pieces
+	WHERE e > s
+	GROUP BY period
+	ORDER BY period;
+`
+
+const reportByTaskQuery = `
+	WITH intervals AS (` + reportIntervalsQuery + `)
+	SELECT task_id, name_task, SUM(EXTRACT(EPOCH FROM e - s))::BIGINT AS total
+	FROM intervals
+	GROUP BY task_id, name_task
+	ORDER BY total DESC, task_id;
+`
+
+const reportByProjectQuery = `
+	WITH intervals AS (` + reportIntervalsQuery + `)
+	SELECT project_id, project_name, client_id, client_name, SUM(EXTRACT(EPOCH FROM e - s))::BIGINT AS total
+	FROM intervals
+	GROUP BY project_id, project_name, client_id, client_name
+	ORDER BY total DESC, project_id NULLS LAST;
+`
+
+const reportByClientQuery = `
+	WITH intervals AS (` + reportIntervalsQuery + `)
+	SELECT client_id, client_name, SUM(EXTRACT(EPOCH FROM e - s))::BIGINT AS total
+	FROM intervals
+	GROUP BY client_id, client_name
+	ORDER BY total DESC, client_id NULLS LAST;
+`
+
+// reportByTagQuery учитывает задачу с несколькими тегами в каждом из них,
+// поэтому общий итог без повторов считается отдельной колонкой
+const reportByTagQuery = `
+	WITH intervals AS (` + reportIntervalsQuery + `)
+	SELECT COALESCE(g.name, '') AS tag, SUM(EXTRACT(EPOCH FROM iv.e - iv.s))::BIGINT AS total,
+		(SELECT COALESCE(SUM(EXTRACT(EPOCH FROM e - s)), 0)::BIGINT FROM intervals)
+	FROM intervals iv
+	LEFT JOIN task_tags tt ON tt.task_id = iv.task_id
+	LEFT JOIN tags g ON g.id = tt.tag_id
+	GROUP BY g.name
+	ORDER BY total DESC, g.name NULLS LAST;
+`
+
+// reportExportQuery - интервалы периода по одному в строке, время в часовом поясе пользователя
+const reportExportQuery = `
+	WITH intervals AS (` + reportIntervalsQuery + `)
+	SELECT task_id, name_task, project_name, client_name, s AT TIME ZONE $4, e AT TIME ZONE $4, running,
+		EXTRACT(EPOCH FROM e - s)::BIGINT
+	FROM intervals
+	ORDER BY s, task_id;
+`
+
+// Report считает время пользователя за период с группировкой по периодам, задачам, проектам, клиентам или тегам
+func (p *PostgresStorage) Report(ctx context.Context, userID int, filter models.ReportFilter) (models.Report, error) {
+	settings, err := p.ReadUserSettings(ctx, userID)
+	if err != nil {
+		return models.Report{}, err
+	}
+
+	report := models.Report{
+		UserID:    userID,
+		From:      filter.From.Format(time.DateOnly),
+		To:        filter.To.Format(time.DateOnly),
+		TimeZone:  settings.TimeZone,
+		GroupBy:   filter.GroupBy,
+		ProjectID: filter.ProjectID,
+		ClientID:  filter.ClientID,
+		Tags:      filter.Tags,
+		TagMatch:  filter.TagMatch,
+		Buckets:   []models.ReportBucket{},
+	}
+
+	args := reportArgs(userID, filter, settings.TimeZone)
+	var query string
+	switch filter.GroupBy {
+	case models.ReportGroupTask:
+		query = reportByTaskQuery
+	case models.ReportGroupProject:
+		query = reportByProjectQuery
+	case models.ReportGroupClient:
+		query = reportByClientQuery
+	case models.ReportGroupTag:
+		query = reportByTagQuery
+	default:
+		query, args = reportByPeriodQuery, append(args, filter.GroupBy)
+	}
+
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return models.Report{}, err
+	}
+	defer rows.Close()
+
+	var tagTotal int64
+	for rows.Next() {
+		var bucket models.ReportBucket
+		switch filter.GroupBy {
+		case models.ReportGroupTask:
+			err = rows.Scan(&bucket.TaskID, &bucket.NameTask, &bucket.TotalSeconds)
+		case models.ReportGroupProject:
+			err = rows.Scan(&bucket.ProjectID, &bucket.ProjectName, &bucket.ClientID, &bucket.ClientName, &bucket.TotalSeconds)
+		case models.ReportGroupClient:
+			err = rows.Scan(&bucket.ClientID, &bucket.ClientName, &bucket.TotalSeconds)
+		case models.ReportGroupTag:
+			err = rows.Scan(&bucket.Tag, &bucket.TotalSeconds, &tagTotal)
+		default:
+			var period time.Time
+			err = rows.Scan(&period, &bucket.TotalSeconds)
+			bucket.Period = period.Format(time.DateOnly)
+		}
+		if err != nil {
+			return models.Report{}, err
+		}
+		report.Buckets = append(report.Buckets, bucket)
+		report.TotalSeconds += bucket.TotalSeconds
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.Report{}, err
+	}
+	if filter.GroupBy == models.ReportGroupTag {
+		report.TotalSeconds = tagTotal
+	}
+
+	return report, nil
+}
+
+// ExportReport передает в row интервалы пользователя за период по одному, читая их прямо из sql.Rows,
+// чтобы большая выгрузка не собиралась в памяти целиком. Интервалы обрезаются по границам периода,
+// поэтому сумма длительностей совпадает с отчетом. Ошибка из row прерывает выгрузку.
+func (p *PostgresStorage) ExportReport(ctx context.Context, userID int, filter models.ReportFilter, row func(models.ExportRow) error) error {
+	user, err := p.Read(ctx, userID)
+	if err != nil {
+		return err
+	}
+	settings, err := p.ReadUserSettings(ctx, userID)
+	if err != nil {
+		return err
+	}
+	userName := strings.Join(strings.Fields(user.Surname+" "+user.Name+" "+user.Patronymic), " ")
+
+	rows, err := p.db.QueryContext(ctx, reportExportQuery, reportArgs(userID, filter, settings.TimeZone)...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		data := models.ExportRow{UserName: userName}
+		var start, end time.Time
+		var running bool
+		if err := rows.Scan(&data.TaskID, &data.NameTask, &data.ProjectName, &data.ClientName,
+			&start, &end, &running, &data.DurationSeconds); err != nil {
+			return err
+		}
+		data.Start = sql.NullTime{Time: start, Valid: true}
+		data.End = sql.NullTime{Time: end, Valid: !running}
+
+		if err := row(data); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// reportArgs - параметры $1-$8 запроса reportIntervalsQuery
+func reportArgs(userID int, filter models.ReportFilter, timeZone string) []interface{} {
+	projectID := sql.NullInt64{Int64: int64(filter.ProjectID), Valid: filter.ProjectID != 0}
+	clientID := sql.NullInt64{Int64: int64(filter.ClientID), Valid: filter.ClientID != 0}
+	var tags interface{}
+	if len(filter.Tags) > 0 {
+		tags = filter.Tags
+	}
+	return []interface{}{userID, filter.From, filter.To, timeZone, projectID, clientID,
+		tags, filter.TagMatch == models.TagMatchAll}
+}
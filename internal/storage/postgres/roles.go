@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/models"
+)
+
+// ListRoles возвращает роли в порядке расширения прав
+func (p *PostgresStorage) ListRoles(ctx context.Context) ([]models.Role, error) {
+	query := `
+		SELECT name, description FROM roles
+		ORDER BY CASE name WHEN 'member' THEN 1 WHEN 'manager' THEN 2 WHEN 'admin' THEN 3 ELSE 4 END, name;
+		`
+	rows, err := p.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := []models.Role{}
+	for rows.Next() {
+		var role models.Role
+		if err := rows.Scan(&role.Name, &role.Description); err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	return roles, rows.Err()
+}
+
+// SetUserManager записывает пользователя в команду менеджера managerID; nil исключает из команды
+func (p *PostgresStorage) SetUserManager(ctx context.Context, userID int, managerID *int) error {
+	query := `UPDATE users SET manager_id = $2 WHERE id = $1;`
+	err := execOne(ctx, p.db, fmt.Errorf("%w: id %d", domain.ErrUserNotFound, userID), query, userID, managerID)
+	if isForeignKeyViolation(err) {
+		return fmt.Errorf("%w: менеджер id %d", domain.ErrUserNotFound, *managerID)
+	}
+	return err
+}
+
+// IsTeamMember сообщает, входит ли пользователь userID в команду менеджера managerID
+func (p *PostgresStorage) IsTeamMember(ctx context.Context, managerID, userID int) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1 AND manager_id = $2);`
+
+	var member bool
+	err := p.db.QueryRowContext(ctx, query, userID, managerID).Scan(&member)
+	return member, err
+}
+
+// ListTeam возвращает пользователей из команды менеджера managerID
+func (p *PostgresStorage) ListTeam(ctx context.Context, managerID int) ([]models.UserData, error) {
+	query := `
+		SELECT id, passport_number, surname, name, patronymic, address
+		FROM users WHERE manager_id = $1
+		ORDER BY id ASC;
+		`
+	rows, err := p.db.QueryContext(ctx, query, managerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []models.UserData{}
+	for rows.Next() {
+		var user models.UserData
+		if err := rows.Scan(&user.UserID, &user.PassportNumber, &user.Surname, &user.Name, &user.Patronymic, &user.Address); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
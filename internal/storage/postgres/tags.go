@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/models"
+)
+
+func (p *PostgresStorage) ListTags(ctx context.Context) ([]models.Tag, error) {
+	query := `SELECT id, name FROM tags ORDER BY name;`
+
+	rows, err := p.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []models.Tag{}
+	for rows.Next() {
+		var tag models.Tag
+		if err := rows.Scan(&tag.TagID, &tag.Name); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+// AddTaskTags добавляет задаче теги, создавая отсутствующие, и возвращает все теги задачи.
+// Теги должны быть уже нормализованы (validator.NormalizeTags).
+func (p *PostgresStorage) AddTaskTags(ctx context.Context, taskID int, tags []string) ([]string, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := checkTaskExists(ctx, tx, taskID); err != nil {
+		return nil, err
+	}
+
+	query := `INSERT INTO tags (name) SELECT unnest($1::TEXT[]) ON CONFLICT (name) DO NOTHING;`
+	if _, err := tx.ExecContext(ctx, query, tags); err != nil {
+		return nil, err
+	}
+
+	query = `
+		INSERT INTO task_tags (task_id, tag_id)
+		SELECT $1, id FROM tags WHERE name = ANY($2::TEXT[])
+		ON CONFLICT DO NOTHING;
+		`
+	if _, err := tx.ExecContext(ctx, query, taskID, tags); err != nil {
+		return nil, err
+	}
+
+	taskTags, err := readTaskTags(ctx, tx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	return taskTags, tx.Commit()
+}
+
+// RemoveTaskTag снимает тег с задачи; тега, которого у задачи нет, снимать не нужно, это не ошибка
+func (p *PostgresStorage) RemoveTaskTag(ctx context.Context, taskID int, tag string) ([]string, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := checkTaskExists(ctx, tx, taskID); err != nil {
+		return nil, err
+	}
+
+	query := `DELETE FROM task_tags WHERE task_id = $1 AND tag_id IN (SELECT id FROM tags WHERE name = $2);`
+	if _, err := tx.ExecContext(ctx, query, taskID, tag); err != nil {
+		return nil, err
+	}
+
+	taskTags, err := readTaskTags(ctx, tx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	return taskTags, tx.Commit()
+}
+
+// checkTaskExists блокирует строку задачи на время транзакции, чтобы задачу не удалили до изменения тегов
+func checkTaskExists(ctx context.Context, tx *sql.Tx, taskID int) error {
+	query := `SELECT id FROM tasks WHERE id = $1 FOR SHARE;`
+	if err := tx.QueryRowContext(ctx, query, taskID).Scan(&taskID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: id %d", domain.ErrTaskNotFound, taskID)
+		}
+		return err
+	}
+	return nil
+}
+
+func readTaskTags(ctx context.Context, tx *sql.Tx, taskID int) ([]string, error) {
+	query := `
+		SELECT g.name
+		FROM task_tags tt
+		JOIN tags g ON g.id = tt.tag_id
+		WHERE tt.task_id = $1
+		ORDER BY g.name;
+		`
+	rows, err := tx.QueryContext(ctx, query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []string{}
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
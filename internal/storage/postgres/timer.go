@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/models"
+)
+
+// finishOtherTasks завершает все запущенные задачи пользователя, кроме taskID, так же, как AddEndTime:
+// закрывает открытый интервал, проставляет end_time и пересчитывает all_time
+func finishOtherTasks(ctx context.Context, tx *sql.Tx, userID, taskID int) error {
+	query := `
+		UPDATE task_intervals i
+		SET end_time = NOW()
+		FROM tasks t
+		WHERE t.id = i.task_id AND t.user_id = $1 AND t.id <> $2 AND i.end_time IS NULL
+		RETURNING i.task_id;
+		`
+	rows, err := tx.QueryContext(ctx, query, userID, taskID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var running []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return err
+		}
+		running = append(running, id)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE tasks
+		SET end_time = NOW(),
+			all_time = (` + sumIntervalsQuery + `)
+		WHERE id = $1 AND end_time IS NULL;
+		`
+	for _, id := range running {
+		if _, err := tx.ExecContext(ctx, query, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetActiveTimer возвращает последнюю запущенную задачу пользователя.
+// Если политика одного таймера выключена, запущенных задач может быть несколько.
+func (p *PostgresStorage) GetActiveTimer(ctx context.Context, userID int) (models.Timer, error) {
+	if _, err := p.Read(ctx, userID); err != nil {
+		return models.Timer{}, err
+	}
+
+	query := `
+		SELECT t.id, t.name_task, i.start_time,
+			EXTRACT(EPOCH FROM NOW() - i.start_time)::BIGINT,
+			COALESCE(t.all_time, 0) + EXTRACT(EPOCH FROM NOW() - i.start_time)::BIGINT
+		FROM task_intervals i
+		JOIN tasks t ON t.id = i.task_id
+		WHERE t.user_id = $1 AND i.end_time IS NULL
+		ORDER BY i.start_time DESC, i.id DESC
+		LIMIT 1;
+		`
+	timer := models.Timer{}
+	var task models.TimerTask
+	err := p.db.QueryRowContext(ctx, query, userID).Scan(
+		&task.TaskID,
+		&task.NameTask,
+		&task.RunningSince,
+		&task.RunningSeconds,
+		&task.DurationSeconds,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return timer, nil
+		}
+		return models.Timer{}, err
+	}
+
+	timer.Running = true
+	timer.Task = &task
+	return timer, nil
+}
+
+func (p *PostgresStorage) ReadUserSettings(ctx context.Context, userID int) (models.UserSettings, error) {
+	query := `SELECT single_timer, time_zone FROM users WHERE id = $1;`
+
+	settings := models.UserSettings{}
+	err := p.db.QueryRowContext(ctx, query, userID).Scan(&settings.SingleTimer, &settings.TimeZone)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.UserSettings{}, fmt.Errorf("%w: id %d", domain.ErrUserNotFound, userID)
+		}
+		return models.UserSettings{}, err
+	}
+	return settings, nil
+}
+
+// UpdateUserSettings меняет только переданные (не nil) настройки и возвращает итоговые
+func (p *PostgresStorage) UpdateUserSettings(ctx context.Context, userID int, update models.UserSettingsUpdate) (models.UserSettings, error) {
+	var singleTimer sql.NullBool
+	if update.SingleTimer != nil {
+		singleTimer = sql.NullBool{Bool: *update.SingleTimer, Valid: true}
+	}
+
+	// часовой пояс применяет БД, поэтому и проверяем его по справочнику БД
+	var timeZone sql.NullString
+	if update.TimeZone != nil {
+		var known bool
+		query := `SELECT EXISTS (SELECT 1 FROM pg_timezone_names WHERE name = $1);`
+		if err := p.db.QueryRowContext(ctx, query, *update.TimeZone).Scan(&known); err != nil {
+			return models.UserSettings{}, err
+		}
+		if !known {
+			return models.UserSettings{}, domain.NewValidationError("time_zone", "unknown time zone")
+		}
+		timeZone = sql.NullString{String: *update.TimeZone, Valid: true}
+	}
+
+	query := `
+		UPDATE users
+		SET single_timer = COALESCE($2, single_timer),
+			time_zone = COALESCE($3, time_zone)
+		WHERE id = $1
+		RETURNING single_timer, time_zone;
+		`
+	settings := models.UserSettings{}
+	err := p.db.QueryRowContext(ctx, query, userID, singleTimer, timeZone).Scan(&settings.SingleTimer, &settings.TimeZone)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.UserSettings{}, fmt.Errorf("%w: id %d", domain.ErrUserNotFound, userID)
+		}
+		return models.UserSettings{}, err
+	}
+	return settings, nil
+}
@@ -1,17 +1,76 @@
 package storage
 
-import "time-tracker/internal/models"
+import (
+	"context"
+	"time"
+	"time-tracker/internal/models"
+)
 
 // Repository представляет интерфейс для работы с хранилищем данных.
 type RepositoryDB interface {
-	Create(userData models.UserData) (int, error)
-	Read(userID int) (models.UserData, error)
-	Update(userID int, userData models.UserData) error
-	Delete(userID int) error
-	GetUsers(dataFilter models.UserData, page, limit int) ([]models.UserData, error)
-	CreateTask(userID int, nameTask string) (int, error)
-	ReadTask(taskID int) (models.TaskData, error)
-	AddStartTime(taskID int) error
-	AddEndTime(taskID int) error
-	GetTasksUser(userID int, timeTask models.TaskTime) ([]models.Tasks, error)
+	Create(ctx context.Context, userData models.UserData) (int, error)
+	Read(ctx context.Context, userID int) (models.UserData, error)
+	Update(ctx context.Context, userID int, userData models.UserData) error
+	Delete(ctx context.Context, userID int) error
+	GetUsers(ctx context.Context, dataFilter models.UserData, page, limit int) ([]models.UserData, error)
+	CreateTask(ctx context.Context, userID int, nameTask string) (int, error)
+	ReadTask(ctx context.Context, taskID int) (models.TaskData, error)
+	CreateManualTask(ctx context.Context, userID int, nameTask string, start, end time.Time) (int, error)
+	UpdateTaskTime(ctx context.Context, taskID int, start, end time.Time) error
+	AddStartTime(ctx context.Context, taskID int) error
+	AddEndTime(ctx context.Context, taskID int) error
+	PauseTask(ctx context.Context, taskID int) error
+	ResumeTask(ctx context.Context, taskID int) error
+	RenameTask(ctx context.Context, taskID int, nameTask string) error
+	DeleteTask(ctx context.Context, taskID int) error
+	GetActiveTimer(ctx context.Context, userID int) (models.Timer, error)
+	ReadUserSettings(ctx context.Context, userID int) (models.UserSettings, error)
+	UpdateUserSettings(ctx context.Context, userID int, update models.UserSettingsUpdate) (models.UserSettings, error)
+	Report(ctx context.Context, userID int, filter models.ReportFilter) (models.Report, error)
+	ExportReport(ctx context.Context, userID int, filter models.ReportFilter, row func(models.ExportRow) error) error
+	SetCalendarToken(ctx context.Context, userID int, tokenHash string) error
+	ReadCalendarTokenHash(ctx context.Context, userID int) (string, error)
+	CalendarEvents(ctx context.Context, userID int, event func(models.CalendarEvent) error) error
+	ImportTasks(ctx context.Context, userID int, entries []models.ImportEntry, dryRun bool) (models.ImportResult, error)
+	CreateClient(ctx context.Context, name string) (int, error)
+	ReadClient(ctx context.Context, clientID int) (models.Client, error)
+	ListClients(ctx context.Context) ([]models.Client, error)
+	UpdateClient(ctx context.Context, clientID int, name string) error
+	DeleteClient(ctx context.Context, clientID int) error
+	CreateProject(ctx context.Context, project models.ProjectRequest) (int, error)
+	ReadProject(ctx context.Context, projectID int) (models.Project, error)
+	ListProjects(ctx context.Context, clientID int) ([]models.Project, error)
+	UpdateProject(ctx context.Context, projectID int, project models.ProjectRequest) error
+	DeleteProject(ctx context.Context, projectID int) error
+	SetTaskProject(ctx context.Context, taskID int, projectID *int) error
+	ListTags(ctx context.Context) ([]models.Tag, error)
+	AddTaskTags(ctx context.Context, taskID int, tags []string) ([]string, error)
+	RemoveTaskTag(ctx context.Context, taskID int, tag string) ([]string, error)
+	SetCredentials(ctx context.Context, userID int, login, passwordHash string) error
+	ReadCredentials(ctx context.Context, login string) (models.UserCredentials, error)
+	SetUserRole(ctx context.Context, userID int, role string) error
+	TaskOwner(ctx context.Context, taskID int) (int, error)
+	ListRoles(ctx context.Context) ([]models.Role, error)
+	SetUserManager(ctx context.Context, userID int, managerID *int) error
+	IsTeamMember(ctx context.Context, managerID, userID int) (bool, error)
+	ListTeam(ctx context.Context, managerID int) ([]models.UserData, error)
+	CreateAPIKey(ctx context.Context, userID int, key models.APIKey, keyHash string) (models.APIKey, error)
+	ListAPIKeys(ctx context.Context, userID int) ([]models.APIKey, error)
+	RevokeAPIKey(ctx context.Context, userID, keyID int) error
+	AuthenticateAPIKey(ctx context.Context, keyHash string) (models.APIKeyOwner, error)
+	CreatePendingUser(ctx context.Context, passportNumber string) (int, error)
+	ReadEnrichmentStatus(ctx context.Context, userID int) (models.EnrichmentStatus, error)
+	RetryEnrichment(ctx context.Context, userID int) error
+	ClaimEnrichmentJob(ctx context.Context, lease time.Duration) (models.EnrichmentJob, bool, error)
+	CompleteEnrichmentJob(ctx context.Context, userID int, userData models.UserData) error
+	RescheduleEnrichmentJob(ctx context.Context, userID int, runAt time.Time, lastError string) error
+	FailEnrichmentJob(ctx context.Context, userID int, lastError string) error
+	CreateRefreshToken(ctx context.Context, userID int, tokenHash string, expiresAt time.Time) error
+	RotateRefreshToken(ctx context.Context, tokenHash, newHash string, expiresAt time.Time) (models.UserCredentials, error)
+	RevokeRefreshToken(ctx context.Context, tokenHash string) error
+	GetTasksUser(ctx context.Context, userID int, timeTask models.TaskTime) ([]models.Tasks, error)
+	ListTasks(ctx context.Context, userID int, filter models.TaskFilter) (models.TaskPage, error)
+	ExportTasks(ctx context.Context, userID int, filter models.TaskFilter, row func(models.ExportRow) error) error
+	Ping(ctx context.Context) error
+	MigrationVersion(ctx context.Context) (uint, bool, error)
 }
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time-tracker/internal/auth"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/models"
+)
+
+const (
+	// apiKeyMarker - начало каждого ключа API, по нему ключ легко найти в логах и сканерах секретов
+	apiKeyMarker = "tt_"
+	// apiKeySize - длина ключа API в байтах (до кодирования в base64)
+	apiKeySize = 32
+	// apiKeyPrefixLength - сколько первых символов ключа хранится открыто, чтобы узнать ключ в списке
+	apiKeyPrefixLength = len(apiKeyMarker) + 7
+)
+
+// UseCaseCreateAPIKey выпускает ключ API пользователя с правами req.Scopes.
+// Ключ возвращается только здесь, в хранилище попадает его хеш.
+func (uc *useCaseStorage) UseCaseCreateAPIKey(ctx context.Context, userID int, req models.APIKeyRequest) (models.APIKeyCreated, error) {
+	if err := uc.authorize(ctx, actionAPIKeys, userID); err != nil {
+		return models.APIKeyCreated{}, err
+	}
+
+	raw := make([]byte, apiKeySize)
+	if _, err := rand.Read(raw); err != nil {
+		return models.APIKeyCreated{}, err
+	}
+	key := apiKeyMarker + base64.RawURLEncoding.EncodeToString(raw)
+
+	stored, err := uc.storage.CreateAPIKey(ctx, userID, models.APIKey{
+		Name:      req.Name,
+		Prefix:    key[:apiKeyPrefixLength],
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+	}, auth.HashToken(key))
+	if err != nil {
+		return models.APIKeyCreated{}, err
+	}
+	return models.APIKeyCreated{APIKey: stored, Key: key}, nil
+}
+
+// UseCaseListAPIKeys возвращает ключи API пользователя без самих ключей
+func (uc *useCaseStorage) UseCaseListAPIKeys(ctx context.Context, userID int) ([]models.APIKey, error) {
+	if err := uc.authorize(ctx, actionAPIKeys, userID); err != nil {
+		return nil, err
+	}
+	return uc.storage.ListAPIKeys(ctx, userID)
+}
+
+// UseCaseRevokeAPIKey отзывает ключ API пользователя
+func (uc *useCaseStorage) UseCaseRevokeAPIKey(ctx context.Context, userID, keyID int) error {
+	if err := uc.authorize(ctx, actionAPIKeys, userID); err != nil {
+		return err
+	}
+	return uc.storage.RevokeAPIKey(ctx, userID, keyID)
+}
+
+// UseCaseAuthenticateAPIKey проверяет ключ API и возвращает его владельца с правами ключа.
+// Роль берется текущая, поэтому понижение роли владельца сразу сужает и права его ключей.
+func (uc *useCaseStorage) UseCaseAuthenticateAPIKey(ctx context.Context, key string) (auth.Principal, error) {
+	if !strings.HasPrefix(key, apiKeyMarker) {
+		return auth.Principal{}, fmt.Errorf("%w: неверный формат ключа API", domain.ErrUnauthorized)
+	}
+
+	owner, err := uc.storage.AuthenticateAPIKey(ctx, auth.HashToken(key))
+	if err != nil {
+		return auth.Principal{}, err
+	}
+	return auth.Principal{UserID: owner.UserID, Role: owner.Role, Scopes: owner.Scopes}, nil
+}
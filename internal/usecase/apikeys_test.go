@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time-tracker/internal/auth"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/models"
+	"time-tracker/internal/storage/mocks"
+)
+
+func TestAPIKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := mocks.NewMockRepositoryDB(ctrl)
+	uc := NewUseCaseStorage(storage, nil)
+	ctx := auth.WithPrincipal(context.Background(), auth.Principal{UserID: 1, Role: auth.RoleMember})
+
+	var storedHash string
+	storage.EXPECT().CreateAPIKey(ctx, 1, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ int, key models.APIKey, keyHash string) (models.APIKey, error) {
+			storedHash = keyHash
+			key.ID = 5
+			return key, nil
+		})
+
+	created, err := uc.UseCaseCreateAPIKey(ctx, 1, models.APIKeyRequest{Name: "ci-bot", Scopes: []string{auth.ScopeTasksWrite}})
+	require.NoError(t, err)
+	assert.Equal(t, 5, created.ID)
+	assert.True(t, strings.HasPrefix(created.Key, apiKeyMarker), created.Key)
+	assert.True(t, strings.HasPrefix(created.Key, created.Prefix))
+	assert.Len(t, created.Prefix, apiKeyPrefixLength)
+	// в хранилище попадает только хеш ключа
+	assert.Equal(t, auth.HashToken(created.Key), storedHash)
+
+	t.Run("#1 Ключ принимается с правами из хранилища", func(t *testing.T) {
+		storage.EXPECT().AuthenticateAPIKey(gomock.Any(), storedHash).Return(models.APIKeyOwner{
+			UserID: 1,
+			Role:   auth.RoleManager,
+			Scopes: []string{auth.ScopeTasksWrite},
+		}, nil)
+
+		p, err := uc.UseCaseAuthenticateAPIKey(context.Background(), created.Key)
+		require.NoError(t, err)
+		assert.Equal(t, auth.Principal{UserID: 1, Role: auth.RoleManager, Scopes: []string{auth.ScopeTasksWrite}}, p)
+	})
+
+	t.Run("#2 Ключ другого формата не ищется в хранилище", func(t *testing.T) {
+		_, err := uc.UseCaseAuthenticateAPIKey(context.Background(), "eyJhbGciOiJIUzI1NiJ9")
+		assert.True(t, errors.Is(err, domain.ErrUnauthorized), err)
+	})
+
+	t.Run("#3 Отозванный ключ", func(t *testing.T) {
+		storage.EXPECT().AuthenticateAPIKey(gomock.Any(), gomock.Any()).Return(models.APIKeyOwner{}, domain.ErrUnauthorized)
+
+		_, err := uc.UseCaseAuthenticateAPIKey(context.Background(), apiKeyMarker+"revoked")
+		assert.True(t, errors.Is(err, domain.ErrUnauthorized), err)
+	})
+}
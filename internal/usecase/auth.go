@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"time-tracker/internal/auth"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/models"
+)
+
+// UseCaseLogin проверяет логин и пароль и выпускает токен доступа и refresh-токен новой цепочки
+func (uc *useCaseStorage) UseCaseLogin(ctx context.Context, creds models.Credentials) (models.TokenResponse, error) {
+	stored, err := uc.storage.ReadCredentials(ctx, creds.Login)
+	if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+		return models.TokenResponse{}, err
+	}
+
+	// для неизвестного логина пароль тоже проверяется (с пустым хешем), чтобы время ответа было одинаковым
+	ok, err := auth.CheckPassword(stored.PasswordHash, creds.Password)
+	if err != nil {
+		return models.TokenResponse{}, err
+	}
+	if !ok {
+		return models.TokenResponse{}, domain.ErrInvalidCredentials
+	}
+
+	refreshToken, refreshHash, err := auth.NewRefreshToken()
+	if err != nil {
+		return models.TokenResponse{}, err
+	}
+	now := time.Now()
+	if err := uc.storage.CreateRefreshToken(ctx, stored.UserID, refreshHash, now.Add(uc.tokens.RefreshTTL())); err != nil {
+		return models.TokenResponse{}, err
+	}
+
+	return uc.tokenResponse(stored, refreshToken, now)
+}
+
+// UseCaseRefresh обменивает refresh-токен на новую пару токенов; старый refresh-токен больше не действует
+func (uc *useCaseStorage) UseCaseRefresh(ctx context.Context, refreshToken string) (models.TokenResponse, error) {
+	if refreshToken == "" {
+		return models.TokenResponse{}, domain.ErrInvalidToken
+	}
+
+	newToken, newHash, err := auth.NewRefreshToken()
+	if err != nil {
+		return models.TokenResponse{}, err
+	}
+	now := time.Now()
+	stored, err := uc.storage.RotateRefreshToken(ctx, auth.HashToken(refreshToken), newHash, now.Add(uc.tokens.RefreshTTL()))
+	if err != nil {
+		return models.TokenResponse{}, err
+	}
+
+	return uc.tokenResponse(stored, newToken, now)
+}
+
+// UseCaseLogout отзывает refresh-токен вместе со всей его цепочкой.
+// Выданные токены доступа действуют до истечения срока, поэтому он короткий.
+func (uc *useCaseStorage) UseCaseLogout(ctx context.Context, refreshToken string) error {
+	if refreshToken == "" {
+		return nil
+	}
+	return uc.storage.RevokeRefreshToken(ctx, auth.HashToken(refreshToken))
+}
+
+// UseCaseSetCredentials задает пользователю логин и пароль; все его refresh-токены отзываются
+func (uc *useCaseStorage) UseCaseSetCredentials(ctx context.Context, userID int, creds models.Credentials) error {
+	if err := uc.authorize(ctx, actionCredentials, userID); err != nil {
+		return err
+	}
+
+	hash, err := auth.HashPassword(creds.Password)
+	if err != nil {
+		return err
+	}
+	return uc.storage.SetCredentials(ctx, userID, creds.Login, hash)
+}
+
+// UseCaseSetUserRole меняет роль пользователя; роль вступает в силу с новыми токенами доступа
+func (uc *useCaseStorage) UseCaseSetUserRole(ctx context.Context, userID int, role string) error {
+	if err := uc.authorize(ctx, actionManageUsers, 0); err != nil {
+		return err
+	}
+	if !auth.ValidRole(role) {
+		return domain.NewValidationError("role", "must be one of: "+strings.Join(auth.Roles, ", "))
+	}
+	return uc.storage.SetUserRole(ctx, userID, role)
+}
+
+// UseCaseListRoles возвращает роли с описанием прав
+func (uc *useCaseStorage) UseCaseListRoles(ctx context.Context) ([]models.Role, error) {
+	if err := uc.authorize(ctx, actionReadCatalog, 0); err != nil {
+		return nil, err
+	}
+	return uc.storage.ListRoles(ctx)
+}
+
+// UseCaseSetUserManager включает пользователя в команду менеджера managerID или исключает из нее (nil)
+func (uc *useCaseStorage) UseCaseSetUserManager(ctx context.Context, userID int, managerID *int) error {
+	if err := uc.authorize(ctx, actionManageUsers, 0); err != nil {
+		return err
+	}
+	if managerID != nil && *managerID == userID {
+		return domain.NewValidationError("manager_id", "user cannot be their own manager")
+	}
+	return uc.storage.SetUserManager(ctx, userID, managerID)
+}
+
+// UseCaseListTeam возвращает команду менеджера managerID
+func (uc *useCaseStorage) UseCaseListTeam(ctx context.Context, managerID int) ([]models.UserData, error) {
+	if err := uc.authorize(ctx, actionReadUser, managerID); err != nil {
+		return nil, err
+	}
+	return uc.storage.ListTeam(ctx, managerID)
+}
+
+func (uc *useCaseStorage) tokenResponse(stored models.UserCredentials, refreshToken string, now time.Time) (models.TokenResponse, error) {
+	accessToken, err := uc.tokens.IssueAccessToken(auth.Principal{UserID: stored.UserID, Role: stored.Role}, now)
+	if err != nil {
+		return models.TokenResponse{}, err
+	}
+
+	return models.TokenResponse{
+		AccessToken:  accessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(uc.tokens.AccessTTL().Seconds()),
+		RefreshToken: refreshToken,
+	}, nil
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+	"time-tracker/internal/auth"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/models"
+	"time-tracker/internal/storage/mocks"
+)
+
+func TestLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tokens, err := auth.NewTokens(strings.Repeat("s", auth.MinSecretLength), 15*time.Minute, time.Hour)
+	require.NoError(t, err)
+
+	storage := mocks.NewMockRepositoryDB(ctrl)
+	uc := NewUseCaseStorage(storage, tokens)
+	ctx := context.Background()
+
+	hash, err := auth.HashPassword("password1")
+	require.NoError(t, err)
+	stored := models.UserCredentials{UserID: 7, Role: auth.RoleMember, PasswordHash: hash}
+
+	t.Run("#1 Успешный вход", func(t *testing.T) {
+		var refreshHash string
+		storage.EXPECT().ReadCredentials(ctx, "ivanov").Return(stored, nil)
+		storage.EXPECT().CreateRefreshToken(ctx, 7, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ int, tokenHash string, expiresAt time.Time) error {
+				refreshHash = tokenHash
+				assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, time.Minute)
+				return nil
+			})
+
+		resp, err := uc.UseCaseLogin(ctx, models.Credentials{Login: "ivanov", Password: "password1"})
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer", resp.TokenType)
+		assert.Equal(t, 900, resp.ExpiresIn)
+		// в хранилище попадает только хеш refresh-токена
+		assert.Equal(t, auth.HashToken(resp.RefreshToken), refreshHash)
+
+		p, err := tokens.ParseAccessToken(resp.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, auth.Principal{UserID: 7, Role: auth.RoleMember}, p)
+	})
+
+	t.Run("#2 Неверный пароль", func(t *testing.T) {
+		storage.EXPECT().ReadCredentials(ctx, "ivanov").Return(stored, nil)
+
+		_, err := uc.UseCaseLogin(ctx, models.Credentials{Login: "ivanov", Password: "password2"})
+		assert.True(t, errors.Is(err, domain.ErrInvalidCredentials), err)
+	})
+
+	t.Run("#3 Неизвестный логин", func(t *testing.T) {
+		storage.EXPECT().ReadCredentials(ctx, "petrov").Return(models.UserCredentials{}, domain.ErrUserNotFound)
+
+		_, err := uc.UseCaseLogin(ctx, models.Credentials{Login: "petrov", Password: "password1"})
+		assert.True(t, errors.Is(err, domain.ErrInvalidCredentials), err)
+	})
+
+	t.Run("#4 Обновление токенов", func(t *testing.T) {
+		storage.EXPECT().RotateRefreshToken(ctx, auth.HashToken("old"), gomock.Any(), gomock.Any()).
+			Return(models.UserCredentials{UserID: 7, Role: auth.RoleAdmin}, nil)
+
+		resp, err := uc.UseCaseRefresh(ctx, "old")
+		require.NoError(t, err)
+		assert.NotEqual(t, "old", resp.RefreshToken)
+
+		// роль берется из хранилища, а не из прежнего токена
+		p, err := tokens.ParseAccessToken(resp.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, auth.RoleAdmin, p.Role)
+	})
+
+	t.Run("#5 Повторное использование refresh-токена", func(t *testing.T) {
+		storage.EXPECT().RotateRefreshToken(ctx, auth.HashToken("old"), gomock.Any(), gomock.Any()).
+			Return(models.UserCredentials{}, domain.ErrInvalidToken)
+
+		_, err := uc.UseCaseRefresh(ctx, "old")
+		assert.True(t, errors.Is(err, domain.ErrInvalidToken), err)
+	})
+
+	t.Run("#6 Выход без токена", func(t *testing.T) {
+		assert.NoError(t, uc.UseCaseLogout(ctx, ""))
+	})
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"time-tracker/internal/auth"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/models"
+)
+
+// длина токена подписки на календарь в байтах (до кодирования в base64)
+const calendarTokenSize = 32
+
+// UseCaseCreateCalendarToken выпускает новый токен подписки на календарь.
+// Прежний токен перестает действовать. В хранилище попадает только хеш токена.
+func (uc *useCaseStorage) UseCaseCreateCalendarToken(ctx context.Context, userID int) (string, error) {
+	if err := uc.authorize(ctx, actionSettings, userID); err != nil {
+		return "", err
+	}
+
+	raw := make([]byte, calendarTokenSize)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	if err := uc.storage.SetCalendarToken(ctx, userID, auth.HashToken(token)); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// UseCaseCalendarEvents проверяет токен подписки и передает в event завершенные задачи пользователя
+func (uc *useCaseStorage) UseCaseCalendarEvents(ctx context.Context, userID int, token string, event func(models.CalendarEvent) error) error {
+	stored, err := uc.storage.ReadCalendarTokenHash(ctx, userID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		// несуществующий пользователь неотличим от неверного токена,
+		// иначе по ответу можно перебрать ID пользователей
+		return domain.ErrInvalidToken
+	}
+	if err != nil {
+		return err
+	}
+	if stored == "" || token == "" || subtle.ConstantTimeCompare([]byte(stored), []byte(auth.HashToken(token))) != 1 {
+		return domain.ErrInvalidToken
+	}
+
+	return uc.storage.CalendarEvents(ctx, userID, event)
+}
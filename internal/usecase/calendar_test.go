@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time-tracker/internal/auth"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/models"
+	"time-tracker/internal/storage/mocks"
+)
+
+func TestCalendarToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := mocks.NewMockRepositoryDB(ctrl)
+	uc := NewUseCaseStorage(storage, nil)
+	ctx := auth.WithPrincipal(context.Background(), auth.System)
+
+	var stored string
+	storage.EXPECT().SetCalendarToken(ctx, 1, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ int, tokenHash string) error {
+			stored = tokenHash
+			return nil
+		})
+
+	token, err := uc.UseCaseCreateCalendarToken(ctx, 1)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	assert.NotEqual(t, token, stored, "в хранилище должен попадать хеш, а не сам токен")
+
+	event := func(models.CalendarEvent) error { return nil }
+
+	tests := []struct {
+		name       string
+		stored     string
+		token      string
+		wantEvents bool
+		wantErr    error
+	}{
+		{name: "#1 Верный токен", stored: stored, token: token, wantEvents: true},
+		{name: "#2 Неверный токен", stored: stored, token: token + "x", wantErr: domain.ErrInvalidToken},
+		{name: "#3 Пустой токен", stored: stored, token: "", wantErr: domain.ErrInvalidToken},
+		{name: "#4 Токен не выпускался", stored: "", token: "", wantErr: domain.ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage.EXPECT().ReadCalendarTokenHash(ctx, 1).Return(tt.stored, nil)
+			if tt.wantEvents {
+				storage.EXPECT().CalendarEvents(ctx, 1, gomock.Any()).Return(nil)
+			}
+
+			err := uc.UseCaseCalendarEvents(ctx, 1, tt.token, event)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCalendarTokenUserNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := mocks.NewMockRepositoryDB(ctrl)
+	uc := NewUseCaseStorage(storage, nil)
+	ctx := auth.WithPrincipal(context.Background(), auth.System)
+
+	storage.EXPECT().SetCalendarToken(ctx, 2, gomock.Any()).Return(domain.ErrUserNotFound)
+	_, err := uc.UseCaseCreateCalendarToken(ctx, 2)
+	assert.True(t, errors.Is(err, domain.ErrUserNotFound))
+
+	storage.EXPECT().ReadCalendarTokenHash(ctx, 2).Return("", domain.ErrUserNotFound)
+	err = uc.UseCaseCalendarEvents(ctx, 2, "token", func(models.CalendarEvent) error { return nil })
+	assert.True(t, errors.Is(err, domain.ErrInvalidToken))
+}
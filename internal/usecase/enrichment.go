@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+	"time-tracker/internal/models"
+)
+
+// UseCaseCreatePendingUser добавляет пользователя по номеру паспорта; остальные данные
+// заполнит обработчик очереди обогащения
+func (uc *useCaseStorage) UseCaseCreatePendingUser(ctx context.Context, passportNumber string) (int, error) {
+	if err := uc.authorize(ctx, actionManageUsers, 0); err != nil {
+		return 0, err
+	}
+	return uc.storage.CreatePendingUser(ctx, passportNumber)
+}
+
+// UseCaseReadEnrichmentStatus возвращает состояние обогащения данных пользователя
+func (uc *useCaseStorage) UseCaseReadEnrichmentStatus(ctx context.Context, userID int) (models.EnrichmentStatus, error) {
+	if err := uc.authorize(ctx, actionReadUser, userID); err != nil {
+		return models.EnrichmentStatus{}, err
+	}
+	return uc.storage.ReadEnrichmentStatus(ctx, userID)
+}
+
+// UseCaseRetryEnrichment заново ставит обогащение данных пользователя в очередь,
+// в том числе после исчерпания попыток. Данные API заполняют только пустые поля пользователя.
+func (uc *useCaseStorage) UseCaseRetryEnrichment(ctx context.Context, userID int) (models.EnrichmentStatus, error) {
+	if err := uc.authorize(ctx, actionManageUsers, 0); err != nil {
+		return models.EnrichmentStatus{}, err
+	}
+	if err := uc.storage.RetryEnrichment(ctx, userID); err != nil {
+		return models.EnrichmentStatus{}, err
+	}
+	return uc.storage.ReadEnrichmentStatus(ctx, userID)
+}
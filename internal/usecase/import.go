@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"sort"
+	"time"
+	"time-tracker/internal/importer"
+	"time-tracker/internal/models"
+)
+
+// UseCaseImportTasks разбирает файл другого трекера и добавляет записи как ручные задачи.
+// Время без часового пояса считается временем в часовом поясе пользователя.
+// Записи, которые не удалось разобрать, не прерывают импорт и попадают в Issues.
+func (uc *useCaseStorage) UseCaseImportTasks(ctx context.Context, userID int, format string, file io.Reader, dryRun bool) (models.ImportResult, error) {
+	if err := uc.authorize(ctx, actionManageTasks, userID); err != nil {
+		return models.ImportResult{}, err
+	}
+
+	settings, err := uc.storage.ReadUserSettings(ctx, userID)
+	if err != nil {
+		return models.ImportResult{}, err
+	}
+	loc, err := time.LoadLocation(settings.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	entries, issues, err := importer.Parse(format, file, loc)
+	if err != nil {
+		return models.ImportResult{}, err
+	}
+
+	result, err := uc.storage.ImportTasks(ctx, userID, entries, dryRun)
+	if err != nil {
+		return models.ImportResult{}, err
+	}
+
+	result.Total += len(issues)
+	result.Skipped += len(issues)
+	result.Issues = append(result.Issues, issues...)
+	sort.SliceStable(result.Issues, func(i, j int) bool {
+		return result.Issues[i].Line < result.Issues[j].Line
+	})
+	return result, nil
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+	"time-tracker/internal/auth"
+	"time-tracker/internal/importer"
+	"time-tracker/internal/models"
+	"time-tracker/internal/storage/mocks"
+)
+
+func TestImportTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := mocks.NewMockRepositoryDB(ctrl)
+	uc := NewUseCaseStorage(storage, nil)
+	ctx := auth.WithPrincipal(context.Background(), auth.System)
+
+	file := "name_task,start,end\n" +
+		"Без окончания,2024-01-10 08:00,\n" +
+		"Отчет,2024-01-10 12:00,2024-01-10 13:00\n" +
+		"Созвон,2024-01-10 12:30,2024-01-10 13:30\n"
+
+	storage.EXPECT().ReadUserSettings(ctx, 1).Return(models.UserSettings{TimeZone: "Europe/Moscow"}, nil)
+	storage.EXPECT().ImportTasks(ctx, 1, gomock.Any(), true).DoAndReturn(
+		func(_ context.Context, _ int, entries []models.ImportEntry, dryRun bool) (models.ImportResult, error) {
+			require.Len(t, entries, 2)
+			// время без часового пояса считается временем пользователя
+			assert.True(t, entries[0].Start.Equal(time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)))
+
+			return models.ImportResult{
+				DryRun:   dryRun,
+				Total:    2,
+				Imported: 1,
+				Skipped:  1,
+				Issues:   []models.ImportIssue{{Line: 4, Reason: models.ImportIssueOverlap}},
+			}, nil
+		})
+
+	result, err := uc.UseCaseImportTasks(ctx, 1, importer.FormatCSV, strings.NewReader(file), true)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, result.Total)
+	assert.Equal(t, 1, result.Imported)
+	assert.Equal(t, 2, result.Skipped)
+	require.Len(t, result.Issues, 2)
+	assert.Equal(t, 2, result.Issues[0].Line)
+	assert.Equal(t, models.ImportIssueInvalid, result.Issues[0].Reason)
+	assert.Equal(t, 4, result.Issues[1].Line)
+	assert.Equal(t, models.ImportIssueOverlap, result.Issues[1].Reason)
+}
@@ -5,7 +5,11 @@
 package mocks
 
 import (
+	context "context"
+	io "io"
 	reflect "reflect"
+	time "time"
+	auth "time-tracker/internal/auth"
 	models "time-tracker/internal/models"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,147 +39,834 @@ func (m *MockUseCaseStorage) EXPECT() *MockUseCaseStorageMockRecorder {
 }
 
 // UseCaseAddEndTime mocks base method.
-func (m *MockUseCaseStorage) UseCaseAddEndTime(taskID int) error {
+func (m *MockUseCaseStorage) UseCaseAddEndTime(ctx context.Context, taskID int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UseCaseAddEndTime", taskID)
+	ret := m.ctrl.Call(m, "UseCaseAddEndTime", ctx, taskID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UseCaseAddEndTime indicates an expected call of UseCaseAddEndTime.
-func (mr *MockUseCaseStorageMockRecorder) UseCaseAddEndTime(taskID interface{}) *gomock.Call {
+func (mr *MockUseCaseStorageMockRecorder) UseCaseAddEndTime(ctx, taskID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseAddEndTime", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseAddEndTime), taskID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseAddEndTime", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseAddEndTime), ctx, taskID)
 }
 
 // UseCaseAddStartTime mocks base method.
-func (m *MockUseCaseStorage) UseCaseAddStartTime(taskID int) error {
+func (m *MockUseCaseStorage) UseCaseAddStartTime(ctx context.Context, taskID int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UseCaseAddStartTime", taskID)
+	ret := m.ctrl.Call(m, "UseCaseAddStartTime", ctx, taskID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UseCaseAddStartTime indicates an expected call of UseCaseAddStartTime.
-func (mr *MockUseCaseStorageMockRecorder) UseCaseAddStartTime(taskID interface{}) *gomock.Call {
+func (mr *MockUseCaseStorageMockRecorder) UseCaseAddStartTime(ctx, taskID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseAddStartTime", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseAddStartTime), taskID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseAddStartTime", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseAddStartTime), ctx, taskID)
+}
+
+// UseCaseAddTaskTags mocks base method.
+func (m *MockUseCaseStorage) UseCaseAddTaskTags(ctx context.Context, taskID int, tags []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseAddTaskTags", ctx, taskID, tags)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseAddTaskTags indicates an expected call of UseCaseAddTaskTags.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseAddTaskTags(ctx, taskID, tags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseAddTaskTags", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseAddTaskTags), ctx, taskID, tags)
+}
+
+// UseCaseAuthenticateAPIKey mocks base method.
+func (m *MockUseCaseStorage) UseCaseAuthenticateAPIKey(ctx context.Context, key string) (auth.Principal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseAuthenticateAPIKey", ctx, key)
+	ret0, _ := ret[0].(auth.Principal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseAuthenticateAPIKey indicates an expected call of UseCaseAuthenticateAPIKey.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseAuthenticateAPIKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseAuthenticateAPIKey", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseAuthenticateAPIKey), ctx, key)
+}
+
+// UseCaseCalendarEvents mocks base method.
+func (m *MockUseCaseStorage) UseCaseCalendarEvents(ctx context.Context, userID int, token string, event func(models.CalendarEvent) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseCalendarEvents", ctx, userID, token, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseCaseCalendarEvents indicates an expected call of UseCaseCalendarEvents.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseCalendarEvents(ctx, userID, token, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseCalendarEvents", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseCalendarEvents), ctx, userID, token, event)
 }
 
 // UseCaseCreate mocks base method.
-func (m *MockUseCaseStorage) UseCaseCreate(userData models.UserData) (int, error) {
+func (m *MockUseCaseStorage) UseCaseCreate(ctx context.Context, userData models.UserData) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UseCaseCreate", userData)
+	ret := m.ctrl.Call(m, "UseCaseCreate", ctx, userData)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UseCaseCreate indicates an expected call of UseCaseCreate.
-func (mr *MockUseCaseStorageMockRecorder) UseCaseCreate(userData interface{}) *gomock.Call {
+func (mr *MockUseCaseStorageMockRecorder) UseCaseCreate(ctx, userData interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseCreate", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseCreate), userData)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseCreate", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseCreate), ctx, userData)
+}
+
+// UseCaseCreateAPIKey mocks base method.
+func (m *MockUseCaseStorage) UseCaseCreateAPIKey(ctx context.Context, userID int, req models.APIKeyRequest) (models.APIKeyCreated, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseCreateAPIKey", ctx, userID, req)
+	ret0, _ := ret[0].(models.APIKeyCreated)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseCreateAPIKey indicates an expected call of UseCaseCreateAPIKey.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseCreateAPIKey(ctx, userID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseCreateAPIKey", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseCreateAPIKey), ctx, userID, req)
+}
+
+// UseCaseCreateCalendarToken mocks base method.
+func (m *MockUseCaseStorage) UseCaseCreateCalendarToken(ctx context.Context, userID int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseCreateCalendarToken", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseCreateCalendarToken indicates an expected call of UseCaseCreateCalendarToken.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseCreateCalendarToken(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseCreateCalendarToken", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseCreateCalendarToken), ctx, userID)
+}
+
+// UseCaseCreateClient mocks base method.
+func (m *MockUseCaseStorage) UseCaseCreateClient(ctx context.Context, name string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseCreateClient", ctx, name)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseCreateClient indicates an expected call of UseCaseCreateClient.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseCreateClient(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseCreateClient", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseCreateClient), ctx, name)
+}
+
+// UseCaseCreateManualTask mocks base method.
+func (m *MockUseCaseStorage) UseCaseCreateManualTask(ctx context.Context, userID int, nameTask string, start, end time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseCreateManualTask", ctx, userID, nameTask, start, end)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseCreateManualTask indicates an expected call of UseCaseCreateManualTask.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseCreateManualTask(ctx, userID, nameTask, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseCreateManualTask", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseCreateManualTask), ctx, userID, nameTask, start, end)
+}
+
+// UseCaseCreatePendingUser mocks base method.
+func (m *MockUseCaseStorage) UseCaseCreatePendingUser(ctx context.Context, passportNumber string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseCreatePendingUser", ctx, passportNumber)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseCreatePendingUser indicates an expected call of UseCaseCreatePendingUser.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseCreatePendingUser(ctx, passportNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseCreatePendingUser", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseCreatePendingUser), ctx, passportNumber)
+}
+
+// UseCaseCreateProject mocks base method.
+func (m *MockUseCaseStorage) UseCaseCreateProject(ctx context.Context, project models.ProjectRequest) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseCreateProject", ctx, project)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseCreateProject indicates an expected call of UseCaseCreateProject.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseCreateProject(ctx, project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseCreateProject", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseCreateProject), ctx, project)
 }
 
 // UseCaseCreateTask mocks base method.
-func (m *MockUseCaseStorage) UseCaseCreateTask(userID int, nameTask string) (int, error) {
+func (m *MockUseCaseStorage) UseCaseCreateTask(ctx context.Context, userID int, nameTask string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UseCaseCreateTask", userID, nameTask)
+	ret := m.ctrl.Call(m, "UseCaseCreateTask", ctx, userID, nameTask)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UseCaseCreateTask indicates an expected call of UseCaseCreateTask.
-func (mr *MockUseCaseStorageMockRecorder) UseCaseCreateTask(userID, nameTask interface{}) *gomock.Call {
+func (mr *MockUseCaseStorageMockRecorder) UseCaseCreateTask(ctx, userID, nameTask interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseCreateTask", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseCreateTask), userID, nameTask)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseCreateTask", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseCreateTask), ctx, userID, nameTask)
 }
 
 // UseCaseDelete mocks base method.
-func (m *MockUseCaseStorage) UseCaseDelete(userID int) error {
+func (m *MockUseCaseStorage) UseCaseDelete(ctx context.Context, userID int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UseCaseDelete", userID)
+	ret := m.ctrl.Call(m, "UseCaseDelete", ctx, userID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UseCaseDelete indicates an expected call of UseCaseDelete.
-func (mr *MockUseCaseStorageMockRecorder) UseCaseDelete(userID interface{}) *gomock.Call {
+func (mr *MockUseCaseStorageMockRecorder) UseCaseDelete(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseDelete", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseDelete), ctx, userID)
+}
+
+// UseCaseDeleteClient mocks base method.
+func (m *MockUseCaseStorage) UseCaseDeleteClient(ctx context.Context, clientID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseDeleteClient", ctx, clientID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseCaseDeleteClient indicates an expected call of UseCaseDeleteClient.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseDeleteClient(ctx, clientID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseDeleteClient", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseDeleteClient), ctx, clientID)
+}
+
+// UseCaseDeleteProject mocks base method.
+func (m *MockUseCaseStorage) UseCaseDeleteProject(ctx context.Context, projectID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseDeleteProject", ctx, projectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseCaseDeleteProject indicates an expected call of UseCaseDeleteProject.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseDeleteProject(ctx, projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseDeleteProject", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseDeleteProject), ctx, projectID)
+}
+
+// UseCaseDeleteTask mocks base method.
+func (m *MockUseCaseStorage) UseCaseDeleteTask(ctx context.Context, taskID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseDeleteTask", ctx, taskID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseCaseDeleteTask indicates an expected call of UseCaseDeleteTask.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseDeleteTask(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseDeleteTask", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseDeleteTask), ctx, taskID)
+}
+
+// UseCaseExportReport mocks base method.
+func (m *MockUseCaseStorage) UseCaseExportReport(ctx context.Context, userID int, filter models.ReportFilter, row func(models.ExportRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseExportReport", ctx, userID, filter, row)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseCaseExportReport indicates an expected call of UseCaseExportReport.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseExportReport(ctx, userID, filter, row interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseExportReport", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseExportReport), ctx, userID, filter, row)
+}
+
+// UseCaseExportTasks mocks base method.
+func (m *MockUseCaseStorage) UseCaseExportTasks(ctx context.Context, userID int, filter models.TaskFilter, row func(models.ExportRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseExportTasks", ctx, userID, filter, row)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseCaseExportTasks indicates an expected call of UseCaseExportTasks.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseExportTasks(ctx, userID, filter, row interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseExportTasks", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseExportTasks), ctx, userID, filter, row)
+}
+
+// UseCaseGetActiveTimer mocks base method.
+func (m *MockUseCaseStorage) UseCaseGetActiveTimer(ctx context.Context, userID int) (models.Timer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseGetActiveTimer", ctx, userID)
+	ret0, _ := ret[0].(models.Timer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseGetActiveTimer indicates an expected call of UseCaseGetActiveTimer.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseGetActiveTimer(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseDelete", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseDelete), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseGetActiveTimer", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseGetActiveTimer), ctx, userID)
 }
 
 // UseCaseGetTasksUser mocks base method.
-func (m *MockUseCaseStorage) UseCaseGetTasksUser(userID int, timeTask models.TaskTime) ([]models.Tasks, error) {
+func (m *MockUseCaseStorage) UseCaseGetTasksUser(ctx context.Context, userID int, timeTask models.TaskTime) ([]models.Tasks, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UseCaseGetTasksUser", userID, timeTask)
+	ret := m.ctrl.Call(m, "UseCaseGetTasksUser", ctx, userID, timeTask)
 	ret0, _ := ret[0].([]models.Tasks)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UseCaseGetTasksUser indicates an expected call of UseCaseGetTasksUser.
-func (mr *MockUseCaseStorageMockRecorder) UseCaseGetTasksUser(userID, timeTask interface{}) *gomock.Call {
+func (mr *MockUseCaseStorageMockRecorder) UseCaseGetTasksUser(ctx, userID, timeTask interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseGetTasksUser", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseGetTasksUser), userID, timeTask)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseGetTasksUser", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseGetTasksUser), ctx, userID, timeTask)
 }
 
 // UseCaseGetUsers mocks base method.
-func (m *MockUseCaseStorage) UseCaseGetUsers(dataUser models.UserData, page, limit int) ([]models.UserData, error) {
+func (m *MockUseCaseStorage) UseCaseGetUsers(ctx context.Context, dataUser models.UserData, page, limit int) ([]models.UserData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UseCaseGetUsers", dataUser, page, limit)
+	ret := m.ctrl.Call(m, "UseCaseGetUsers", ctx, dataUser, page, limit)
 	ret0, _ := ret[0].([]models.UserData)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UseCaseGetUsers indicates an expected call of UseCaseGetUsers.
-func (mr *MockUseCaseStorageMockRecorder) UseCaseGetUsers(dataUser, page, limit interface{}) *gomock.Call {
+func (mr *MockUseCaseStorageMockRecorder) UseCaseGetUsers(ctx, dataUser, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseGetUsers", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseGetUsers), ctx, dataUser, page, limit)
+}
+
+// UseCaseImportTasks mocks base method.
+func (m *MockUseCaseStorage) UseCaseImportTasks(ctx context.Context, userID int, format string, file io.Reader, dryRun bool) (models.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseImportTasks", ctx, userID, format, file, dryRun)
+	ret0, _ := ret[0].(models.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseImportTasks indicates an expected call of UseCaseImportTasks.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseImportTasks(ctx, userID, format, file, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseImportTasks", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseImportTasks), ctx, userID, format, file, dryRun)
+}
+
+// UseCaseListAPIKeys mocks base method.
+func (m *MockUseCaseStorage) UseCaseListAPIKeys(ctx context.Context, userID int) ([]models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseListAPIKeys", ctx, userID)
+	ret0, _ := ret[0].([]models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseListAPIKeys indicates an expected call of UseCaseListAPIKeys.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseListAPIKeys(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseGetUsers", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseGetUsers), dataUser, page, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseListAPIKeys", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseListAPIKeys), ctx, userID)
+}
+
+// UseCaseListClients mocks base method.
+func (m *MockUseCaseStorage) UseCaseListClients(ctx context.Context) ([]models.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseListClients", ctx)
+	ret0, _ := ret[0].([]models.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseListClients indicates an expected call of UseCaseListClients.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseListClients(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseListClients", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseListClients), ctx)
+}
+
+// UseCaseListProjects mocks base method.
+func (m *MockUseCaseStorage) UseCaseListProjects(ctx context.Context, clientID int) ([]models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseListProjects", ctx, clientID)
+	ret0, _ := ret[0].([]models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseListProjects indicates an expected call of UseCaseListProjects.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseListProjects(ctx, clientID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseListProjects", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseListProjects), ctx, clientID)
+}
+
+// UseCaseListRoles mocks base method.
+func (m *MockUseCaseStorage) UseCaseListRoles(ctx context.Context) ([]models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseListRoles", ctx)
+	ret0, _ := ret[0].([]models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseListRoles indicates an expected call of UseCaseListRoles.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseListRoles(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseListRoles", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseListRoles), ctx)
+}
+
+// UseCaseListTags mocks base method.
+func (m *MockUseCaseStorage) UseCaseListTags(ctx context.Context) ([]models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseListTags", ctx)
+	ret0, _ := ret[0].([]models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseListTags indicates an expected call of UseCaseListTags.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseListTags(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseListTags", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseListTags), ctx)
+}
+
+// UseCaseListTasks mocks base method.
+func (m *MockUseCaseStorage) UseCaseListTasks(ctx context.Context, userID int, filter models.TaskFilter) (models.TaskPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseListTasks", ctx, userID, filter)
+	ret0, _ := ret[0].(models.TaskPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseListTasks indicates an expected call of UseCaseListTasks.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseListTasks(ctx, userID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseListTasks", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseListTasks), ctx, userID, filter)
+}
+
+// UseCaseListTeam mocks base method.
+func (m *MockUseCaseStorage) UseCaseListTeam(ctx context.Context, managerID int) ([]models.UserData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseListTeam", ctx, managerID)
+	ret0, _ := ret[0].([]models.UserData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseListTeam indicates an expected call of UseCaseListTeam.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseListTeam(ctx, managerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseListTeam", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseListTeam), ctx, managerID)
+}
+
+// UseCaseLogin mocks base method.
+func (m *MockUseCaseStorage) UseCaseLogin(ctx context.Context, creds models.Credentials) (models.TokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseLogin", ctx, creds)
+	ret0, _ := ret[0].(models.TokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseLogin indicates an expected call of UseCaseLogin.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseLogin(ctx, creds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseLogin", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseLogin), ctx, creds)
+}
+
+// UseCaseLogout mocks base method.
+func (m *MockUseCaseStorage) UseCaseLogout(ctx context.Context, refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseLogout", ctx, refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseCaseLogout indicates an expected call of UseCaseLogout.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseLogout(ctx, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseLogout", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseLogout), ctx, refreshToken)
+}
+
+// UseCaseMigrationVersion mocks base method.
+func (m *MockUseCaseStorage) UseCaseMigrationVersion(ctx context.Context) (uint, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseMigrationVersion", ctx)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UseCaseMigrationVersion indicates an expected call of UseCaseMigrationVersion.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseMigrationVersion(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseMigrationVersion", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseMigrationVersion), ctx)
+}
+
+// UseCasePauseTask mocks base method.
+func (m *MockUseCaseStorage) UseCasePauseTask(ctx context.Context, taskID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCasePauseTask", ctx, taskID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseCasePauseTask indicates an expected call of UseCasePauseTask.
+func (mr *MockUseCaseStorageMockRecorder) UseCasePauseTask(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCasePauseTask", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCasePauseTask), ctx, taskID)
+}
+
+// UseCasePing mocks base method.
+func (m *MockUseCaseStorage) UseCasePing(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCasePing", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseCasePing indicates an expected call of UseCasePing.
+func (mr *MockUseCaseStorageMockRecorder) UseCasePing(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCasePing", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCasePing), ctx)
 }
 
 // UseCaseRead mocks base method.
-func (m *MockUseCaseStorage) UseCaseRead(userID int) (models.UserData, error) {
+func (m *MockUseCaseStorage) UseCaseRead(ctx context.Context, userID int) (models.UserData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UseCaseRead", userID)
+	ret := m.ctrl.Call(m, "UseCaseRead", ctx, userID)
 	ret0, _ := ret[0].(models.UserData)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UseCaseRead indicates an expected call of UseCaseRead.
-func (mr *MockUseCaseStorageMockRecorder) UseCaseRead(userID interface{}) *gomock.Call {
+func (mr *MockUseCaseStorageMockRecorder) UseCaseRead(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseRead", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseRead), ctx, userID)
+}
+
+// UseCaseReadClient mocks base method.
+func (m *MockUseCaseStorage) UseCaseReadClient(ctx context.Context, clientID int) (models.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseReadClient", ctx, clientID)
+	ret0, _ := ret[0].(models.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseReadClient indicates an expected call of UseCaseReadClient.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseReadClient(ctx, clientID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseRead", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseRead), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseReadClient", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseReadClient), ctx, clientID)
+}
+
+// UseCaseReadEnrichmentStatus mocks base method.
+func (m *MockUseCaseStorage) UseCaseReadEnrichmentStatus(ctx context.Context, userID int) (models.EnrichmentStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseReadEnrichmentStatus", ctx, userID)
+	ret0, _ := ret[0].(models.EnrichmentStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseReadEnrichmentStatus indicates an expected call of UseCaseReadEnrichmentStatus.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseReadEnrichmentStatus(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseReadEnrichmentStatus", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseReadEnrichmentStatus), ctx, userID)
+}
+
+// UseCaseReadProject mocks base method.
+func (m *MockUseCaseStorage) UseCaseReadProject(ctx context.Context, projectID int) (models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseReadProject", ctx, projectID)
+	ret0, _ := ret[0].(models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseReadProject indicates an expected call of UseCaseReadProject.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseReadProject(ctx, projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseReadProject", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseReadProject), ctx, projectID)
 }
 
 // UseCaseReadTask mocks base method.
-func (m *MockUseCaseStorage) UseCaseReadTask(taskID int) (models.TaskData, error) {
+func (m *MockUseCaseStorage) UseCaseReadTask(ctx context.Context, taskID int) (models.TaskData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UseCaseReadTask", taskID)
+	ret := m.ctrl.Call(m, "UseCaseReadTask", ctx, taskID)
 	ret0, _ := ret[0].(models.TaskData)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UseCaseReadTask indicates an expected call of UseCaseReadTask.
-func (mr *MockUseCaseStorageMockRecorder) UseCaseReadTask(taskID interface{}) *gomock.Call {
+func (mr *MockUseCaseStorageMockRecorder) UseCaseReadTask(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseReadTask", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseReadTask), ctx, taskID)
+}
+
+// UseCaseReadUserSettings mocks base method.
+func (m *MockUseCaseStorage) UseCaseReadUserSettings(ctx context.Context, userID int) (models.UserSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseReadUserSettings", ctx, userID)
+	ret0, _ := ret[0].(models.UserSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseReadUserSettings indicates an expected call of UseCaseReadUserSettings.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseReadUserSettings(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseReadTask", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseReadTask), taskID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseReadUserSettings", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseReadUserSettings), ctx, userID)
+}
+
+// UseCaseRefresh mocks base method.
+func (m *MockUseCaseStorage) UseCaseRefresh(ctx context.Context, refreshToken string) (models.TokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseRefresh", ctx, refreshToken)
+	ret0, _ := ret[0].(models.TokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseRefresh indicates an expected call of UseCaseRefresh.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseRefresh(ctx, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseRefresh", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseRefresh), ctx, refreshToken)
+}
+
+// UseCaseRemoveTaskTag mocks base method.
+func (m *MockUseCaseStorage) UseCaseRemoveTaskTag(ctx context.Context, taskID int, tag string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseRemoveTaskTag", ctx, taskID, tag)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseRemoveTaskTag indicates an expected call of UseCaseRemoveTaskTag.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseRemoveTaskTag(ctx, taskID, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseRemoveTaskTag", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseRemoveTaskTag), ctx, taskID, tag)
+}
+
+// UseCaseRenameTask mocks base method.
+func (m *MockUseCaseStorage) UseCaseRenameTask(ctx context.Context, taskID int, nameTask string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseRenameTask", ctx, taskID, nameTask)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseCaseRenameTask indicates an expected call of UseCaseRenameTask.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseRenameTask(ctx, taskID, nameTask interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseRenameTask", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseRenameTask), ctx, taskID, nameTask)
+}
+
+// UseCaseReport mocks base method.
+func (m *MockUseCaseStorage) UseCaseReport(ctx context.Context, userID int, filter models.ReportFilter) (models.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseReport", ctx, userID, filter)
+	ret0, _ := ret[0].(models.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseReport indicates an expected call of UseCaseReport.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseReport(ctx, userID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseReport", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseReport), ctx, userID, filter)
+}
+
+// UseCaseResumeTask mocks base method.
+func (m *MockUseCaseStorage) UseCaseResumeTask(ctx context.Context, taskID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseResumeTask", ctx, taskID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseCaseResumeTask indicates an expected call of UseCaseResumeTask.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseResumeTask(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseResumeTask", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseResumeTask), ctx, taskID)
+}
+
+// UseCaseRetryEnrichment mocks base method.
+func (m *MockUseCaseStorage) UseCaseRetryEnrichment(ctx context.Context, userID int) (models.EnrichmentStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseRetryEnrichment", ctx, userID)
+	ret0, _ := ret[0].(models.EnrichmentStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseRetryEnrichment indicates an expected call of UseCaseRetryEnrichment.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseRetryEnrichment(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseRetryEnrichment", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseRetryEnrichment), ctx, userID)
+}
+
+// UseCaseRevokeAPIKey mocks base method.
+func (m *MockUseCaseStorage) UseCaseRevokeAPIKey(ctx context.Context, userID, keyID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseRevokeAPIKey", ctx, userID, keyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseCaseRevokeAPIKey indicates an expected call of UseCaseRevokeAPIKey.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseRevokeAPIKey(ctx, userID, keyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseRevokeAPIKey", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseRevokeAPIKey), ctx, userID, keyID)
+}
+
+// UseCaseSetCredentials mocks base method.
+func (m *MockUseCaseStorage) UseCaseSetCredentials(ctx context.Context, userID int, creds models.Credentials) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseSetCredentials", ctx, userID, creds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseCaseSetCredentials indicates an expected call of UseCaseSetCredentials.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseSetCredentials(ctx, userID, creds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseSetCredentials", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseSetCredentials), ctx, userID, creds)
+}
+
+// UseCaseSetTaskProject mocks base method.
+func (m *MockUseCaseStorage) UseCaseSetTaskProject(ctx context.Context, taskID int, projectID *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseSetTaskProject", ctx, taskID, projectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseCaseSetTaskProject indicates an expected call of UseCaseSetTaskProject.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseSetTaskProject(ctx, taskID, projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseSetTaskProject", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseSetTaskProject), ctx, taskID, projectID)
+}
+
+// UseCaseSetUserManager mocks base method.
+func (m *MockUseCaseStorage) UseCaseSetUserManager(ctx context.Context, userID int, managerID *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseSetUserManager", ctx, userID, managerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseCaseSetUserManager indicates an expected call of UseCaseSetUserManager.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseSetUserManager(ctx, userID, managerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseSetUserManager", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseSetUserManager), ctx, userID, managerID)
+}
+
+// UseCaseSetUserRole mocks base method.
+func (m *MockUseCaseStorage) UseCaseSetUserRole(ctx context.Context, userID int, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseSetUserRole", ctx, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseCaseSetUserRole indicates an expected call of UseCaseSetUserRole.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseSetUserRole(ctx, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseSetUserRole", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseSetUserRole), ctx, userID, role)
 }
 
 // UseCaseUpdate mocks base method.
-func (m *MockUseCaseStorage) UseCaseUpdate(userID int, userData models.UserData) error {
+func (m *MockUseCaseStorage) UseCaseUpdate(ctx context.Context, userID int, userData models.UserData) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UseCaseUpdate", userID, userData)
+	ret := m.ctrl.Call(m, "UseCaseUpdate", ctx, userID, userData)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UseCaseUpdate indicates an expected call of UseCaseUpdate.
-func (mr *MockUseCaseStorageMockRecorder) UseCaseUpdate(userID, userData interface{}) *gomock.Call {
+func (mr *MockUseCaseStorageMockRecorder) UseCaseUpdate(ctx, userID, userData interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseUpdate", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseUpdate), ctx, userID, userData)
+}
+
+// UseCaseUpdateClient mocks base method.
+func (m *MockUseCaseStorage) UseCaseUpdateClient(ctx context.Context, clientID int, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseUpdateClient", ctx, clientID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseCaseUpdateClient indicates an expected call of UseCaseUpdateClient.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseUpdateClient(ctx, clientID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseUpdateClient", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseUpdateClient), ctx, clientID, name)
+}
+
+// UseCaseUpdateProject mocks base method.
+func (m *MockUseCaseStorage) UseCaseUpdateProject(ctx context.Context, projectID int, project models.ProjectRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseUpdateProject", ctx, projectID, project)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseCaseUpdateProject indicates an expected call of UseCaseUpdateProject.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseUpdateProject(ctx, projectID, project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseUpdateProject", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseUpdateProject), ctx, projectID, project)
+}
+
+// UseCaseUpdateTaskTime mocks base method.
+func (m *MockUseCaseStorage) UseCaseUpdateTaskTime(ctx context.Context, taskID int, start, end time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseUpdateTaskTime", ctx, taskID, start, end)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseCaseUpdateTaskTime indicates an expected call of UseCaseUpdateTaskTime.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseUpdateTaskTime(ctx, taskID, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseUpdateTaskTime", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseUpdateTaskTime), ctx, taskID, start, end)
+}
+
+// UseCaseUpdateUserSettings mocks base method.
+func (m *MockUseCaseStorage) UseCaseUpdateUserSettings(ctx context.Context, userID int, update models.UserSettingsUpdate) (models.UserSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCaseUpdateUserSettings", ctx, userID, update)
+	ret0, _ := ret[0].(models.UserSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCaseUpdateUserSettings indicates an expected call of UseCaseUpdateUserSettings.
+func (mr *MockUseCaseStorageMockRecorder) UseCaseUpdateUserSettings(ctx, userID, update interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseUpdate", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseUpdate), userID, userData)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCaseUpdateUserSettings", reflect.TypeOf((*MockUseCaseStorage)(nil).UseCaseUpdateUserSettings), ctx, userID, update)
 }
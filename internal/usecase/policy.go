@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time-tracker/internal/auth"
+	"time-tracker/internal/domain"
+)
+
+// action - действие, право на которое проверяет политика доступа
+type action string
+
+const (
+	actionManageUsers   action = "users:manage"        // создание, изменение, удаление и список пользователей, роли и команды
+	actionReadUser      action = "user:read"           // профиль пользователя и состав его команды
+	actionCredentials   action = "user:credentials"    // логин и пароль
+	actionSettings      action = "user:settings"       // настройки и подписка на календарь
+	actionAPIKeys       action = "user:api-keys"       // ключи API
+	actionManageTasks   action = auth.ScopeTasksWrite  // создание и изменение задач, таймер, теги, импорт
+	actionReadTasks     action = auth.ScopeTasksRead   // задачи и активный таймер
+	actionReadReports   action = auth.ScopeReportsRead // отчет и его выгрузка
+	actionReadCatalog   action = auth.ScopeCatalogRead // клиенты, проекты, теги и роли
+	actionManageCatalog action = "catalog:manage"      // изменение клиентов и проектов
+)
+
+// scope - чьи данные роль может затрагивать действием
+type scope int
+
+const (
+	scopeOwn  scope = iota + 1 // только свои
+	scopeTeam                  // свои и своей команды (пользователей, у которых он менеджер)
+	scopeAll                   // любые, в том числе данные без владельца (справочники, список пользователей)
+)
+
+// policy - матрица прав: действие -> роль -> область. Роли, которой нет в строке действия, оно запрещено.
+var policy = map[action]map[string]scope{
+	actionManageUsers:   {auth.RoleAdmin: scopeAll},
+	actionReadUser:      {auth.RoleMember: scopeOwn, auth.RoleManager: scopeTeam, auth.RoleAdmin: scopeAll},
+	actionCredentials:   {auth.RoleMember: scopeOwn, auth.RoleManager: scopeOwn, auth.RoleAdmin: scopeAll},
+	actionSettings:      {auth.RoleMember: scopeOwn, auth.RoleManager: scopeOwn, auth.RoleAdmin: scopeAll},
+	actionAPIKeys:       {auth.RoleMember: scopeOwn, auth.RoleManager: scopeOwn, auth.RoleAdmin: scopeAll},
+	actionManageTasks:   {auth.RoleMember: scopeOwn, auth.RoleManager: scopeOwn, auth.RoleAdmin: scopeAll},
+	actionReadTasks:     {auth.RoleMember: scopeOwn, auth.RoleManager: scopeOwn, auth.RoleAdmin: scopeAll},
+	actionReadReports:   {auth.RoleMember: scopeOwn, auth.RoleManager: scopeTeam, auth.RoleAdmin: scopeAll},
+	actionReadCatalog:   {auth.RoleMember: scopeAll, auth.RoleManager: scopeAll, auth.RoleAdmin: scopeAll},
+	actionManageCatalog: {auth.RoleAdmin: scopeAll},
+}
+
+// principal возвращает пользователя из контекста, если права его ключа API (если запрос по ключу) допускают act
+func principal(ctx context.Context, act action) (auth.Principal, error) {
+	p, err := auth.FromContext(ctx)
+	if err != nil {
+		return auth.Principal{}, err
+	}
+	if !p.Allows(string(act)) {
+		return auth.Principal{}, fmt.Errorf("%w: у ключа API нет права %s", domain.ErrForbidden, act)
+	}
+	return p, nil
+}
+
+// authorize проверяет, может ли пользователь из контекста выполнить act над данными пользователя ownerID;
+// ownerID = 0 - данные без владельца, они доступны только с областью scopeAll.
+// Запрос по ключу API дополнительно ограничен правами ключа, но не больше, чем разрешено роли владельца.
+// Без пользователя в контексте - ErrUnauthorized, без права - ErrForbidden.
+func (uc *useCaseStorage) authorize(ctx context.Context, act action, ownerID int) error {
+	p, err := principal(ctx, act)
+	if err != nil {
+		return err
+	}
+
+	switch scope := policy[act][p.Role]; {
+	case scope == scopeAll:
+		return nil
+	case scope == 0 || ownerID == 0:
+	case ownerID == p.UserID:
+		return nil
+	case scope == scopeTeam:
+		member, err := uc.storage.IsTeamMember(ctx, p.UserID, ownerID)
+		if err != nil {
+			return err
+		}
+		if member {
+			return nil
+		}
+	}
+
+	if ownerID == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrForbidden, act)
+	}
+	return fmt.Errorf("%w: %s, пользователь %d", domain.ErrForbidden, act, ownerID)
+}
+
+// authorizeTask проверяет право на act над задачей taskID по ее владельцу.
+// Владелец не запрашивается, если роли действие разрешено над любыми данными.
+// Остальным несуществующая задача отвечает так же, как чужая, чтобы по ответам
+// нельзя было перебрать ID задач.
+func (uc *useCaseStorage) authorizeTask(ctx context.Context, act action, taskID int) error {
+	p, err := principal(ctx, act)
+	if err != nil {
+		return err
+	}
+	if policy[act][p.Role] == scopeAll {
+		return nil
+	}
+
+	owner, err := uc.storage.TaskOwner(ctx, taskID)
+	if errors.Is(err, domain.ErrTaskNotFound) {
+		return fmt.Errorf("%w: %s", domain.ErrForbidden, act)
+	}
+	if err != nil {
+		return err
+	}
+	return uc.authorize(ctx, act, owner)
+}
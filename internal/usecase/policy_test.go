@@ -0,0 +1,408 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+	"time-tracker/internal/auth"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/models"
+	"time-tracker/internal/storage/mocks"
+)
+
+// Участники матрицы доступа. Все данные принадлежат owner (пользователь 1, задача 10),
+// manager - его менеджер, otherManager руководит другой командой.
+const (
+	subjAdmin        = "admin"
+	subjManager      = "manager"
+	subjOtherManager = "otherManager"
+	subjOwner        = "owner"
+	subjStranger     = "stranger"
+)
+
+var subjects = map[string]auth.Principal{
+	subjAdmin:        {UserID: 3, Role: auth.RoleAdmin},
+	subjManager:      {UserID: 2, Role: auth.RoleManager},
+	subjOtherManager: {UserID: 4, Role: auth.RoleManager},
+	subjOwner:        {UserID: 1, Role: auth.RoleMember},
+	subjStranger:     {UserID: 5, Role: auth.RoleMember},
+}
+
+// newPolicyStorage возвращает хранилище, которое принимает любые вызовы: матрица проверяет только,
+// пропускает ли политика вызов дальше, а не результат
+func newPolicyStorage(ctrl *gomock.Controller) *mocks.MockRepositoryDB {
+	storage := mocks.NewMockRepositoryDB(ctrl)
+	storage.EXPECT().TaskOwner(gomock.Any(), gomock.Any()).Return(1, nil).AnyTimes()
+	storage.EXPECT().IsTeamMember(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, managerID, userID int) (bool, error) {
+			return managerID == 2 && userID == 1, nil
+		}).AnyTimes()
+	storage.EXPECT().Create(gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().Read(gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().Delete(gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().GetUsers(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().CreateTask(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().ReadTask(gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().CreateManualTask(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().UpdateTaskTime(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().AddStartTime(gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().AddEndTime(gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().PauseTask(gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().ResumeTask(gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().RenameTask(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().DeleteTask(gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().GetActiveTimer(gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().ReadUserSettings(gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().UpdateUserSettings(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().Report(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().ExportReport(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().SetCalendarToken(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().ReadCalendarTokenHash(gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().CalendarEvents(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().ImportTasks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().CreateClient(gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().ReadClient(gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().ListClients(gomock.Any()).AnyTimes()
+	storage.EXPECT().UpdateClient(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().DeleteClient(gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().CreateProject(gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().ReadProject(gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().ListProjects(gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().UpdateProject(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().DeleteProject(gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().SetTaskProject(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().ListTags(gomock.Any()).AnyTimes()
+	storage.EXPECT().AddTaskTags(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().RemoveTaskTag(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().SetCredentials(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().ReadCredentials(gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().SetUserRole(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().ListRoles(gomock.Any()).AnyTimes()
+	storage.EXPECT().SetUserManager(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().ListTeam(gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().ListAPIKeys(gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().RevokeAPIKey(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().CreatePendingUser(gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().ReadEnrichmentStatus(gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().RetryEnrichment(gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().RotateRefreshToken(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().RevokeRefreshToken(gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().GetTasksUser(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().ListTasks(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().ExportTasks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	storage.EXPECT().Ping(gomock.Any()).AnyTimes()
+	storage.EXPECT().MigrationVersion(gomock.Any()).AnyTimes()
+	return storage
+}
+
+// TestPolicyMatrix проверяет разрешения каждого эндпоинта для каждой роли
+func TestPolicyMatrix(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := NewUseCaseStorage(newPolicyStorage(ctrl), nil)
+	start := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+	ignoreRow := func(models.ExportRow) error { return nil }
+
+	all := []string{subjAdmin, subjManager, subjOtherManager, subjOwner, subjStranger}
+	adminOnly := []string{subjAdmin}
+	ownerOnly := []string{subjAdmin, subjOwner}
+	ownerAndTeam := []string{subjAdmin, subjManager, subjOwner}
+
+	tests := []struct {
+		endpoint string
+		call     func(ctx context.Context) error
+		allowed  []string
+	}{
+		{"POST /user", func(ctx context.Context) error {
+			_, err := uc.UseCaseCreatePendingUser(ctx, "1234 567890")
+			return err
+		}, adminOnly},
+		{"POST /test", func(ctx context.Context) error {
+			_, err := uc.UseCaseCreate(ctx, models.UserData{})
+			return err
+		}, adminOnly},
+		{"GET /users/{userID}/enrichment", func(ctx context.Context) error {
+			_, err := uc.UseCaseReadEnrichmentStatus(ctx, 1)
+			return err
+		}, ownerAndTeam},
+		{"POST /users/{userID}/enrichment", func(ctx context.Context) error {
+			_, err := uc.UseCaseRetryEnrichment(ctx, 1)
+			return err
+		}, adminOnly},
+		{"GET /user/{userID}", func(ctx context.Context) error {
+			_, err := uc.UseCaseRead(ctx, 1)
+			return err
+		}, ownerAndTeam},
+		{"PUT /user/{userID}", func(ctx context.Context) error {
+			return uc.UseCaseUpdate(ctx, 1, models.UserData{})
+		}, adminOnly},
+		{"DELETE /user/{userID}", func(ctx context.Context) error {
+			return uc.UseCaseDelete(ctx, 1)
+		}, adminOnly},
+		{"POST /users/{page}/{limit}", func(ctx context.Context) error {
+			_, err := uc.UseCaseGetUsers(ctx, models.UserData{}, 1, 10)
+			return err
+		}, adminOnly},
+		{"PUT /users/{userID}/credentials", func(ctx context.Context) error {
+			return uc.UseCaseSetCredentials(ctx, 1, models.Credentials{Login: "ivanov", Password: "password1"})
+		}, ownerOnly},
+		{"PUT /users/{userID}/role", func(ctx context.Context) error {
+			return uc.UseCaseSetUserRole(ctx, 1, auth.RoleManager)
+		}, adminOnly},
+		{"PUT /users/{userID}/manager", func(ctx context.Context) error {
+			managerID := 2
+			return uc.UseCaseSetUserManager(ctx, 1, &managerID)
+		}, adminOnly},
+		{"GET /users/{userID}/team", func(ctx context.Context) error {
+			_, err := uc.UseCaseListTeam(ctx, 2)
+			return err
+		}, []string{subjAdmin, subjManager}},
+		{"GET /roles", func(ctx context.Context) error {
+			_, err := uc.UseCaseListRoles(ctx)
+			return err
+		}, all},
+		{"POST /users/{userID}/api-keys", func(ctx context.Context) error {
+			_, err := uc.UseCaseCreateAPIKey(ctx, 1, models.APIKeyRequest{Name: "ci-bot", Scopes: []string{auth.ScopeTasksWrite}})
+			return err
+		}, ownerOnly},
+		{"GET /users/{userID}/api-keys", func(ctx context.Context) error {
+			_, err := uc.UseCaseListAPIKeys(ctx, 1)
+			return err
+		}, ownerOnly},
+		{"DELETE /users/{userID}/api-keys/{keyID}", func(ctx context.Context) error {
+			return uc.UseCaseRevokeAPIKey(ctx, 1, 1)
+		}, ownerOnly},
+		{"POST /task/{userID}", func(ctx context.Context) error {
+			_, err := uc.UseCaseCreateTask(ctx, 1, "Отчет")
+			return err
+		}, ownerOnly},
+		{"POST /users/{userID}/tasks", func(ctx context.Context) error {
+			_, err := uc.UseCaseCreateManualTask(ctx, 1, "Отчет", start, start.Add(time.Hour))
+			return err
+		}, ownerOnly},
+		{"POST /users/{userID}/import", func(ctx context.Context) error {
+			_, err := uc.UseCaseImportTasks(ctx, 1, "csv", strings.NewReader("name_task,start,end\n"), true)
+			return err
+		}, ownerOnly},
+		{"PATCH /task/{taskID}", func(ctx context.Context) error {
+			return uc.UseCaseRenameTask(ctx, 10, "Отчет")
+		}, ownerOnly},
+		{"DELETE /task/{taskID}", func(ctx context.Context) error {
+			return uc.UseCaseDeleteTask(ctx, 10)
+		}, ownerOnly},
+		{"PUT /task/start/{taskID}", func(ctx context.Context) error {
+			return uc.UseCaseAddStartTime(ctx, 10)
+		}, ownerOnly},
+		{"PUT /task/end/{taskID}", func(ctx context.Context) error {
+			return uc.UseCaseAddEndTime(ctx, 10)
+		}, ownerOnly},
+		{"PUT /task/pause/{taskID}", func(ctx context.Context) error {
+			return uc.UseCasePauseTask(ctx, 10)
+		}, ownerOnly},
+		{"PUT /task/resume/{taskID}", func(ctx context.Context) error {
+			return uc.UseCaseResumeTask(ctx, 10)
+		}, ownerOnly},
+		{"PUT /task/time/{taskID}", func(ctx context.Context) error {
+			return uc.UseCaseUpdateTaskTime(ctx, 10, start, start.Add(time.Hour))
+		}, ownerOnly},
+		{"PUT /task/project/{taskID}", func(ctx context.Context) error {
+			return uc.UseCaseSetTaskProject(ctx, 10, nil)
+		}, ownerOnly},
+		{"POST /task/tags/{taskID}", func(ctx context.Context) error {
+			_, err := uc.UseCaseAddTaskTags(ctx, 10, []string{"meeting"})
+			return err
+		}, ownerOnly},
+		{"DELETE /task/tags/{taskID}/{tag}", func(ctx context.Context) error {
+			_, err := uc.UseCaseRemoveTaskTag(ctx, 10, "meeting")
+			return err
+		}, ownerOnly},
+		{"POST /tasks/{userID}", func(ctx context.Context) error {
+			_, err := uc.UseCaseGetTasksUser(ctx, 1, models.TaskTime{})
+			return err
+		}, ownerOnly},
+		{"GET /users/{userID}/tasks", func(ctx context.Context) error {
+			_, err := uc.UseCaseListTasks(ctx, 1, models.TaskFilter{})
+			return err
+		}, ownerOnly},
+		{"GET /users/{userID}/tasks?format=csv", func(ctx context.Context) error {
+			return uc.UseCaseExportTasks(ctx, 1, models.TaskFilter{}, ignoreRow)
+		}, ownerOnly},
+		{"GET /users/{userID}/timer", func(ctx context.Context) error {
+			_, err := uc.UseCaseGetActiveTimer(ctx, 1)
+			return err
+		}, ownerOnly},
+		{"GET /users/{userID}/report", func(ctx context.Context) error {
+			_, err := uc.UseCaseReport(ctx, 1, models.ReportFilter{})
+			return err
+		}, ownerAndTeam},
+		{"GET /users/{userID}/report?format=csv", func(ctx context.Context) error {
+			return uc.UseCaseExportReport(ctx, 1, models.ReportFilter{}, ignoreRow)
+		}, ownerAndTeam},
+		{"GET /users/{userID}/settings", func(ctx context.Context) error {
+			_, err := uc.UseCaseReadUserSettings(ctx, 1)
+			return err
+		}, ownerOnly},
+		{"PATCH /users/{userID}/settings", func(ctx context.Context) error {
+			_, err := uc.UseCaseUpdateUserSettings(ctx, 1, models.UserSettingsUpdate{})
+			return err
+		}, ownerOnly},
+		{"POST /users/{userID}/calendar/token", func(ctx context.Context) error {
+			_, err := uc.UseCaseCreateCalendarToken(ctx, 1)
+			return err
+		}, ownerOnly},
+		{"POST /clients", func(ctx context.Context) error {
+			_, err := uc.UseCaseCreateClient(ctx, "Рога и копыта")
+			return err
+		}, adminOnly},
+		{"GET /clients", func(ctx context.Context) error {
+			_, err := uc.UseCaseListClients(ctx)
+			return err
+		}, all},
+		{"GET /clients/{clientID}", func(ctx context.Context) error {
+			_, err := uc.UseCaseReadClient(ctx, 1)
+			return err
+		}, all},
+		{"PUT /clients/{clientID}", func(ctx context.Context) error {
+			return uc.UseCaseUpdateClient(ctx, 1, "Рога и копыта")
+		}, adminOnly},
+		{"DELETE /clients/{clientID}", func(ctx context.Context) error {
+			return uc.UseCaseDeleteClient(ctx, 1)
+		}, adminOnly},
+		{"POST /projects", func(ctx context.Context) error {
+			_, err := uc.UseCaseCreateProject(ctx, models.ProjectRequest{Name: "Сайт"})
+			return err
+		}, adminOnly},
+		{"GET /projects", func(ctx context.Context) error {
+			_, err := uc.UseCaseListProjects(ctx, 0)
+			return err
+		}, all},
+		{"GET /projects/{projectID}", func(ctx context.Context) error {
+			_, err := uc.UseCaseReadProject(ctx, 1)
+			return err
+		}, all},
+		{"PUT /projects/{projectID}", func(ctx context.Context) error {
+			return uc.UseCaseUpdateProject(ctx, 1, models.ProjectRequest{Name: "Сайт"})
+		}, adminOnly},
+		{"DELETE /projects/{projectID}", func(ctx context.Context) error {
+			return uc.UseCaseDeleteProject(ctx, 1)
+		}, adminOnly},
+		{"GET /tags", func(ctx context.Context) error {
+			_, err := uc.UseCaseListTags(ctx)
+			return err
+		}, all},
+	}
+
+	for _, tt := range tests {
+		for _, subject := range all {
+			allowed := false
+			for _, s := range tt.allowed {
+				allowed = allowed || s == subject
+			}
+
+			t.Run(tt.endpoint+"/"+subject, func(t *testing.T) {
+				err := tt.call(auth.WithPrincipal(context.Background(), subjects[subject]))
+				if allowed {
+					assert.False(t, errors.Is(err, domain.ErrForbidden), "%s: ожидался доступ, получено %v", subject, err)
+				} else {
+					assert.True(t, errors.Is(err, domain.ErrForbidden), "%s: ожидался запрет, получено %v", subject, err)
+				}
+			})
+		}
+
+		t.Run(tt.endpoint+"/anonymous", func(t *testing.T) {
+			err := tt.call(context.Background())
+			assert.True(t, errors.Is(err, domain.ErrUnauthorized), err)
+		})
+	}
+}
+
+// TestPolicyAPIKeyScopes проверяет, что запрос по ключу API ограничен и правами ключа, и ролью владельца
+func TestPolicyAPIKeyScopes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := NewUseCaseStorage(newPolicyStorage(ctrl), nil)
+	owner := subjects[subjOwner]
+	manager := subjects[subjManager]
+	key := func(p auth.Principal, scopes ...string) context.Context {
+		// пустой, но не nil список: у ключа нет ни одного права
+		p.Scopes = append([]string{}, scopes...)
+		return auth.WithPrincipal(context.Background(), p)
+	}
+
+	tests := []struct {
+		name    string
+		call    func() error
+		allowed bool
+	}{
+		{"#1 tasks:write запускает таймер", func() error {
+			return uc.UseCaseAddStartTime(key(owner, auth.ScopeTasksWrite), 10)
+		}, true},
+		{"#2 tasks:write не читает отчет", func() error {
+			_, err := uc.UseCaseReport(key(owner, auth.ScopeTasksWrite), 1, models.ReportFilter{})
+			return err
+		}, false},
+		{"#3 reports:read читает свой отчет", func() error {
+			_, err := uc.UseCaseReport(key(owner, auth.ScopeReportsRead), 1, models.ReportFilter{})
+			return err
+		}, true},
+		{"#4 reports:read менеджера читает отчет команды", func() error {
+			_, err := uc.UseCaseReport(key(manager, auth.ScopeReportsRead), 1, models.ReportFilter{})
+			return err
+		}, true},
+		{"#5 Права ключа не расширяют роль", func() error {
+			_, err := uc.UseCaseReport(key(owner, auth.ScopeReportsRead), 5, models.ReportFilter{})
+			return err
+		}, false},
+		{"#6 Ключ без прав", func() error {
+			_, err := uc.UseCaseListTags(key(owner))
+			return err
+		}, false},
+		{"#7 Ключ не управляет ключами", func() error {
+			_, err := uc.UseCaseListAPIKeys(key(owner, auth.Scopes...), 1)
+			return err
+		}, false},
+		{"#8 Ключ администратора не управляет пользователями", func() error {
+			return uc.UseCaseDelete(key(subjects[subjAdmin], auth.Scopes...), 1)
+		}, false},
+		{"#9 Ключ не меняет пароль", func() error {
+			return uc.UseCaseSetCredentials(key(owner, auth.Scopes...), 1, models.Credentials{Login: "ivanov", Password: "password1"})
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			assert.Equal(t, !tt.allowed, errors.Is(err, domain.ErrForbidden), err)
+		})
+	}
+}
+
+func TestPolicyTaskNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := mocks.NewMockRepositoryDB(ctrl)
+	uc := NewUseCaseStorage(storage, nil)
+
+	// для участника несуществующая задача неотличима от чужой: 403
+	ctx := auth.WithPrincipal(context.Background(), subjects[subjStranger])
+	storage.EXPECT().TaskOwner(ctx, 11).Return(0, domain.ErrTaskNotFound)
+	err := uc.UseCaseAddStartTime(ctx, 11)
+	assert.True(t, errors.Is(err, domain.ErrForbidden), err)
+	assert.False(t, errors.Is(err, domain.ErrTaskNotFound), err)
+
+	// администратору доступны все задачи, поэтому он получает 404
+	ctx = auth.WithPrincipal(context.Background(), subjects[subjAdmin])
+	storage.EXPECT().AddStartTime(ctx, 11).Return(domain.ErrTaskNotFound)
+	err = uc.UseCaseAddStartTime(ctx, 11)
+	assert.True(t, errors.Is(err, domain.ErrTaskNotFound), err)
+}
@@ -1,16 +1,69 @@
 package usecase
 
-import "time-tracker/internal/models"
+import (
+	"context"
+	"io"
+	"time"
+	"time-tracker/internal/auth"
+	"time-tracker/internal/models"
+)
 
 type UseCaseStorage interface {
-	UseCaseCreate(userData models.UserData) (int, error)
-	UseCaseRead(userID int) (models.UserData, error)
-	UseCaseUpdate(userID int, userData models.UserData) error
-	UseCaseDelete(userID int) error
-	UseCaseGetUsers(dataUser models.UserData, page, limit int) ([]models.UserData, error)
-	UseCaseCreateTask(userID int, nameTask string) (int, error)
-	UseCaseReadTask(taskID int) (models.TaskData, error)
-	UseCaseAddStartTime(taskID int) error
-	UseCaseAddEndTime(taskID int) error
-	UseCaseGetTasksUser(userID int, timeTask models.TaskTime) ([]models.Tasks, error)
+	UseCaseCreate(ctx context.Context, userData models.UserData) (int, error)
+	UseCaseRead(ctx context.Context, userID int) (models.UserData, error)
+	UseCaseUpdate(ctx context.Context, userID int, userData models.UserData) error
+	UseCaseDelete(ctx context.Context, userID int) error
+	UseCaseGetUsers(ctx context.Context, dataUser models.UserData, page, limit int) ([]models.UserData, error)
+	UseCaseCreateTask(ctx context.Context, userID int, nameTask string) (int, error)
+	UseCaseReadTask(ctx context.Context, taskID int) (models.TaskData, error)
+	UseCaseCreateManualTask(ctx context.Context, userID int, nameTask string, start, end time.Time) (int, error)
+	UseCaseUpdateTaskTime(ctx context.Context, taskID int, start, end time.Time) error
+	UseCaseAddStartTime(ctx context.Context, taskID int) error
+	UseCaseAddEndTime(ctx context.Context, taskID int) error
+	UseCasePauseTask(ctx context.Context, taskID int) error
+	UseCaseResumeTask(ctx context.Context, taskID int) error
+	UseCaseRenameTask(ctx context.Context, taskID int, nameTask string) error
+	UseCaseDeleteTask(ctx context.Context, taskID int) error
+	UseCaseGetActiveTimer(ctx context.Context, userID int) (models.Timer, error)
+	UseCaseReadUserSettings(ctx context.Context, userID int) (models.UserSettings, error)
+	UseCaseUpdateUserSettings(ctx context.Context, userID int, update models.UserSettingsUpdate) (models.UserSettings, error)
+	UseCaseReport(ctx context.Context, userID int, filter models.ReportFilter) (models.Report, error)
+	UseCaseExportReport(ctx context.Context, userID int, filter models.ReportFilter, row func(models.ExportRow) error) error
+	UseCaseCreateCalendarToken(ctx context.Context, userID int) (string, error)
+	UseCaseCalendarEvents(ctx context.Context, userID int, token string, event func(models.CalendarEvent) error) error
+	UseCaseImportTasks(ctx context.Context, userID int, format string, file io.Reader, dryRun bool) (models.ImportResult, error)
+	UseCaseCreateClient(ctx context.Context, name string) (int, error)
+	UseCaseReadClient(ctx context.Context, clientID int) (models.Client, error)
+	UseCaseListClients(ctx context.Context) ([]models.Client, error)
+	UseCaseUpdateClient(ctx context.Context, clientID int, name string) error
+	UseCaseDeleteClient(ctx context.Context, clientID int) error
+	UseCaseCreateProject(ctx context.Context, project models.ProjectRequest) (int, error)
+	UseCaseReadProject(ctx context.Context, projectID int) (models.Project, error)
+	UseCaseListProjects(ctx context.Context, clientID int) ([]models.Project, error)
+	UseCaseUpdateProject(ctx context.Context, projectID int, project models.ProjectRequest) error
+	UseCaseDeleteProject(ctx context.Context, projectID int) error
+	UseCaseSetTaskProject(ctx context.Context, taskID int, projectID *int) error
+	UseCaseListTags(ctx context.Context) ([]models.Tag, error)
+	UseCaseAddTaskTags(ctx context.Context, taskID int, tags []string) ([]string, error)
+	UseCaseRemoveTaskTag(ctx context.Context, taskID int, tag string) ([]string, error)
+	UseCaseLogin(ctx context.Context, creds models.Credentials) (models.TokenResponse, error)
+	UseCaseRefresh(ctx context.Context, refreshToken string) (models.TokenResponse, error)
+	UseCaseLogout(ctx context.Context, refreshToken string) error
+	UseCaseSetCredentials(ctx context.Context, userID int, creds models.Credentials) error
+	UseCaseSetUserRole(ctx context.Context, userID int, role string) error
+	UseCaseListRoles(ctx context.Context) ([]models.Role, error)
+	UseCaseSetUserManager(ctx context.Context, userID int, managerID *int) error
+	UseCaseListTeam(ctx context.Context, managerID int) ([]models.UserData, error)
+	UseCaseCreateAPIKey(ctx context.Context, userID int, req models.APIKeyRequest) (models.APIKeyCreated, error)
+	UseCaseListAPIKeys(ctx context.Context, userID int) ([]models.APIKey, error)
+	UseCaseRevokeAPIKey(ctx context.Context, userID, keyID int) error
+	UseCaseAuthenticateAPIKey(ctx context.Context, key string) (auth.Principal, error)
+	UseCaseCreatePendingUser(ctx context.Context, passportNumber string) (int, error)
+	UseCaseReadEnrichmentStatus(ctx context.Context, userID int) (models.EnrichmentStatus, error)
+	UseCaseRetryEnrichment(ctx context.Context, userID int) (models.EnrichmentStatus, error)
+	UseCaseGetTasksUser(ctx context.Context, userID int, timeTask models.TaskTime) ([]models.Tasks, error)
+	UseCaseListTasks(ctx context.Context, userID int, filter models.TaskFilter) (models.TaskPage, error)
+	UseCaseExportTasks(ctx context.Context, userID int, filter models.TaskFilter, row func(models.ExportRow) error) error
+	UseCasePing(ctx context.Context) error
+	UseCaseMigrationVersion(ctx context.Context) (uint, bool, error)
 }
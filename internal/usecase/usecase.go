@@ -1,52 +1,286 @@
 package usecase
 
 import (
+	"context"
+	"time"
+	"time-tracker/internal/auth"
 	"time-tracker/internal/models"
 	"time-tracker/internal/storage"
 )
 
 type useCaseStorage struct {
 	storage storage.RepositoryDB
+	tokens  *auth.Tokens
 }
 
-func NewUseCaseStorage(storage storage.RepositoryDB) UseCaseStorage {
-	return &useCaseStorage{storage: storage}
+// NewUseCaseStorage создает сценарии поверх хранилища; tokens нужны только для входа
+// и обновления токенов, подкомандам без входа достаточно nil
+func NewUseCaseStorage(storage storage.RepositoryDB, tokens *auth.Tokens) UseCaseStorage {
+	return &useCaseStorage{storage: storage, tokens: tokens}
 }
-func (uc *useCaseStorage) UseCaseCreate(userData models.UserData) (int, error) {
-	return uc.storage.Create(userData)
+
+func (uc *useCaseStorage) UseCaseCreate(ctx context.Context, userData models.UserData) (int, error) {
+	if err := uc.authorize(ctx, actionManageUsers, 0); err != nil {
+		return 0, err
+	}
+	return uc.storage.Create(ctx, userData)
+}
+
+func (uc *useCaseStorage) UseCaseRead(ctx context.Context, userID int) (models.UserData, error) {
+	if err := uc.authorize(ctx, actionReadUser, userID); err != nil {
+		return models.UserData{}, err
+	}
+	return uc.storage.Read(ctx, userID)
+}
+
+func (uc *useCaseStorage) UseCaseUpdate(ctx context.Context, userID int, userData models.UserData) error {
+	if err := uc.authorize(ctx, actionManageUsers, 0); err != nil {
+		return err
+	}
+	return uc.storage.Update(ctx, userID, userData)
+}
+
+func (uc *useCaseStorage) UseCaseDelete(ctx context.Context, userID int) error {
+	if err := uc.authorize(ctx, actionManageUsers, 0); err != nil {
+		return err
+	}
+	return uc.storage.Delete(ctx, userID)
+}
+
+func (uc *useCaseStorage) UseCaseGetUsers(ctx context.Context, dataUser models.UserData, page, limit int) ([]models.UserData, error) {
+	if err := uc.authorize(ctx, actionManageUsers, 0); err != nil {
+		return nil, err
+	}
+	return uc.storage.GetUsers(ctx, dataUser, page, limit)
+}
+
+func (uc *useCaseStorage) UseCaseCreateTask(ctx context.Context, userID int, nameTask string) (int, error) {
+	if err := uc.authorize(ctx, actionManageTasks, userID); err != nil {
+		return 0, err
+	}
+	return uc.storage.CreateTask(ctx, userID, nameTask)
+}
+
+func (uc *useCaseStorage) UseCaseReadTask(ctx context.Context, taskID int) (models.TaskData, error) {
+	if err := uc.authorizeTask(ctx, actionReadTasks, taskID); err != nil {
+		return models.TaskData{}, err
+	}
+	return uc.storage.ReadTask(ctx, taskID)
+}
+
+func (uc *useCaseStorage) UseCaseCreateManualTask(ctx context.Context, userID int, nameTask string, start, end time.Time) (int, error) {
+	if err := uc.authorize(ctx, actionManageTasks, userID); err != nil {
+		return 0, err
+	}
+	return uc.storage.CreateManualTask(ctx, userID, nameTask, start, end)
+}
+
+func (uc *useCaseStorage) UseCaseUpdateTaskTime(ctx context.Context, taskID int, start, end time.Time) error {
+	if err := uc.authorizeTask(ctx, actionManageTasks, taskID); err != nil {
+		return err
+	}
+	return uc.storage.UpdateTaskTime(ctx, taskID, start, end)
+}
+func (uc *useCaseStorage) UseCaseAddStartTime(ctx context.Context, taskID int) error {
+	if err := uc.authorizeTask(ctx, actionManageTasks, taskID); err != nil {
+		return err
+	}
+	return uc.storage.AddStartTime(ctx, taskID)
+}
+
+func (uc *useCaseStorage) UseCaseAddEndTime(ctx context.Context, taskID int) error {
+	if err := uc.authorizeTask(ctx, actionManageTasks, taskID); err != nil {
+		return err
+	}
+	return uc.storage.AddEndTime(ctx, taskID)
+}
+
+func (uc *useCaseStorage) UseCasePauseTask(ctx context.Context, taskID int) error {
+	if err := uc.authorizeTask(ctx, actionManageTasks, taskID); err != nil {
+		return err
+	}
+	return uc.storage.PauseTask(ctx, taskID)
+}
+
+func (uc *useCaseStorage) UseCaseResumeTask(ctx context.Context, taskID int) error {
+	if err := uc.authorizeTask(ctx, actionManageTasks, taskID); err != nil {
+		return err
+	}
+	return uc.storage.ResumeTask(ctx, taskID)
+}
+
+func (uc *useCaseStorage) UseCaseRenameTask(ctx context.Context, taskID int, nameTask string) error {
+	if err := uc.authorizeTask(ctx, actionManageTasks, taskID); err != nil {
+		return err
+	}
+	return uc.storage.RenameTask(ctx, taskID, nameTask)
+}
+
+func (uc *useCaseStorage) UseCaseDeleteTask(ctx context.Context, taskID int) error {
+	if err := uc.authorizeTask(ctx, actionManageTasks, taskID); err != nil {
+		return err
+	}
+	return uc.storage.DeleteTask(ctx, taskID)
+}
+
+func (uc *useCaseStorage) UseCaseGetActiveTimer(ctx context.Context, userID int) (models.Timer, error) {
+	if err := uc.authorize(ctx, actionReadTasks, userID); err != nil {
+		return models.Timer{}, err
+	}
+	return uc.storage.GetActiveTimer(ctx, userID)
 }
 
-func (uc *useCaseStorage) UseCaseRead(userID int) (models.UserData, error) {
-	return uc.storage.Read(userID)
+func (uc *useCaseStorage) UseCaseReadUserSettings(ctx context.Context, userID int) (models.UserSettings, error) {
+	if err := uc.authorize(ctx, actionSettings, userID); err != nil {
+		return models.UserSettings{}, err
+	}
+	return uc.storage.ReadUserSettings(ctx, userID)
 }
 
-func (uc *useCaseStorage) UseCaseUpdate(userID int, userData models.UserData) error {
-	return uc.storage.Update(userID, userData)
+func (uc *useCaseStorage) UseCaseUpdateUserSettings(ctx context.Context, userID int, update models.UserSettingsUpdate) (models.UserSettings, error) {
+	if err := uc.authorize(ctx, actionSettings, userID); err != nil {
+		return models.UserSettings{}, err
+	}
+	return uc.storage.UpdateUserSettings(ctx, userID, update)
 }
 
-func (uc *useCaseStorage) UseCaseDelete(userID int) error {
-	return uc.storage.Delete(userID)
+func (uc *useCaseStorage) UseCaseReport(ctx context.Context, userID int, filter models.ReportFilter) (models.Report, error) {
+	if err := uc.authorize(ctx, actionReadReports, userID); err != nil {
+		return models.Report{}, err
+	}
+	return uc.storage.Report(ctx, userID, filter)
 }
 
-func (uc *useCaseStorage) UseCaseGetUsers(dataUser models.UserData, page, limit int) ([]models.UserData, error) {
-	return uc.storage.GetUsers(dataUser, page, limit)
+func (uc *useCaseStorage) UseCaseExportReport(ctx context.Context, userID int, filter models.ReportFilter, row func(models.ExportRow) error) error {
+	if err := uc.authorize(ctx, actionReadReports, userID); err != nil {
+		return err
+	}
+	return uc.storage.ExportReport(ctx, userID, filter, row)
 }
 
-func (uc *useCaseStorage) UseCaseCreateTask(userID int, nameTask string) (int, error) {
-	return uc.storage.CreateTask(userID, nameTask)
+func (uc *useCaseStorage) UseCaseCreateClient(ctx context.Context, name string) (int, error) {
+	if err := uc.authorize(ctx, actionManageCatalog, 0); err != nil {
+		return 0, err
+	}
+	return uc.storage.CreateClient(ctx, name)
 }
 
-func (uc *useCaseStorage) UseCaseReadTask(taskID int) (models.TaskData, error) {
-	return uc.storage.ReadTask(taskID)
+func (uc *useCaseStorage) UseCaseReadClient(ctx context.Context, clientID int) (models.Client, error) {
+	if err := uc.authorize(ctx, actionReadCatalog, 0); err != nil {
+		return models.Client{}, err
+	}
+	return uc.storage.ReadClient(ctx, clientID)
 }
-func (uc *useCaseStorage) UseCaseAddStartTime(taskID int) error {
-	return uc.storage.AddStartTime(taskID)
+
+func (uc *useCaseStorage) UseCaseListClients(ctx context.Context) ([]models.Client, error) {
+	if err := uc.authorize(ctx, actionReadCatalog, 0); err != nil {
+		return nil, err
+	}
+	return uc.storage.ListClients(ctx)
+}
+
+func (uc *useCaseStorage) UseCaseUpdateClient(ctx context.Context, clientID int, name string) error {
+	if err := uc.authorize(ctx, actionManageCatalog, 0); err != nil {
+		return err
+	}
+	return uc.storage.UpdateClient(ctx, clientID, name)
+}
+
+func (uc *useCaseStorage) UseCaseDeleteClient(ctx context.Context, clientID int) error {
+	if err := uc.authorize(ctx, actionManageCatalog, 0); err != nil {
+		return err
+	}
+	return uc.storage.DeleteClient(ctx, clientID)
+}
+
+func (uc *useCaseStorage) UseCaseCreateProject(ctx context.Context, project models.ProjectRequest) (int, error) {
+	if err := uc.authorize(ctx, actionManageCatalog, 0); err != nil {
+		return 0, err
+	}
+	return uc.storage.CreateProject(ctx, project)
+}
+
+func (uc *useCaseStorage) UseCaseReadProject(ctx context.Context, projectID int) (models.Project, error) {
+	if err := uc.authorize(ctx, actionReadCatalog, 0); err != nil {
+		return models.Project{}, err
+	}
+	return uc.storage.ReadProject(ctx, projectID)
+}
+
+func (uc *useCaseStorage) UseCaseListProjects(ctx context.Context, clientID int) ([]models.Project, error) {
+	if err := uc.authorize(ctx, actionReadCatalog, 0); err != nil {
+		return nil, err
+	}
+	return uc.storage.ListProjects(ctx, clientID)
+}
+
+func (uc *useCaseStorage) UseCaseUpdateProject(ctx context.Context, projectID int, project models.ProjectRequest) error {
+	if err := uc.authorize(ctx, actionManageCatalog, 0); err != nil {
+		return err
+	}
+	return uc.storage.UpdateProject(ctx, projectID, project)
+}
+
+func (uc *useCaseStorage) UseCaseDeleteProject(ctx context.Context, projectID int) error {
+	if err := uc.authorize(ctx, actionManageCatalog, 0); err != nil {
+		return err
+	}
+	return uc.storage.DeleteProject(ctx, projectID)
+}
+
+func (uc *useCaseStorage) UseCaseSetTaskProject(ctx context.Context, taskID int, projectID *int) error {
+	if err := uc.authorizeTask(ctx, actionManageTasks, taskID); err != nil {
+		return err
+	}
+	return uc.storage.SetTaskProject(ctx, taskID, projectID)
+}
+
+func (uc *useCaseStorage) UseCaseListTags(ctx context.Context) ([]models.Tag, error) {
+	if err := uc.authorize(ctx, actionReadCatalog, 0); err != nil {
+		return nil, err
+	}
+	return uc.storage.ListTags(ctx)
+}
+
+func (uc *useCaseStorage) UseCaseAddTaskTags(ctx context.Context, taskID int, tags []string) ([]string, error) {
+	if err := uc.authorizeTask(ctx, actionManageTasks, taskID); err != nil {
+		return nil, err
+	}
+	return uc.storage.AddTaskTags(ctx, taskID, tags)
+}
+
+func (uc *useCaseStorage) UseCaseRemoveTaskTag(ctx context.Context, taskID int, tag string) ([]string, error) {
+	if err := uc.authorizeTask(ctx, actionManageTasks, taskID); err != nil {
+		return nil, err
+	}
+	return uc.storage.RemoveTaskTag(ctx, taskID, tag)
+}
+
+func (uc *useCaseStorage) UseCaseGetTasksUser(ctx context.Context, userID int, timeTask models.TaskTime) ([]models.Tasks, error) {
+	if err := uc.authorize(ctx, actionReadTasks, userID); err != nil {
+		return nil, err
+	}
+	return uc.storage.GetTasksUser(ctx, userID, timeTask)
+}
+
+func (uc *useCaseStorage) UseCaseListTasks(ctx context.Context, userID int, filter models.TaskFilter) (models.TaskPage, error) {
+	if err := uc.authorize(ctx, actionReadTasks, userID); err != nil {
+		return models.TaskPage{}, err
+	}
+	return uc.storage.ListTasks(ctx, userID, filter)
+}
+
+func (uc *useCaseStorage) UseCaseExportTasks(ctx context.Context, userID int, filter models.TaskFilter, row func(models.ExportRow) error) error {
+	if err := uc.authorize(ctx, actionReadTasks, userID); err != nil {
+		return err
+	}
+	return uc.storage.ExportTasks(ctx, userID, filter, row)
 }
 
-func (uc *useCaseStorage) UseCaseAddEndTime(taskID int) error {
-	return uc.storage.AddEndTime(taskID)
+func (uc *useCaseStorage) UseCasePing(ctx context.Context) error {
+	return uc.storage.Ping(ctx)
 }
 
-func (uc *useCaseStorage) UseCaseGetTasksUser(userID int, timeTask models.TaskTime) ([]models.Tasks, error) {
-	return uc.storage.GetTasksUser(userID, timeTask)
+func (uc *useCaseStorage) UseCaseMigrationVersion(ctx context.Context) (uint, bool, error) {
+	return uc.storage.MigrationVersion(ctx)
 }
@@ -7,7 +7,10 @@ import (
 	"math/big"
 	"strconv"
 	"strings"
+	"time-tracker/internal/auth"
+	"time-tracker/internal/domain"
 	"unicode"
+	"unicode/utf8"
 )
 
 func ValidateDigits(input string, ln int) error {
@@ -22,6 +25,126 @@ func ValidateDigits(input string, ln int) error {
 	return nil
 }
 
+// ValidatePassport проверяет строку формата "1234 123456" (серия, пробел, номер)
+// и возвращает серию и номер либо ошибку валидации с описанием неверной части
+func ValidatePassport(field, passport string) (string, string, error) {
+	parts := strings.Split(passport, " ")
+	if len(parts) != 2 {
+		return "", "", domain.NewValidationError(field, "expected format: 4 digits, space, 6 digits")
+	}
+
+	vErr := &domain.ValidationError{}
+	if err := ValidateDigits(parts[0], 4); err != nil {
+		vErr.Add(field+".series", err.Error())
+	}
+	if err := ValidateDigits(parts[1], 6); err != nil {
+		vErr.Add(field+".number", err.Error())
+	}
+	if vErr.HasErrors() {
+		return "", "", vErr
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// MaxTaskNameLength - длина поля name_task в таблице tasks (VARCHAR(100))
+const MaxTaskNameLength = 100
+
+// ValidateTaskName проверяет, что название задачи не пустое и помещается в name_task.
+// Названия клиентов и проектов ограничены той же длиной.
+func ValidateTaskName(field, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return domain.NewValidationError(field, "must not be empty")
+	}
+	if utf8.RuneCountInString(name) > MaxTaskNameLength {
+		return domain.NewValidationError(field, "must be at most "+strconv.Itoa(MaxTaskNameLength)+" characters")
+	}
+	return nil
+}
+
+// MaxTagLength - длина поля name в таблице tags (VARCHAR(50))
+const MaxTagLength = 50
+
+// NormalizeTags приводит теги к нижнему регистру, убирает пробелы по краям и повторы.
+// Запятая в теге запрещена, потому что в query-параметре tags теги перечисляются через запятую.
+func NormalizeTags(field string, tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return nil, domain.NewValidationError(field, "must not be empty")
+	}
+
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		switch {
+		case tag == "":
+			return nil, domain.NewValidationError(field, "tag must not be empty")
+		case utf8.RuneCountInString(tag) > MaxTagLength:
+			return nil, domain.NewValidationError(field, "tag must be at most "+strconv.Itoa(MaxTagLength)+" characters")
+		case strings.Contains(tag, ","):
+			return nil, domain.NewValidationError(field, "tag must not contain commas")
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized, nil
+}
+
+// границы длины пароля; bcrypt учитывает только первые 72 байта
+const (
+	MinPasswordLength = 8
+	MaxPasswordLength = 72
+)
+
+// ValidateCredentials проверяет логин (3-50 символов: буквы, цифры, точка, дефис, подчеркивание) и пароль
+func ValidateCredentials(login, password string) error {
+	vErr := &domain.ValidationError{}
+
+	length := utf8.RuneCountInString(login)
+	switch {
+	case length < 3 || length > 50:
+		vErr.Add("login", "must be between 3 and 50 characters")
+	case strings.IndexFunc(login, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("._-", r)
+	}) >= 0:
+		vErr.Add("login", "may contain only letters, digits, '.', '_' and '-'")
+	}
+
+	if len(password) < MinPasswordLength || len(password) > MaxPasswordLength {
+		vErr.Add("password", fmt.Sprintf("must be between %d and %d bytes", MinPasswordLength, MaxPasswordLength))
+	}
+
+	if vErr.HasErrors() {
+		return vErr
+	}
+	return nil
+}
+
+// NormalizeScopes проверяет права ключа API и возвращает их без повторов в порядке auth.Scopes
+func NormalizeScopes(field string, scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, domain.NewValidationError(field, "must not be empty")
+	}
+
+	requested := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		if !auth.ValidScope(scope) {
+			return nil, domain.NewValidationError(field, fmt.Sprintf("unknown scope %q, expected: %s", scope, strings.Join(auth.Scopes, ", ")))
+		}
+		requested[scope] = true
+	}
+
+	normalized := make([]string, 0, len(requested))
+	for _, scope := range auth.Scopes {
+		if requested[scope] {
+			normalized = append(normalized, scope)
+		}
+	}
+	return normalized, nil
+}
+
 func GenerateRandomString(length int) string {
 	const letters = "abcdefghijklmnopqrstuvwxyz"
 
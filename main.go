@@ -1,17 +1,67 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"os"
+	"time-tracker/internal/cli"
+	"time-tracker/internal/config"
 	"time-tracker/internal/server"
 )
 
 //	@title			Тайм-Трекер API
 //	@version		1.0
+//	@description	Все ошибки возвращаются в едином формате: {"error": {"code": "...", "message": "...", "details": {...}}}.
+//	@description	code - машинночитаемый код ошибки, details - описание ошибок по полям (только для validation_failed).
+//	@description	Кроме /auth/*, проверок состояния и календарной подписки, запросы требуют заголовок Authorization: Bearer <access_token> из POST /auth/login
+//	@description	или Authorization: ApiKey <key> из POST /users/{userID}/api-keys.
 
-// @host		localhost:8080
+//	@securityDefinitions.apikey	BearerAuth
+//	@in							header
+//	@name						Authorization
+//	@description				Токен доступа "Bearer <access_token>" или ключ API "ApiKey <key>"
 
 func main() {
-	err := server.StartServer()
+	configFile := flag.String("config", "", "файл переменных окружения (по умолчанию CONFIG_FILE или .env текущего каталога)")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "использование: time-tracker [--config файл] [import ... | migrate ... | credentials ...]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	conf, err := config.Load(*configFile)
 	if err != nil {
+		fmt.Fprintln(os.Stderr, "Ошибка чтения конфигурации:", err)
+		os.Exit(1)
+	}
+
+	// подкоманды выполняются вместо запуска сервера и завершают работу
+	switch flag.Arg(0) {
+	case "import":
+		if err := cli.Import(conf, flag.Args()[1:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, "Ошибка импорта:", err)
+			os.Exit(1)
+		}
+		return
+	case "migrate":
+		if err := cli.Migrate(conf, flag.Args()[1:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, "Ошибка миграции:", err)
+			os.Exit(1)
+		}
+		return
+	case "credentials":
+		if err := cli.Credentials(conf, flag.Args()[1:], os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, "Ошибка сохранения учетных данных:", err)
+			os.Exit(1)
+		}
+		return
+	case "":
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := server.StartServer(conf); err != nil {
 		panic(err)
 	}
 }
@@ -1 +1 @@
-DROP TABLE IF EXISTS tasks;
\ No newline at end of file
+DROP TABLE IF EXISTS tasks;
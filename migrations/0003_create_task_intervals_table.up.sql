@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS task_intervals (
+                       id SERIAL PRIMARY KEY,
+                       task_id INT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+                       start_time TIMESTAMP NOT NULL,
+                       end_time TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS task_intervals_task_id_idx ON task_intervals (task_id);
+
+-- уже запущенные и завершенные задачи получают по одному интервалу
+INSERT INTO task_intervals (task_id, start_time, end_time)
+SELECT id, start_time, end_time FROM tasks WHERE start_time IS NOT NULL;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS tasks_user_id_idx ON tasks (user_id);
\ No newline at end of file
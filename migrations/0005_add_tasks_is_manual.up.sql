@@ -0,0 +1,2 @@
+-- задачи, время которых внесено или исправлено вручную, а не таймером
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS is_manual BOOLEAN NOT NULL DEFAULT FALSE;
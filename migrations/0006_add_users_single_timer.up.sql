@@ -0,0 +1,2 @@
+-- политика одного таймера: при старте или возобновлении задачи запущенные задачи пользователя завершаются
+ALTER TABLE users ADD COLUMN IF NOT EXISTS single_timer BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS task_intervals_open_idx;
@@ -0,0 +1,2 @@
+-- у задачи не может быть больше одного открытого интервала
+CREATE UNIQUE INDEX IF NOT EXISTS task_intervals_open_idx ON task_intervals (task_id) WHERE end_time IS NULL;
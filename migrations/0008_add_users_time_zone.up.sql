@@ -0,0 +1,2 @@
+-- часовой пояс пользователя для отчетов (имя из pg_timezone_names)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS time_zone TEXT NOT NULL DEFAULT 'UTC';
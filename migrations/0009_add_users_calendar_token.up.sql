@@ -0,0 +1,2 @@
+-- хеш секретного токена подписки на календарь (сам токен не хранится)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS calendar_token_hash TEXT;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS tasks_user_external_id_idx;
+ALTER TABLE tasks DROP COLUMN IF EXISTS external_id;
@@ -0,0 +1,3 @@
+-- идентификатор записи во внешнем трекере (UID события или строки CSV), по нему импорт пропускает уже загруженные записи
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS external_id TEXT;
+CREATE UNIQUE INDEX IF NOT EXISTS tasks_user_external_id_idx ON tasks (user_id, external_id) WHERE external_id IS NOT NULL;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS clients (
+                       id SERIAL PRIMARY KEY,
+                       name VARCHAR(100) NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS clients_name_idx ON clients (LOWER(name));
+
+-- проект может быть без клиента (внутренние проекты); клиента с проектами удалить нельзя
+CREATE TABLE IF NOT EXISTS projects (
+                       id SERIAL PRIMARY KEY,
+                       client_id INT REFERENCES clients(id) ON DELETE RESTRICT,
+                       name VARCHAR(100) NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS projects_client_name_idx ON projects (COALESCE(client_id, 0), LOWER(name));
+CREATE INDEX IF NOT EXISTS projects_client_id_idx ON projects (client_id);
+
+-- при удалении проекта задачи остаются без проекта
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS project_id INT REFERENCES projects(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS tasks_project_id_idx ON tasks (project_id);
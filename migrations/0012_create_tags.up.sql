@@ -0,0 +1,13 @@
+-- теги общие для всех пользователей, названия хранятся в нижнем регистре
+CREATE TABLE IF NOT EXISTS tags (
+                       id SERIAL PRIMARY KEY,
+                       name VARCHAR(50) NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS task_tags (
+                       task_id INT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+                       tag_id INT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+                       PRIMARY KEY (task_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS task_tags_tag_id_idx ON task_tags (tag_id);
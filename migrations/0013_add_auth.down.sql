@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS refresh_tokens;
+DROP INDEX IF EXISTS users_login_idx;
+ALTER TABLE users DROP COLUMN IF EXISTS role;
+ALTER TABLE users DROP COLUMN IF EXISTS password_hash;
+ALTER TABLE users DROP COLUMN IF EXISTS login;
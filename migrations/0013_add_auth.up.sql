@@ -0,0 +1,21 @@
+-- учетные данные: пароль хранится только в виде bcrypt-хеша; пользователь без пароля войти не может
+ALTER TABLE users ADD COLUMN IF NOT EXISTS login VARCHAR(50);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_hash TEXT;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'user'
+    CHECK (role IN ('user', 'admin'));
+
+CREATE UNIQUE INDEX IF NOT EXISTS users_login_idx ON users (LOWER(login));
+
+-- refresh-токены хранятся хешами; токены одной цепочки обновлений объединены family_id,
+-- чтобы при повторном использовании отозванного токена отозвать всю цепочку
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+                       id SERIAL PRIMARY KEY,
+                       user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                       family_id UUID NOT NULL,
+                       token_hash TEXT NOT NULL UNIQUE,
+                       expires_at TIMESTAMPTZ NOT NULL,
+                       revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS refresh_tokens_family_id_idx ON refresh_tokens (family_id);
+CREATE INDEX IF NOT EXISTS refresh_tokens_user_id_idx ON refresh_tokens (user_id);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS users_manager_id_idx;
+ALTER TABLE users DROP COLUMN IF EXISTS manager_id;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_fkey;
+UPDATE users SET role = 'user' WHERE role <> 'admin';
+ALTER TABLE users ALTER COLUMN role SET DEFAULT 'user';
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('user', 'admin'));
+DROP TABLE IF EXISTS roles;
@@ -0,0 +1,24 @@
+-- роли: member работает со своими задачами, manager дополнительно видит профили и отчеты своей команды,
+-- admin управляет пользователями и справочниками и видит все данные
+CREATE TABLE IF NOT EXISTS roles (
+                       name VARCHAR(20) PRIMARY KEY,
+                       description VARCHAR(255) NOT NULL
+);
+
+INSERT INTO roles (name, description) VALUES
+    ('member', 'свои задачи, отчеты и настройки'),
+    ('manager', 'как member, плюс профили и отчеты своей команды'),
+    ('admin', 'все данные, пользователи, клиенты и проекты')
+ON CONFLICT (name) DO NOTHING;
+
+-- роль user из 0013 становится member, список ролей теперь задает таблица roles
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+UPDATE users SET role = 'member' WHERE role = 'user';
+ALTER TABLE users ALTER COLUMN role SET DEFAULT 'member';
+ALTER TABLE users ADD CONSTRAINT users_role_fkey FOREIGN KEY (role) REFERENCES roles(name);
+
+-- команда менеджера - пользователи, у которых он указан в manager_id
+ALTER TABLE users ADD COLUMN IF NOT EXISTS manager_id INT REFERENCES users(id) ON DELETE SET NULL
+    CONSTRAINT users_manager_not_self CHECK (manager_id <> id);
+
+CREATE INDEX IF NOT EXISTS users_manager_id_idx ON users (manager_id);
@@ -0,0 +1,16 @@
+-- ключи API для ботов и скриптов: хранится только хеш ключа, prefix - начало ключа, чтобы узнать его в списке.
+-- Ключ действует от имени владельца и ограничен правами scopes.
+CREATE TABLE IF NOT EXISTS api_keys (
+                       id SERIAL PRIMARY KEY,
+                       user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                       name VARCHAR(100) NOT NULL,
+                       prefix VARCHAR(16) NOT NULL,
+                       key_hash TEXT NOT NULL UNIQUE,
+                       scopes TEXT[] NOT NULL,
+                       created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                       expires_at TIMESTAMPTZ,
+                       last_used_at TIMESTAMPTZ,
+                       revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS api_keys_user_id_idx ON api_keys (user_id);
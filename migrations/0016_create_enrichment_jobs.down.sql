@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS enrichment_jobs;
+ALTER TABLE users DROP COLUMN IF EXISTS enrichment_status;
@@ -0,0 +1,18 @@
+-- состояние обогащения данных пользователя через сторонний API: pending - ждет в очереди,
+-- done - данные получены, failed - API отклонил паспорт или попытки исчерпаны
+ALTER TABLE users ADD COLUMN IF NOT EXISTS enrichment_status VARCHAR(16) NOT NULL DEFAULT 'done'
+    CHECK (enrichment_status IN ('pending', 'done', 'failed'));
+
+-- очередь обогащения: одна задача на пользователя. Обработчик забирает задачу со сроком run_at <= NOW()
+-- через FOR UPDATE SKIP LOCKED и сдвигает run_at на время обработки, поэтому задача упавшего
+-- обработчика снова становится доступной, когда это время истечет.
+CREATE TABLE IF NOT EXISTS enrichment_jobs (
+                       user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+                       attempts INT NOT NULL DEFAULT 0,
+                       run_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                       last_error TEXT,
+                       created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                       updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS enrichment_jobs_run_at_idx ON enrichment_jobs (run_at);
@@ -0,0 +1,9 @@
+// Package migrations встраивает SQL-миграции в бинарник, чтобы они не зависели от рабочего каталога
+package migrations
+
+import "embed"
+
+// FS - файлы миграций golang-migrate: {версия}_{название}.up.sql и .down.sql
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+// у каждой миграции должен быть непустой откат, иначе migrate down оставит схему в промежуточном состоянии
+func TestEveryUpHasDown(t *testing.T) {
+	ups, err := fs.Glob(FS, "*.up.sql")
+	require.NoError(t, err)
+	require.NotEmpty(t, ups)
+
+	for _, up := range ups {
+		down := strings.TrimSuffix(up, ".up.sql") + ".down.sql"
+		data, err := fs.ReadFile(FS, down)
+		if assert.NoError(t, err, down) {
+			assert.NotEmpty(t, strings.TrimSpace(string(data)), down)
+		}
+	}
+}